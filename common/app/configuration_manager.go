@@ -1,15 +1,37 @@
 package app
 
-import "todo-app--go-gin/common/postgresql"
+import (
+	"time"
+	"todo-app--go-gin/common/postgresql"
+)
 
 type ConfigurationManager struct {
 	PostgreSqlConfig postgresql.Config
+	TrashConfig      TrashConfig
+	AttachmentConfig AttachmentConfig
+}
+
+// TrashConfig controls how long deleted todos stay restorable and how often expired ones are purged.
+type TrashConfig struct {
+	RetentionPeriod time.Duration
+	PurgeInterval   time.Duration
+}
+
+// AttachmentConfig controls where uploaded files are stored and which files are accepted.
+type AttachmentConfig struct {
+	StorageDirectory    string
+	MaxSize             int64
+	AllowedContentTypes []string
 }
 
 func NewConfigurationManager() *ConfigurationManager {
 	postgreSqlConfig := getPostgreSqlConfig()
+	trashConfig := getTrashConfig()
+	attachmentConfig := getAttachmentConfig()
 	return &ConfigurationManager{
 		PostgreSqlConfig: postgreSqlConfig,
+		TrashConfig:      trashConfig,
+		AttachmentConfig: attachmentConfig,
 	}
 }
 
@@ -24,3 +46,25 @@ func getPostgreSqlConfig() postgresql.Config {
 		MaxConnectionIdleTime: "10s",
 	}
 }
+
+func getTrashConfig() TrashConfig {
+	return TrashConfig{
+		RetentionPeriod: 30 * 24 * time.Hour,
+		PurgeInterval:   time.Hour,
+	}
+}
+
+func getAttachmentConfig() AttachmentConfig {
+	return AttachmentConfig{
+		StorageDirectory: "data/attachments",
+		MaxSize:          10 * 1024 * 1024,
+		AllowedContentTypes: []string{
+			"image/png",
+			"image/jpeg",
+			"image/gif",
+			"image/webp",
+			"application/pdf",
+			"text/plain",
+		},
+	}
+}
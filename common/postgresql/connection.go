@@ -24,7 +24,7 @@ func GetConnectionPool(context context.Context, config Config) *pgxpool.Pool {
 
 	conn, err := pgxpool.ConnectConfig(context, connConfig)
 	if err != nil {
-		log.Fatal("Unable to connect to database: %v\n", err)
+		log.Fatalf("Unable to connect to database: %v\n", err)
 	}
 
 	createTables(context, conn)
@@ -53,6 +53,210 @@ func createTables(ctx context.Context, dbPool *pgxpool.Pool) {
 -- 		FOREIGN KEY (user_id) REFERENCES users(id)  
 	);
 	`
+	createProjectTableQuery := `
+	CREATE TABLE IF NOT EXISTS projects (
+		id SERIAL PRIMARY KEY,
+		user_id INT NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		color VARCHAR(16) NOT NULL DEFAULT '',
+		is_archived BOOLEAN NOT NULL DEFAULT FALSE,
+		position INT NOT NULL DEFAULT 0,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_projects_user_id ON projects (user_id);
+	`
+	alterTodoTableQuery := `
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS due_date DATE;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS due_time VARCHAR(5);
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS due_timezone VARCHAR(64);
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS project_id INT REFERENCES projects(id) ON DELETE SET NULL;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES todos(id) ON DELETE CASCADE;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS recurrence_rule VARCHAR(255);
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS series_id INT;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS occurrence_index INT NOT NULL DEFAULT 1;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 0;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS position DOUBLE PRECISION NOT NULL DEFAULT 0;
+	-- Completed todos are moved to the done status once, when the column is added. No workflow can
+	-- have been customised before then, so done is the completion status of every owner.
+	DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'todos' AND column_name = 'status') THEN
+			ALTER TABLE todos ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'todo';
+			UPDATE todos SET status = 'done' WHERE is_completed;
+		END IF;
+	END $$;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS assignee_id INT REFERENCES users(id) ON DELETE SET NULL;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS assigned_by INT REFERENCES users(id) ON DELETE SET NULL;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS estimate_minutes INT;
+	ALTER TABLE todos ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', COALESCE(title, '')), 'A') || setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+	) STORED;
+	`
+	createTagTablesQuery := `
+	CREATE TABLE IF NOT EXISTS tags (
+		id SERIAL PRIMARY KEY,
+		user_id INT NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		color VARCHAR(16) NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (user_id, name)
+	);
+	CREATE TABLE IF NOT EXISTS todo_tags (
+		todo_id INT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+		PRIMARY KEY (todo_id, tag_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_todo_tags_tag_id ON todo_tags (tag_id);
+	`
+	createTodoDependenciesTableQuery := `
+	CREATE TABLE IF NOT EXISTS todo_dependencies (
+		todo_id INT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		depends_on_id INT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		PRIMARY KEY (todo_id, depends_on_id),
+		CHECK (todo_id <> depends_on_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_todo_dependencies_depends_on_id ON todo_dependencies (depends_on_id);
+	`
+	createCommentTableQuery := `
+	CREATE TABLE IF NOT EXISTS comments (
+		id SERIAL PRIMARY KEY,
+		todo_id INT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		user_id INT NOT NULL,
+		body TEXT NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		edited_at TIMESTAMPTZ
+	);
+	CREATE INDEX IF NOT EXISTS idx_comments_todo_id_created_at ON comments (todo_id, created_at);
+	`
+	createAttachmentTableQuery := `
+	CREATE TABLE IF NOT EXISTS attachments (
+		id SERIAL PRIMARY KEY,
+		todo_id INT REFERENCES todos(id) ON DELETE SET NULL,
+		user_id INT NOT NULL,
+		file_name VARCHAR(255) NOT NULL,
+		content_type VARCHAR(127) NOT NULL,
+		size BIGINT NOT NULL,
+		blob_key VARCHAR(64) NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_attachments_todo_id ON attachments (todo_id);
+	CREATE INDEX IF NOT EXISTS idx_attachments_blob_key ON attachments (blob_key);
+	`
+	createShareTableQuery := `
+	CREATE TABLE IF NOT EXISTS shares (
+		id SERIAL PRIMARY KEY,
+		todo_id INT REFERENCES todos(id) ON DELETE CASCADE,
+		project_id INT REFERENCES projects(id) ON DELETE CASCADE,
+		user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		permission VARCHAR(16) NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		CHECK ((todo_id IS NULL) <> (project_id IS NULL))
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_shares_todo_id_user_id ON shares (todo_id, user_id) WHERE todo_id IS NOT NULL;
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_shares_project_id_user_id ON shares (project_id, user_id) WHERE project_id IS NOT NULL;
+	CREATE INDEX IF NOT EXISTS idx_shares_user_id ON shares (user_id);
+	`
+	createTodoHistoryTableQuery := `
+	CREATE TABLE IF NOT EXISTS todo_history (
+		id SERIAL PRIMARY KEY,
+		todo_id INT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		user_id INT NOT NULL,
+		action VARCHAR(16) NOT NULL,
+		changes JSONB NOT NULL DEFAULT '[]',
+		snapshot JSONB NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_todo_history_todo_id ON todo_history (todo_id, id);
+	-- Completion timestamps are backfilled once, when the columns are added, from the last revision
+	-- that completed the todo. The history table has to exist by then, which is why this is not part
+	-- of the other todo columns.
+	DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'todos' AND column_name = 'completed_at') THEN
+			ALTER TABLE todos ADD COLUMN completed_at TIMESTAMPTZ;
+			ALTER TABLE todos ADD COLUMN completed_by INT REFERENCES users(id) ON DELETE SET NULL;
+			UPDATE todos SET completed_at = COALESCE((SELECT MAX(todo_history.created_at) FROM todo_history
+				WHERE todo_history.todo_id = todos.id AND todo_history.changes @> '[{"field": "isCompleted", "newValue": true}]'), todos.updated_at)
+			WHERE is_completed;
+		END IF;
+	END $$;
+	CREATE INDEX IF NOT EXISTS idx_todos_user_id_completed_at ON todos (user_id, completed_at);
+	`
+	createTimeEntryTableQuery := `
+	CREATE TABLE IF NOT EXISTS time_entries (
+		id SERIAL PRIMARY KEY,
+		todo_id INT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		user_id INT NOT NULL,
+		started_at TIMESTAMPTZ NOT NULL,
+		ended_at TIMESTAMPTZ,
+		note TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		CHECK (ended_at IS NULL OR ended_at >= started_at)
+	);
+	CREATE INDEX IF NOT EXISTS idx_time_entries_todo_id ON time_entries (todo_id, started_at);
+	CREATE INDEX IF NOT EXISTS idx_time_entries_user_id_started_at ON time_entries (user_id, started_at);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries (user_id) WHERE ended_at IS NULL;
+	`
+	createTemplateTableQuery := `
+	CREATE TABLE IF NOT EXISTS templates (
+		id SERIAL PRIMARY KEY,
+		user_id INT NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		items JSONB NOT NULL DEFAULT '[]',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_templates_user_id ON templates (user_id);
+	`
+	createSavedFilterTableQuery := `
+	CREATE TABLE IF NOT EXISTS saved_filters (
+		id SERIAL PRIMARY KEY,
+		user_id INT NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		expression TEXT NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (user_id, name)
+	);
+	`
+	createWorkflowTablesQuery := `
+	CREATE TABLE IF NOT EXISTS workflow_statuses (
+		user_id INT NOT NULL,
+		status_key VARCHAR(32) NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		is_done BOOLEAN NOT NULL DEFAULT FALSE,
+		position INT NOT NULL,
+		PRIMARY KEY (user_id, status_key)
+	);
+	CREATE TABLE IF NOT EXISTS workflow_transitions (
+		user_id INT NOT NULL,
+		from_status VARCHAR(32) NOT NULL,
+		to_status VARCHAR(32) NOT NULL,
+		PRIMARY KEY (user_id, from_status, to_status),
+		FOREIGN KEY (user_id, from_status) REFERENCES workflow_statuses(user_id, status_key) ON DELETE CASCADE,
+		FOREIGN KEY (user_id, to_status) REFERENCES workflow_statuses(user_id, status_key) ON DELETE CASCADE
+	);
+	`
+	createTodoIndexesQuery := `
+	CREATE INDEX IF NOT EXISTS idx_todos_user_id_created_at ON todos (user_id, created_at);
+	CREATE INDEX IF NOT EXISTS idx_todos_user_id_updated_at ON todos (user_id, updated_at);
+	CREATE INDEX IF NOT EXISTS idx_todos_user_id_due_date ON todos (user_id, due_date);
+	CREATE INDEX IF NOT EXISTS idx_todos_user_id_position ON todos (user_id, position);
+	CREATE INDEX IF NOT EXISTS idx_todos_user_id_status ON todos (user_id, status);
+	CREATE INDEX IF NOT EXISTS idx_todos_project_id ON todos (project_id);
+	CREATE INDEX IF NOT EXISTS idx_todos_parent_id ON todos (parent_id);
+	CREATE INDEX IF NOT EXISTS idx_todos_assignee_id ON todos (assignee_id) WHERE assignee_id IS NOT NULL;
+	CREATE INDEX IF NOT EXISTS idx_todos_series_id ON todos (series_id, occurrence_index);
+	CREATE INDEX IF NOT EXISTS idx_todos_search_vector ON todos USING GIN (search_vector);
+	CREATE INDEX IF NOT EXISTS idx_todos_deleted_at ON todos (deleted_at) WHERE deleted_at IS NOT NULL;
+	`
 
 	_, err := dbPool.Exec(ctx, createUserTableQuery)
 	if err != nil {
@@ -64,5 +268,70 @@ func createTables(ctx context.Context, dbPool *pgxpool.Pool) {
 		log.Fatalf("Failed to create todo table: %v", err)
 	}
 
+	_, err = dbPool.Exec(ctx, createProjectTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create project table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, alterTodoTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to alter todo table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createTagTablesQuery)
+	if err != nil {
+		log.Fatalf("Failed to create tag tables: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createTodoDependenciesTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create todo dependencies table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createCommentTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create comment table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createAttachmentTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create attachment table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createShareTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create share table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createTodoHistoryTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create todo history table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createTimeEntryTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create time entry table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createTemplateTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create template table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createSavedFilterTableQuery)
+	if err != nil {
+		log.Fatalf("Failed to create saved filter table: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createWorkflowTablesQuery)
+	if err != nil {
+		log.Fatalf("Failed to create workflow tables: %v", err)
+	}
+
+	_, err = dbPool.Exec(ctx, createTodoIndexesQuery)
+	if err != nil {
+		log.Fatalf("Failed to create todo indexes: %v", err)
+	}
+
 	log.Println("Tables created or already exist.")
 }
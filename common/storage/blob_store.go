@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("Blob not found")
+var ErrBlobTooLarge = errors.New("Blob exceeds the maximum size")
+
+// BlobStore keeps file contents under keys derived from the content itself, so storing the same
+// content twice returns the same key and keeps a single copy.
+type BlobStore interface {
+	// Put stores content and returns its key and size. Content longer than maxSize is discarded
+	// and ErrBlobTooLarge is returned.
+	Put(content io.Reader, maxSize int64) (string, int64, error)
+	Open(key string) (io.ReadCloser, error)
+	// Delete removes the blob with the given key. Deleting a blob that does not exist is not an error.
+	Delete(key string) error
+}
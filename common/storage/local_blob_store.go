@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var blobKeyPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// LocalBlobStore stores blobs as files named by the SHA-256 of their content, spread over
+// subdirectories by the first two characters of the key.
+type LocalBlobStore struct {
+	directory string
+}
+
+func NewLocalBlobStore(directory string) (*LocalBlobStore, error) {
+	err := os.MkdirAll(directory, 0o750)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while creating blob directory %s: %v", directory, err))
+	}
+
+	return &LocalBlobStore{directory: directory}, nil
+}
+
+func (localBlobStore *LocalBlobStore) Put(content io.Reader, maxSize int64) (string, int64, error) {
+	tempFile, err := os.CreateTemp(localBlobStore.directory, "upload-*")
+	if err != nil {
+		return "", 0, errors.New(fmt.Sprintf("Error while creating blob: %v", err))
+	}
+	defer os.Remove(tempFile.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), io.LimitReader(content, maxSize+1))
+	closeErr := tempFile.Close()
+	if err != nil {
+		return "", 0, errors.New(fmt.Sprintf("Error while writing blob: %v", err))
+	} else if closeErr != nil {
+		return "", 0, errors.New(fmt.Sprintf("Error while writing blob: %v", closeErr))
+	}
+
+	if size > maxSize {
+		return "", 0, ErrBlobTooLarge
+	}
+
+	key := hex.EncodeToString(hash.Sum(nil))
+	blobPath := localBlobStore.blobPath(key)
+	if _, err := os.Stat(blobPath); err == nil {
+		return key, size, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(blobPath), 0o750)
+	if err != nil {
+		return "", 0, errors.New(fmt.Sprintf("Error while creating blob directory: %v", err))
+	}
+
+	err = os.Rename(tempFile.Name(), blobPath)
+	if err != nil {
+		return "", 0, errors.New(fmt.Sprintf("Error while storing blob: %v", err))
+	}
+
+	return key, size, nil
+}
+
+func (localBlobStore *LocalBlobStore) Open(key string) (io.ReadCloser, error) {
+	if !blobKeyPattern.MatchString(key) {
+		return nil, ErrBlobNotFound
+	}
+
+	file, err := os.Open(localBlobStore.blobPath(key))
+	if os.IsNotExist(err) {
+		return nil, ErrBlobNotFound
+	} else if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while opening blob: %v", err))
+	}
+
+	return file, nil
+}
+
+func (localBlobStore *LocalBlobStore) Delete(key string) error {
+	if !blobKeyPattern.MatchString(key) {
+		return nil
+	}
+
+	err := os.Remove(localBlobStore.blobPath(key))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.New(fmt.Sprintf("Error while deleting blob: %v", err))
+	}
+
+	return nil
+}
+
+func (localBlobStore *LocalBlobStore) blobPath(key string) string {
+	return filepath.Join(localBlobStore.directory, key[:2], key)
+}
@@ -0,0 +1,119 @@
+package filterexpr
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+const maxTermCount = 20
+
+// Term is a field and the values it may have. A todo matches a term when it matches any of the values.
+type Term struct {
+	Field  string
+	Values []string
+}
+
+// Parse reads a filter expression such as `priority:high,urgent due:this-week done:false`. An expression
+// is a list of field:value terms that all have to match, optionally joined by AND. A term matches any
+// of its comma separated values, and values containing spaces or commas are written in double quotes,
+// as in title:"weekly report". Fields are case-insensitive and can only be used once.
+func Parse(expression string) ([]Term, error) {
+	words, err := splitWords(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	var terms []Term
+	usedFields := map[string]bool{}
+	for i, word := range words {
+		if strings.EqualFold(word, "AND") && i > 0 && i < len(words)-1 {
+			continue
+		}
+		if strings.EqualFold(word, "OR") {
+			return nil, errors.New("OR is only supported between values of one field, as in priority:high,urgent")
+		}
+
+		field, value, found := strings.Cut(word, ":")
+		if !found || field == "" || value == "" {
+			return nil, errors.New(fmt.Sprintf("Filter term %s must be in field:value format", word))
+		}
+
+		field = strings.ToLower(field)
+		if usedFields[field] {
+			return nil, errors.New(fmt.Sprintf("Filter field %s can only be used once", field))
+		}
+		usedFields[field] = true
+
+		values, err := splitValues(value)
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, Term{Field: field, Values: values})
+	}
+
+	if len(terms) == 0 {
+		return nil, errors.New("Filter expression cannot be empty")
+	}
+
+	if len(terms) > maxTermCount {
+		return nil, errors.New(fmt.Sprintf("Filter expression cannot have more than %d terms", maxTermCount))
+	}
+
+	return terms, nil
+}
+
+// splitWords splits an expression on whitespace outside of double quotes, keeping the quotes.
+func splitWords(expression string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	quoted := false
+	for _, character := range expression {
+		switch {
+		case character == '"':
+			quoted = !quoted
+			word.WriteRune(character)
+		case !quoted && (character == ' ' || character == '\t' || character == '\n'):
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		default:
+			word.WriteRune(character)
+		}
+	}
+
+	if quoted {
+		return nil, errors.New("Filter expression has an unterminated quote")
+	}
+
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
+
+// splitValues splits the value of a term on commas outside of double quotes and removes the quotes.
+func splitValues(value string) ([]string, error) {
+	var values []string
+	var current strings.Builder
+	quoted := false
+	for _, character := range value + "," {
+		switch {
+		case character == '"':
+			quoted = !quoted
+		case character == ',' && !quoted:
+			if strings.TrimSpace(current.String()) == "" {
+				return nil, errors.New(fmt.Sprintf("Filter value %s has an empty entry", value))
+			}
+			values = append(values, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(character)
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,391 @@
+package quickadd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	KindDate       = "date"
+	KindTime       = "time"
+	KindTag        = "tag"
+	KindPriority   = "priority"
+	KindRecurrence = "recurrence"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04"
+)
+
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+// weekdays only holds full names since abbreviations such as sat and sun are common words in titles.
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var workweek = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+var weekdayCodes = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+var months = map[string]time.Month{
+	"january":   time.January,
+	"jan":       time.January,
+	"february":  time.February,
+	"feb":       time.February,
+	"march":     time.March,
+	"mar":       time.March,
+	"april":     time.April,
+	"apr":       time.April,
+	"may":       time.May,
+	"june":      time.June,
+	"jun":       time.June,
+	"july":      time.July,
+	"jul":       time.July,
+	"august":    time.August,
+	"aug":       time.August,
+	"september": time.September,
+	"sep":       time.September,
+	"october":   time.October,
+	"oct":       time.October,
+	"november":  time.November,
+	"nov":       time.November,
+	"december":  time.December,
+	"dec":       time.December,
+}
+
+var frequencies = map[string]string{
+	"day":   "DAILY",
+	"week":  "WEEKLY",
+	"month": "MONTHLY",
+	"year":  "YEARLY",
+}
+
+// Token is a part of the input that was recognised, with the value it was read as.
+type Token struct {
+	Text  string
+	Kind  string
+	Value string
+}
+
+// Result is what Parse recognised in a line. Words that are not recognised make up the title.
+type Result struct {
+	Title          string
+	DueDate        *string
+	DueTime        *string
+	Priority       string
+	Tags           []string
+	RecurrenceRule *string
+	Tokens         []Token
+}
+
+// Parse reads a single line such as "Pay rent tomorrow 9am #home !high every month". It understands:
+//
+//   - dates: today, tomorrow, weekday names (the next such day after today), next week (next Monday),
+//     in N days/weeks/months, YYYY-MM-DD and month names with a day such as jun 5 (the next such date)
+//   - times: 9am, 9:30pm, 21:00, noon and midnight, optionally preceded by at
+//   - tags: #name
+//   - priorities: !none, !low, !medium, !high and !urgent
+//   - recurrence: every day/week/month/year, every other week, every N days, every weekday and
+//     every monday, returned as an RRULE
+//
+// Only the first date, time, priority and recurrence are used; later ones stay in the title. Relative
+// dates are resolved against now, so the result only depends on the input and now. A time without a
+// date falls on today when it is still ahead and on tomorrow otherwise, and a recurrence without a date
+// starts on its first occurrence from today.
+func Parse(text string, now time.Time) Result {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	words := strings.Fields(text)
+
+	var result Result
+	var dueDate *time.Time
+	var dueTime *time.Time
+	var recurrenceWeekdays []time.Weekday
+	var titleWords []string
+	for i := 0; i < len(words); {
+		if tag, found := parseTag(words[i]); found {
+			if !containsFold(result.Tags, tag) {
+				result.Tags = append(result.Tags, tag)
+			}
+			result.Tokens = append(result.Tokens, Token{Text: words[i], Kind: KindTag, Value: tag})
+			i++
+			continue
+		}
+
+		if result.Priority == "" {
+			if priority, found := parsePriority(words[i]); found {
+				result.Priority = priority
+				result.Tokens = append(result.Tokens, Token{Text: words[i], Kind: KindPriority, Value: priority})
+				i++
+				continue
+			}
+		}
+
+		if result.RecurrenceRule == nil {
+			if rule, ruleWeekdays, count := parseRecurrence(words[i:]); count > 0 {
+				result.RecurrenceRule = &rule
+				recurrenceWeekdays = ruleWeekdays
+				result.Tokens = append(result.Tokens, Token{Text: strings.Join(words[i:i+count], " "), Kind: KindRecurrence, Value: rule})
+				i += count
+				continue
+			}
+		}
+
+		if dueDate == nil {
+			if date, count := parseDate(words[i:], today); count > 0 {
+				dueDate = &date
+				result.Tokens = append(result.Tokens, Token{Text: strings.Join(words[i:i+count], " "), Kind: KindDate, Value: date.Format(dateLayout)})
+				i += count
+				continue
+			}
+		}
+
+		if dueTime == nil {
+			if clock, count := parseTime(words[i:]); count > 0 {
+				dueTime = &clock
+				result.Tokens = append(result.Tokens, Token{Text: strings.Join(words[i:i+count], " "), Kind: KindTime, Value: clock.Format(timeLayout)})
+				i += count
+				continue
+			}
+		}
+
+		titleWords = append(titleWords, words[i])
+		i++
+	}
+
+	if dueDate == nil && result.RecurrenceRule != nil {
+		date := today
+		for days := 0; days < 7 && len(recurrenceWeekdays) > 0; days++ {
+			date = today.AddDate(0, 0, days)
+			if slices.Contains(recurrenceWeekdays, date.Weekday()) {
+				break
+			}
+		}
+		dueDate = &date
+	}
+
+	if dueDate == nil && dueTime != nil {
+		date := today
+		if dueTime.Hour()*60+dueTime.Minute() <= now.Hour()*60+now.Minute() {
+			date = today.AddDate(0, 0, 1)
+		}
+		dueDate = &date
+	}
+
+	if dueDate != nil {
+		formattedDueDate := dueDate.Format(dateLayout)
+		result.DueDate = &formattedDueDate
+	}
+
+	if dueTime != nil {
+		formattedDueTime := dueTime.Format(timeLayout)
+		result.DueTime = &formattedDueTime
+	}
+
+	result.Title = strings.Join(titleWords, " ")
+
+	return result
+}
+
+func parseTag(word string) (string, bool) {
+	tag := strings.TrimRight(strings.TrimPrefix(word, "#"), ",;")
+	if !strings.HasPrefix(word, "#") || tag == "" {
+		return "", false
+	}
+
+	return tag, true
+}
+
+func parsePriority(word string) (string, bool) {
+	if !strings.HasPrefix(word, "!") {
+		return "", false
+	}
+
+	name := normalize(strings.TrimPrefix(word, "!"))
+	for _, priorityName := range priorityNames {
+		if name == priorityName {
+			return priorityName, true
+		}
+	}
+
+	return "", false
+}
+
+// parseRecurrence reads a recurrence starting with every and returns the rule, the weekdays a weekly
+// rule is limited to and the number of words it used, or zero when there is no recurrence.
+func parseRecurrence(words []string) (string, []time.Weekday, int) {
+	if len(words) < 2 || normalize(words[0]) != "every" {
+		return "", nil, 0
+	}
+
+	unit := normalize(words[1])
+	if frequency, found := frequencies[unit]; found {
+		return "FREQ=" + frequency, nil, 2
+	}
+
+	if unit == "weekday" {
+		return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", workweek, 2
+	}
+
+	if weekday, found := weekdays[unit]; found {
+		return "FREQ=WEEKLY;BYDAY=" + weekdayCodes[weekday], []time.Weekday{weekday}, 2
+	}
+
+	if len(words) < 3 {
+		return "", nil, 0
+	}
+
+	interval, err := strconv.Atoi(unit)
+	if unit == "other" {
+		interval, err = 2, nil
+	}
+	frequency, found := frequencies[strings.TrimSuffix(normalize(words[2]), "s")]
+	if err != nil || interval < 1 || !found {
+		return "", nil, 0
+	}
+
+	return fmt.Sprintf("FREQ=%s;INTERVAL=%d", frequency, interval), nil, 3
+}
+
+// parseDate reads a date, optionally preceded by on, and returns it with the number of words it used,
+// or zero when there is no date.
+func parseDate(words []string, today time.Time) (time.Time, int) {
+	if normalize(words[0]) == "on" && len(words) > 1 {
+		if date, count := parseDate(words[1:], today); count > 0 {
+			return date, count + 1
+		}
+		return time.Time{}, 0
+	}
+
+	word := normalize(words[0])
+	switch word {
+	case "today":
+		return today, 1
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), 1
+	}
+
+	if date, err := time.Parse(dateLayout, word); err == nil {
+		return date, 1
+	}
+
+	if weekday, found := weekdays[word]; found {
+		return nextWeekday(today, weekday), 1
+	}
+
+	if len(words) < 2 {
+		return time.Time{}, 0
+	}
+
+	next := normalize(words[1])
+	if word == "next" {
+		if next == "week" {
+			return nextWeekday(today, time.Monday), 2
+		}
+		if weekday, found := weekdays[next]; found {
+			return nextWeekday(today, weekday), 2
+		}
+	}
+
+	if month, found := months[word]; found {
+		if day, err := strconv.Atoi(strings.TrimRight(next, "stndrh")); err == nil {
+			if date, valid := nextMonthDay(today, month, day); valid {
+				return date, 2
+			}
+		}
+	}
+
+	if word == "in" && len(words) > 2 {
+		amount, err := strconv.Atoi(next)
+		unit := strings.TrimSuffix(normalize(words[2]), "s")
+		if err == nil && amount > 0 {
+			switch unit {
+			case "day":
+				return today.AddDate(0, 0, amount), 3
+			case "week":
+				return today.AddDate(0, 0, 7*amount), 3
+			case "month":
+				return today.AddDate(0, amount, 0), 3
+			}
+		}
+	}
+
+	return time.Time{}, 0
+}
+
+// parseTime reads a time of day, optionally preceded by at, and returns it with the number of words
+// it used, or zero when there is no time.
+func parseTime(words []string) (time.Time, int) {
+	if normalize(words[0]) == "at" && len(words) > 1 {
+		if clock, count := parseTime(words[1:]); count > 0 {
+			return clock, count + 1
+		}
+		return time.Time{}, 0
+	}
+
+	word := normalize(words[0])
+	switch word {
+	case "noon":
+		return time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC), 1
+	case "midnight":
+		return time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC), 1
+	}
+
+	for _, layout := range []string{"3pm", "3:04pm", "15:04"} {
+		if clock, err := time.Parse(layout, word); err == nil {
+			return clock, 1
+		}
+	}
+
+	return time.Time{}, 0
+}
+
+// nextWeekday returns the first date after day that falls on weekday.
+func nextWeekday(day time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday)-int(day.Weekday())+6)%7 + 1
+	return day.AddDate(0, 0, days)
+}
+
+// nextMonthDay returns the next date on or after today with the given month and day.
+func nextMonthDay(today time.Time, month time.Month, day int) (time.Time, bool) {
+	for year := today.Year(); year <= today.Year()+4; year++ {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if date.Month() == month && date.Day() == day && !date.Before(today) {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func normalize(word string) string {
+	return strings.ToLower(strings.TrimRight(word, ",;"))
+}
+
+func containsFold(values []string, value string) bool {
+	for _, existing := range values {
+		if strings.EqualFold(existing, value) {
+			return true
+		}
+	}
+
+	return false
+}
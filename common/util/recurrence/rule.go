@@ -0,0 +1,248 @@
+package recurrence
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyYearly  = "YEARLY"
+)
+
+// maxPeriods bounds how many periods are scanned while expanding a rule, so rules whose
+// occurrences never fall on a valid date (for example every 12 months on February 30th) terminate.
+const maxPeriods = 10000
+
+const untilLayout = "20060102"
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is the subset of an iCalendar (RFC 5545) RRULE supported for recurring todos:
+// FREQ, INTERVAL, BYDAY (daily and weekly rules only), UNTIL and COUNT.
+type Rule struct {
+	Frequency string
+	Interval  int
+	ByWeekday []time.Weekday
+	Until     *time.Time
+	Count     int
+}
+
+func Parse(rrule string) (Rule, error) {
+	rule := Rule{Interval: 1}
+	rrule = strings.TrimPrefix(strings.TrimSpace(rrule), "RRULE:")
+	if rrule == "" {
+		return Rule{}, errors.New("Recurrence rule cannot be empty")
+	}
+
+	for _, part := range strings.Split(rrule, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return Rule{}, errors.New(fmt.Sprintf("Invalid recurrence rule part %s", part))
+		}
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Frequency = strings.ToUpper(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return Rule{}, errors.New("Recurrence interval must be a positive number")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return Rule{}, errors.New("Recurrence count must be a positive number")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse(untilLayout, value[:min(len(value), len(untilLayout))])
+			if err != nil {
+				return Rule{}, errors.New("Recurrence until must be a date in YYYYMMDD format")
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				weekday, found := weekdayCodes[strings.ToUpper(code)]
+				if !found {
+					return Rule{}, errors.New(fmt.Sprintf("Unsupported recurrence weekday %s", code))
+				}
+				rule.ByWeekday = append(rule.ByWeekday, weekday)
+			}
+		default:
+			return Rule{}, errors.New(fmt.Sprintf("Unsupported recurrence rule part %s", name))
+		}
+	}
+
+	switch rule.Frequency {
+	case FrequencyDaily, FrequencyWeekly:
+	case FrequencyMonthly, FrequencyYearly:
+		if len(rule.ByWeekday) > 0 {
+			return Rule{}, errors.New("BYDAY is only supported for daily and weekly recurrence")
+		}
+	case "":
+		return Rule{}, errors.New("Recurrence rule must have a FREQ")
+	default:
+		return Rule{}, errors.New(fmt.Sprintf("Unsupported recurrence frequency %s", rule.Frequency))
+	}
+
+	if rule.Until != nil && rule.Count > 0 {
+		return Rule{}, errors.New("Recurrence rule cannot have both UNTIL and COUNT")
+	}
+
+	sort.Slice(rule.ByWeekday, func(i, j int) bool {
+		return weekdayOffset(rule.ByWeekday[i]) < weekdayOffset(rule.ByWeekday[j])
+	})
+
+	return rule, nil
+}
+
+func (rule Rule) String() string {
+	parts := []string{"FREQ=" + rule.Frequency}
+	if rule.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(rule.Interval))
+	}
+
+	if len(rule.ByWeekday) > 0 {
+		var codes []string
+		for _, weekday := range rule.ByWeekday {
+			for code, codeWeekday := range weekdayCodes {
+				if codeWeekday == weekday {
+					codes = append(codes, code)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+
+	if rule.Until != nil {
+		parts = append(parts, "UNTIL="+rule.Until.Format(untilLayout))
+	}
+
+	if rule.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(rule.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns up to limit occurrence dates of the rule, starting with start itself,
+// which always counts as the first occurrence as it does for DTSTART in RFC 5545.
+func (rule Rule) Occurrences(start time.Time, limit int) []time.Time {
+	start = truncateToDate(start)
+	if limit > rule.Count && rule.Count > 0 {
+		limit = rule.Count
+	}
+
+	occurrences := []time.Time{start}
+	for period := 0; period < maxPeriods && len(occurrences) < limit; period++ {
+		for _, candidate := range rule.periodCandidates(start, period) {
+			if !candidate.After(start) {
+				continue
+			}
+			if rule.Until != nil && candidate.After(*rule.Until) {
+				return occurrences
+			}
+
+			occurrences = append(occurrences, candidate)
+			if len(occurrences) == limit {
+				return occurrences
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// Next returns the occurrence following current, where current is the occurrenceIndex-th
+// (1-based) occurrence of the series. It reports false once the series is exhausted.
+func (rule Rule) Next(current time.Time, occurrenceIndex int) (time.Time, bool) {
+	remainingRule := rule
+	if rule.Count > 0 {
+		remainingRule.Count = rule.Count - occurrenceIndex + 1
+		if remainingRule.Count < 2 {
+			return time.Time{}, false
+		}
+	}
+
+	occurrences := remainingRule.Occurrences(current, 2)
+	if len(occurrences) < 2 {
+		return time.Time{}, false
+	}
+
+	return occurrences[1], true
+}
+
+func (rule Rule) periodCandidates(start time.Time, period int) []time.Time {
+	step := period * rule.Interval
+	switch rule.Frequency {
+	case FrequencyDaily:
+		day := start.AddDate(0, 0, step)
+		if len(rule.ByWeekday) > 0 && !containsWeekday(rule.ByWeekday, day.Weekday()) {
+			return nil
+		}
+		return []time.Time{day}
+	case FrequencyWeekly:
+		weekStart := start.AddDate(0, 0, -weekdayOffset(start.Weekday())+7*step)
+		weekdays := rule.ByWeekday
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{start.Weekday()}
+		}
+		var days []time.Time
+		for _, weekday := range weekdays {
+			days = append(days, weekStart.AddDate(0, 0, weekdayOffset(weekday)))
+		}
+		return days
+	case FrequencyMonthly:
+		return validDate(start.Year(), start.Month()+time.Month(step), start.Day())
+	case FrequencyYearly:
+		return validDate(start.Year()+step, start.Month(), start.Day())
+	}
+
+	return nil
+}
+
+// validDate skips dates that do not exist, such as February 30th, instead of letting
+// time.Date roll them into the following month.
+func validDate(year int, month time.Month, day int) []time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return nil
+	}
+
+	return []time.Time{date}
+}
+
+func truncateToDate(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekdayOffset counts days from Monday, the default RFC 5545 week start.
+func weekdayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, candidate := range weekdays {
+		if candidate == weekday {
+			return true
+		}
+	}
+
+	return false
+}
@@ -6,6 +6,21 @@ type DataResult struct {
 	Data    interface{} `json:"data"`
 }
 
+type PagedDataResult struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+type Pagination struct {
+	Total    int    `json:"total"`
+	Limit    int    `json:"limit"`
+	Offset   int    `json:"offset"`
+	Next     string `json:"next,omitempty"`
+	Previous string `json:"previous,omitempty"`
+}
+
 type Result struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
@@ -19,6 +34,15 @@ func NewDataResult(success bool, message string, data interface{}) DataResult {
 	}
 }
 
+func NewPagedDataResult(success bool, message string, data interface{}, pagination Pagination) PagedDataResult {
+	return PagedDataResult{
+		Success:    success,
+		Message:    message,
+		Data:       data,
+		Pagination: pagination,
+	}
+}
+
 func NewResult(success bool, message string) Result {
 	return Result{
 		Success: success,
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type AttachmentController struct {
+	attachmentService service.IAttachmentService
+}
+
+func NewAttachmentController(attachmentService service.IAttachmentService) *AttachmentController {
+	return &AttachmentController{attachmentService: attachmentService}
+}
+
+func (attachmentController *AttachmentController) RegisterAttachmentRoutes(router *gin.Engine) {
+	attachmentGroup := router.Group("/todos/:id/attachments")
+	{
+		attachmentGroup.Use(middlewares.Authenticate)
+		attachmentGroup.GET("", attachmentController.GetAttachments)
+		attachmentGroup.POST("", attachmentController.AddAttachment)
+		attachmentGroup.GET("/:attachmentId", attachmentController.DownloadAttachment)
+		attachmentGroup.DELETE("/:attachmentId", attachmentController.DeleteAttachment)
+	}
+}
+
+func (attachmentController *AttachmentController) GetAttachments(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	attachments, err := attachmentController.attachmentService.GetAttachments(userId, todoId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, attachments))
+}
+
+func (attachmentController *AttachmentController) AddAttachment(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Upload the attachment as multipart form field file"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Upload the attachment as multipart form field file"))
+		return
+	}
+	defer file.Close()
+
+	attachment, err := attachmentController.attachmentService.AddAttachment(request.AttachmentCreate{
+		UserId:   userId,
+		TodoId:   todoId,
+		FileName: fileHeader.Filename,
+		Content:  file,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, attachment))
+}
+
+func (attachmentController *AttachmentController) DownloadAttachment(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, attachmentId, ok := getAttachmentPathIds(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := attachmentController.attachmentService.OpenAttachment(userId, todoId, attachmentId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (attachmentController *AttachmentController) DeleteAttachment(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, attachmentId, ok := getAttachmentPathIds(ctx)
+	if !ok {
+		return
+	}
+
+	err = attachmentController.attachmentService.DeleteAttachment(userId, todoId, attachmentId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+// getAttachmentPathIds parses the todo and attachment ids of the path, responding with 400 when either is invalid.
+func getAttachmentPathIds(ctx *gin.Context) (int, int, bool) {
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return 0, 0, false
+	}
+
+	attachmentId, err := strconv.Atoi(ctx.Param("attachmentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid attachment id"))
+		return 0, 0, false
+	}
+
+	return todoId, attachmentId, true
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type CommentController struct {
+	commentService service.ICommentService
+}
+
+func NewCommentController(commentService service.ICommentService) *CommentController {
+	return &CommentController{commentService: commentService}
+}
+
+func (commentController *CommentController) RegisterCommentRoutes(router *gin.Engine) {
+	commentGroup := router.Group("/todos/:id/comments")
+	{
+		commentGroup.Use(middlewares.Authenticate)
+		commentGroup.GET("", commentController.GetComments)
+		commentGroup.POST("", commentController.AddComment)
+		commentGroup.PUT("/:commentId", commentController.UpdateComment)
+		commentGroup.DELETE("/:commentId", commentController.DeleteComment)
+	}
+}
+
+func (commentController *CommentController) GetComments(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var commentFilter request.CommentFilter
+	if err := ctx.ShouldBindQuery(&commentFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	commentPage, err := commentController.commentService.GetComments(userId, todoId, commentFilter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewPagedDataResult(true, constants.DataFetched, commentPage.Comments, newPagination(ctx, commentPage.Total, commentPage.Limit, commentPage.Offset)))
+}
+
+func (commentController *CommentController) AddComment(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var newComment request.CommentCreate
+	if err := ctx.ShouldBindJSON(&newComment); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter comment in valid format"))
+		return
+	}
+
+	newComment.UserId = userId
+	newComment.TodoId = todoId
+	comment, err := commentController.commentService.AddComment(newComment)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, comment))
+}
+
+func (commentController *CommentController) UpdateComment(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, commentId, ok := getCommentPathIds(ctx)
+	if !ok {
+		return
+	}
+
+	var updatedComment request.CommentUpdate
+	if err := ctx.ShouldBindJSON(&updatedComment); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter comment in valid format"))
+		return
+	}
+
+	comment, err := commentController.commentService.UpdateComment(userId, todoId, commentId, updatedComment)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, comment))
+}
+
+func (commentController *CommentController) DeleteComment(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, commentId, ok := getCommentPathIds(ctx)
+	if !ok {
+		return
+	}
+
+	err = commentController.commentService.DeleteComment(userId, todoId, commentId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+// getCommentPathIds parses the todo and comment ids of the path, responding with 400 when either is invalid.
+func getCommentPathIds(ctx *gin.Context) (int, int, bool) {
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return 0, 0, false
+	}
+
+	commentId, err := strconv.Atoi(ctx.Param("commentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid comment id"))
+		return 0, 0, false
+	}
+
+	return todoId, commentId, true
+}
@@ -8,3 +8,4 @@ var DataFetched = "Data fetched successfully"
 var DataAdded = "Data added successfully"
 var DataUpdated = "Data updated successfully"
 var DataDeleted = "Data deleted successfully"
+var DataRestored = "Data restored successfully"
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/service"
+)
+
+// todoETag is the strong entity tag of a todo. It leads with the version, which If-Match is checked
+// against, followed by a hash of the representation, which also changes with subtasks and blockers
+// that do not bump the version.
+func todoETag(todo response.TodoResponse) string {
+	data, _ := json.Marshal(todo)
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(`"%d-%x"`, todo.Version, sum[:8])
+}
+
+// weakETag derives an entity tag from the JSON representation of a body, for responses such as
+// todo lists that have no single version.
+func weakETag(body interface{}) string {
+	data, _ := json.Marshal(body)
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(`W/"%x"`, sum[:16])
+}
+
+// getExpectedVersion returns the todo version required by the If-Match header, or nil when there is
+// no precondition. It reports false when the header can never match a todo version.
+func getExpectedVersion(ctx *gin.Context) (*int, bool) {
+	ifMatch := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, true
+	}
+
+	if !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) {
+		return nil, false
+	}
+
+	versionTag, _, _ := strings.Cut(strings.Trim(ifMatch, `"`), "-")
+	version, err := strconv.Atoi(versionTag)
+	if err != nil {
+		return nil, false
+	}
+
+	return &version, true
+}
+
+// isNotModified reports whether the If-None-Match header matches etag, using the weak comparison
+// that RFC 9110 prescribes for If-None-Match.
+func isNotModified(ctx *gin.Context, etag string) bool {
+	ifNoneMatch := ctx.GetHeader("If-None-Match")
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func respondWithETag(ctx *gin.Context, etag string, body interface{}) {
+	ctx.Header("ETag", etag)
+	if isNotModified(ctx, etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, body)
+}
+
+func todoWriteErrorStatus(err error, defaultStatus int) int {
+	if err == service.ErrTodoVersionMismatch {
+		return http.StatusPreconditionFailed
+	}
+
+	return defaultStatus
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/service"
+)
+
+func newPagination(ctx *gin.Context, total int, limit int, offset int) results.Pagination {
+	pagination := results.Pagination{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if offset+limit < total {
+		pagination.Next = buildPageLink(ctx, limit, offset+limit)
+	}
+
+	if offset > 0 {
+		previousOffset := offset - limit
+		if previousOffset < 0 {
+			previousOffset = 0
+		}
+		pagination.Previous = buildPageLink(ctx, limit, previousOffset)
+	}
+
+	return pagination
+}
+
+func buildPageLink(ctx *gin.Context, limit int, offset int) string {
+	pageUrl := *ctx.Request.URL
+	query := pageUrl.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	pageUrl.RawQuery = query.Encode()
+
+	return pageUrl.RequestURI()
+}
+
+// queryErrorStatus answers an invalid listing request with 400 and a failed lookup with 500.
+func queryErrorStatus(err error) int {
+	if _, isValidationError := err.(service.ValidationError); isValidationError {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
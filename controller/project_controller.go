@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type ProjectController struct {
+	projectService service.IProjectService
+}
+
+func NewProjectController(projectService service.IProjectService) *ProjectController {
+	return &ProjectController{projectService: projectService}
+}
+
+func (projectController *ProjectController) RegisterProjectRoutes(router *gin.Engine) {
+	projectGroup := router.Group("/projects")
+	{
+		projectGroup.Use(middlewares.Authenticate)
+		projectGroup.GET("", projectController.GetAllProjects)
+		projectGroup.GET("/:id", projectController.GetProjectById)
+		projectGroup.POST("/", projectController.AddProject)
+		projectGroup.PUT("/:id", projectController.UpdateProject)
+		projectGroup.DELETE("/:id", projectController.DeleteProject)
+	}
+}
+
+func (projectController *ProjectController) GetAllProjects(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	includeArchived := ctx.Query("archived") == "true"
+	projects, err := projectController.projectService.GetAllProjects(userId, includeArchived)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, projects))
+}
+
+func (projectController *ProjectController) GetProjectById(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid project id"))
+		return
+	}
+
+	project, err := projectController.projectService.GetProjectById(userId, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, project))
+}
+
+func (projectController *ProjectController) AddProject(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var newProject request.ProjectCreate
+	if err := ctx.ShouldBindJSON(&newProject); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter project in valid format"))
+		return
+	}
+
+	newProject.UserId = userId
+	project, err := projectController.projectService.AddProject(newProject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, project))
+}
+
+func (projectController *ProjectController) UpdateProject(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid project id"))
+		return
+	}
+
+	var updatedProject request.ProjectUpdate
+	if err := ctx.ShouldBindJSON(&updatedProject); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	project, err := projectController.projectService.UpdateProject(userId, id, updatedProject)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, project))
+}
+
+func (projectController *ProjectController) DeleteProject(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid project id"))
+		return
+	}
+
+	err = projectController.projectService.DeleteProject(userId, id, ctx.Query("mode"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
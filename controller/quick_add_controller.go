@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type QuickAddController struct {
+	quickAddService service.IQuickAddService
+}
+
+func NewQuickAddController(quickAddService service.IQuickAddService) *QuickAddController {
+	return &QuickAddController{quickAddService: quickAddService}
+}
+
+func (quickAddController *QuickAddController) RegisterQuickAddRoutes(router *gin.Engine) {
+	quickAddGroup := router.Group("/todos")
+	{
+		quickAddGroup.Use(middlewares.Authenticate)
+		quickAddGroup.POST("/quick", quickAddController.QuickAddTodo)
+	}
+}
+
+func (quickAddController *QuickAddController) QuickAddTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var todoQuickAdd request.TodoQuickAdd
+	if err := ctx.ShouldBindJSON(&todoQuickAdd); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter quick add text in valid format"))
+		return
+	}
+
+	todoQuickAdd.UserId = userId
+	quickAddResult, err := quickAddController.quickAddService.QuickAddTodo(todoQuickAdd)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, quickAddResult))
+}
@@ -3,27 +3,63 @@ package controller
 import (
 	"github.com/gin-gonic/gin"
 	"golang.org/x/net/context"
+	"log"
+	"time"
 	"todo-app--go-gin/common/app"
 	"todo-app--go-gin/common/postgresql"
+	"todo-app--go-gin/common/storage"
 	"todo-app--go-gin/persistence"
 	"todo-app--go-gin/service"
 )
 
 type MainRouter struct {
-	authController *AuthController
-	todoController *TodoController
+	authController        *AuthController
+	todoController        *TodoController
+	tagController         *TagController
+	projectController     *ProjectController
+	workflowController    *WorkflowController
+	commentController     *CommentController
+	attachmentController  *AttachmentController
+	shareController       *ShareController
+	timeEntryController   *TimeEntryController
+	templateController    *TemplateController
+	quickAddController    *QuickAddController
+	savedFilterController *SavedFilterController
+	statsController       *StatsController
 }
 
-func NewRouter(authController *AuthController, todoController *TodoController) *MainRouter {
+func NewRouter(authController *AuthController, todoController *TodoController, tagController *TagController, projectController *ProjectController, workflowController *WorkflowController, commentController *CommentController, attachmentController *AttachmentController, shareController *ShareController, timeEntryController *TimeEntryController, templateController *TemplateController, quickAddController *QuickAddController, savedFilterController *SavedFilterController, statsController *StatsController) *MainRouter {
 	return &MainRouter{
-		authController: authController,
-		todoController: todoController,
+		authController:        authController,
+		todoController:        todoController,
+		tagController:         tagController,
+		projectController:     projectController,
+		workflowController:    workflowController,
+		commentController:     commentController,
+		attachmentController:  attachmentController,
+		shareController:       shareController,
+		timeEntryController:   timeEntryController,
+		templateController:    templateController,
+		quickAddController:    quickAddController,
+		savedFilterController: savedFilterController,
+		statsController:       statsController,
 	}
 }
 
 func (mainRouter *MainRouter) RegisterRoutes(server *gin.Engine) {
 	mainRouter.authController.RegisterAuthRoutes(server)
 	mainRouter.todoController.RegisterTodoRoutes(server)
+	mainRouter.tagController.RegisterTagRoutes(server)
+	mainRouter.projectController.RegisterProjectRoutes(server)
+	mainRouter.workflowController.RegisterWorkflowRoutes(server)
+	mainRouter.commentController.RegisterCommentRoutes(server)
+	mainRouter.attachmentController.RegisterAttachmentRoutes(server)
+	mainRouter.shareController.RegisterShareRoutes(server)
+	mainRouter.timeEntryController.RegisterTimeEntryRoutes(server)
+	mainRouter.templateController.RegisterTemplateRoutes(server)
+	mainRouter.quickAddController.RegisterQuickAddRoutes(server)
+	mainRouter.savedFilterController.RegisterSavedFilterRoutes(server)
+	mainRouter.statsController.RegisterStatsRoutes(server)
 }
 
 func InitializeRouter() *gin.Engine {
@@ -33,16 +69,65 @@ func InitializeRouter() *gin.Engine {
 	configurationManager := app.NewConfigurationManager()
 	dbPool := postgresql.GetConnectionPool(ctx, configurationManager.PostgreSqlConfig)
 
+	projectRepo := persistence.NewProjectRepository(dbPool)
+	projectService := service.NewProjectService(projectRepo)
+	projectController := NewProjectController(projectService)
+
+	workflowRepo := persistence.NewWorkflowRepository(dbPool)
+	shareRepo := persistence.NewShareRepository(dbPool)
 	todoRepo := persistence.NewTodoRepository(dbPool)
-	todoService := service.NewTodoService(todoRepo)
+	todoService := service.NewTodoService(todoRepo, projectRepo, workflowRepo, shareRepo)
 	todoController := NewTodoController(todoService)
 
+	workflowService := service.NewWorkflowService(workflowRepo, todoRepo)
+	workflowController := NewWorkflowController(workflowService)
+
+	commentRepo := persistence.NewCommentRepository(dbPool)
+	commentService := service.NewCommentService(commentRepo, todoRepo, shareRepo)
+	commentController := NewCommentController(commentService)
+
+	blobStore, err := storage.NewLocalBlobStore(configurationManager.AttachmentConfig.StorageDirectory)
+	if err != nil {
+		log.Fatalf("Failed to create attachment storage: %v", err)
+	}
+	attachmentRepo := persistence.NewAttachmentRepository(dbPool)
+	attachmentService := service.NewAttachmentService(attachmentRepo, todoRepo, shareRepo, blobStore, configurationManager.AttachmentConfig.MaxSize, configurationManager.AttachmentConfig.AllowedContentTypes)
+	attachmentController := NewAttachmentController(attachmentService)
+
+	timeEntryRepo := persistence.NewTimeEntryRepository(dbPool)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepo, todoRepo, shareRepo)
+	timeEntryController := NewTimeEntryController(timeEntryService)
+
+	trashPurgeJob := service.NewTrashPurgeJob(todoService, attachmentService, configurationManager.TrashConfig.RetentionPeriod, configurationManager.TrashConfig.PurgeInterval)
+	trashPurgeJob.Start(ctx)
+
+	tagRepo := persistence.NewTagRepository(dbPool)
+	tagService := service.NewTagService(tagRepo)
+	tagController := NewTagController(tagService)
+
+	templateRepo := persistence.NewTemplateRepository(dbPool)
+	templateService := service.NewTemplateService(templateRepo, todoRepo, projectRepo, workflowRepo, tagRepo, shareRepo)
+	templateController := NewTemplateController(templateService)
+
+	quickAddService := service.NewQuickAddService(todoService, tagService, time.Now)
+	quickAddController := NewQuickAddController(quickAddService)
+
+	savedFilterRepo := persistence.NewSavedFilterRepository(dbPool)
+	savedFilterService := service.NewSavedFilterService(savedFilterRepo, todoRepo, shareRepo, time.Now)
+	savedFilterController := NewSavedFilterController(savedFilterService)
+
+	statsService := service.NewStatsService(todoRepo, time.Now)
+	statsController := NewStatsController(statsService)
+
 	userRepo := persistence.NewUserRepository(dbPool)
 	userService := service.NewUserService(userRepo)
 	authService := service.NewAuthService(userService)
 	authController := NewAuthController(authService)
 
-	mainRouter := NewRouter(authController, todoController)
+	shareService := service.NewShareService(shareRepo, todoRepo, projectRepo, userRepo)
+	shareController := NewShareController(shareService)
+
+	mainRouter := NewRouter(authController, todoController, tagController, projectController, workflowController, commentController, attachmentController, shareController, timeEntryController, templateController, quickAddController, savedFilterController, statsController)
 	mainRouter.RegisterRoutes(server)
 
 	return server
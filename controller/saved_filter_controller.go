@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type SavedFilterController struct {
+	savedFilterService service.ISavedFilterService
+}
+
+func NewSavedFilterController(savedFilterService service.ISavedFilterService) *SavedFilterController {
+	return &SavedFilterController{savedFilterService: savedFilterService}
+}
+
+func (savedFilterController *SavedFilterController) RegisterSavedFilterRoutes(router *gin.Engine) {
+	savedFilterGroup := router.Group("/filters")
+	{
+		savedFilterGroup.Use(middlewares.Authenticate)
+		savedFilterGroup.GET("", savedFilterController.GetSavedFilters)
+		savedFilterGroup.GET("/:id", savedFilterController.GetSavedFilterById)
+		savedFilterGroup.POST("/", savedFilterController.AddSavedFilter)
+		savedFilterGroup.PUT("/:id", savedFilterController.UpdateSavedFilter)
+		savedFilterGroup.DELETE("/:id", savedFilterController.DeleteSavedFilter)
+		savedFilterGroup.GET("/:id/todos", savedFilterController.GetSavedFilterTodos)
+	}
+}
+
+func (savedFilterController *SavedFilterController) GetSavedFilters(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	savedFilters, err := savedFilterController.savedFilterService.GetSavedFilters(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, savedFilters))
+}
+
+func (savedFilterController *SavedFilterController) GetSavedFilterById(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	savedFilterId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid saved filter id"))
+		return
+	}
+
+	savedFilter, err := savedFilterController.savedFilterService.GetSavedFilterById(userId, savedFilterId)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, savedFilter))
+}
+
+func (savedFilterController *SavedFilterController) AddSavedFilter(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var savedFilterCreate request.SavedFilterCreate
+	if err := ctx.ShouldBindJSON(&savedFilterCreate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter saved filter in valid format"))
+		return
+	}
+
+	savedFilterCreate.UserId = userId
+	savedFilter, err := savedFilterController.savedFilterService.AddSavedFilter(savedFilterCreate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, savedFilter))
+}
+
+func (savedFilterController *SavedFilterController) UpdateSavedFilter(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	savedFilterId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid saved filter id"))
+		return
+	}
+
+	var savedFilterUpdate request.SavedFilterUpdate
+	if err := ctx.ShouldBindJSON(&savedFilterUpdate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter saved filter in valid format"))
+		return
+	}
+
+	savedFilter, err := savedFilterController.savedFilterService.UpdateSavedFilter(userId, savedFilterId, savedFilterUpdate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, savedFilter))
+}
+
+func (savedFilterController *SavedFilterController) DeleteSavedFilter(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	savedFilterId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid saved filter id"))
+		return
+	}
+
+	err = savedFilterController.savedFilterService.DeleteSavedFilter(userId, savedFilterId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+func (savedFilterController *SavedFilterController) GetSavedFilterTodos(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	savedFilterId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid saved filter id"))
+		return
+	}
+
+	var savedFilterEvaluate request.SavedFilterEvaluate
+	if err := ctx.ShouldBindQuery(&savedFilterEvaluate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	savedFilterEvaluate.Timezone = getTimezone(ctx, savedFilterEvaluate.Timezone)
+	todoPage, err := savedFilterController.savedFilterService.GetSavedFilterTodos(userId, savedFilterId, savedFilterEvaluate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	pagedResult := results.NewPagedDataResult(true, constants.DataFetched, todoPage.Todos, newPagination(ctx, todoPage.Total, todoPage.Limit, todoPage.Offset))
+	respondWithETag(ctx, weakETag(pagedResult), pagedResult)
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type ShareController struct {
+	shareService service.IShareService
+}
+
+func NewShareController(shareService service.IShareService) *ShareController {
+	return &ShareController{shareService: shareService}
+}
+
+func (shareController *ShareController) RegisterShareRoutes(router *gin.Engine) {
+	todoShareGroup := router.Group("/todos/:id/shares")
+	{
+		todoShareGroup.Use(middlewares.Authenticate)
+		todoShareGroup.GET("", shareController.GetTodoShares)
+		todoShareGroup.POST("", shareController.ShareTodo)
+		todoShareGroup.DELETE("/:userId", shareController.UnshareTodo)
+	}
+
+	projectShareGroup := router.Group("/projects/:id/shares")
+	{
+		projectShareGroup.Use(middlewares.Authenticate)
+		projectShareGroup.GET("", shareController.GetProjectShares)
+		projectShareGroup.POST("", shareController.ShareProject)
+		projectShareGroup.DELETE("/:userId", shareController.UnshareProject)
+	}
+}
+
+func (shareController *ShareController) GetTodoShares(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	shares, err := shareController.shareService.GetTodoShares(userId, todoId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, shares))
+}
+
+func (shareController *ShareController) ShareTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var shareCreate request.ShareCreate
+	if err := ctx.ShouldBindJSON(&shareCreate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter share in valid format"))
+		return
+	}
+
+	share, err := shareController.shareService.ShareTodo(userId, todoId, shareCreate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, share))
+}
+
+func (shareController *ShareController) UnshareTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, sharedUserId, ok := getSharePathIds(ctx, "Invalid todo id")
+	if !ok {
+		return
+	}
+
+	err = shareController.shareService.UnshareTodo(userId, todoId, sharedUserId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+func (shareController *ShareController) GetProjectShares(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	projectId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid project id"))
+		return
+	}
+
+	shares, err := shareController.shareService.GetProjectShares(userId, projectId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, shares))
+}
+
+func (shareController *ShareController) ShareProject(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	projectId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid project id"))
+		return
+	}
+
+	var shareCreate request.ShareCreate
+	if err := ctx.ShouldBindJSON(&shareCreate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter share in valid format"))
+		return
+	}
+
+	share, err := shareController.shareService.ShareProject(userId, projectId, shareCreate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, share))
+}
+
+func (shareController *ShareController) UnshareProject(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	projectId, sharedUserId, ok := getSharePathIds(ctx, "Invalid project id")
+	if !ok {
+		return
+	}
+
+	err = shareController.shareService.UnshareProject(userId, projectId, sharedUserId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+// getSharePathIds parses the todo or project id and the user id of the path, responding with 400 when either is invalid.
+func getSharePathIds(ctx *gin.Context, invalidIdMessage string) (int, int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, invalidIdMessage))
+		return 0, 0, false
+	}
+
+	sharedUserId, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid user id"))
+		return 0, 0, false
+	}
+
+	return id, sharedUserId, true
+}
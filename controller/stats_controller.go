@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type StatsController struct {
+	statsService service.IStatsService
+}
+
+func NewStatsController(statsService service.IStatsService) *StatsController {
+	return &StatsController{statsService: statsService}
+}
+
+func (statsController *StatsController) RegisterStatsRoutes(router *gin.Engine) {
+	statsGroup := router.Group("/stats")
+	{
+		statsGroup.Use(middlewares.Authenticate)
+		statsGroup.GET("", statsController.GetStats)
+	}
+}
+
+func (statsController *StatsController) GetStats(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var statsFilter request.StatsFilter
+	if err := ctx.ShouldBindQuery(&statsFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	statsFilter.Timezone = getTimezone(ctx, statsFilter.Timezone)
+	stats, err := statsController.statsService.GetStats(userId, statsFilter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, stats))
+}
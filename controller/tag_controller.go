@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type TagController struct {
+	tagService service.ITagService
+}
+
+func NewTagController(tagService service.ITagService) *TagController {
+	return &TagController{tagService: tagService}
+}
+
+func (tagController *TagController) RegisterTagRoutes(router *gin.Engine) {
+	tagGroup := router.Group("/tags")
+	{
+		tagGroup.Use(middlewares.Authenticate)
+		tagGroup.GET("", tagController.GetAllTags)
+		tagGroup.GET("/:id", tagController.GetTagById)
+		tagGroup.POST("/", tagController.AddTag)
+		tagGroup.PUT("/:id", tagController.UpdateTag)
+		tagGroup.DELETE("/:id", tagController.DeleteTag)
+	}
+}
+
+func (tagController *TagController) GetAllTags(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	tags, err := tagController.tagService.GetAllTags(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, tags))
+}
+
+func (tagController *TagController) GetTagById(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid tag id"))
+		return
+	}
+
+	tag, err := tagController.tagService.GetTagById(userId, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, tag))
+}
+
+func (tagController *TagController) AddTag(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var newTag request.TagCreate
+	if err := ctx.ShouldBindJSON(&newTag); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter tag in valid format"))
+		return
+	}
+
+	newTag.UserId = userId
+	tag, err := tagController.tagService.AddTag(newTag)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, tag))
+}
+
+func (tagController *TagController) UpdateTag(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid tag id"))
+		return
+	}
+
+	var updatedTag request.TagUpdate
+	if err := ctx.ShouldBindJSON(&updatedTag); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	tag, err := tagController.tagService.UpdateTag(userId, id, updatedTag)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, tag))
+}
+
+func (tagController *TagController) DeleteTag(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid tag id"))
+		return
+	}
+
+	err = tagController.tagService.DeleteTag(userId, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type TemplateController struct {
+	templateService service.ITemplateService
+}
+
+func NewTemplateController(templateService service.ITemplateService) *TemplateController {
+	return &TemplateController{templateService: templateService}
+}
+
+func (templateController *TemplateController) RegisterTemplateRoutes(router *gin.Engine) {
+	templateGroup := router.Group("/templates")
+	{
+		templateGroup.Use(middlewares.Authenticate)
+		templateGroup.GET("", templateController.GetTemplates)
+		templateGroup.GET("/:id", templateController.GetTemplateById)
+		templateGroup.POST("/", templateController.AddTemplate)
+		templateGroup.POST("/from-todos", templateController.AddTemplateFromTodos)
+		templateGroup.PUT("/:id", templateController.UpdateTemplate)
+		templateGroup.DELETE("/:id", templateController.DeleteTemplate)
+		templateGroup.POST("/:id/instantiate", templateController.InstantiateTemplate)
+	}
+}
+
+func (templateController *TemplateController) GetTemplates(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	templates, err := templateController.templateService.GetTemplates(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, templates))
+}
+
+func (templateController *TemplateController) GetTemplateById(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	templateId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid template id"))
+		return
+	}
+
+	template, err := templateController.templateService.GetTemplateById(userId, templateId)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, template))
+}
+
+func (templateController *TemplateController) AddTemplate(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var templateCreate request.TemplateCreate
+	if err := ctx.ShouldBindJSON(&templateCreate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter template in valid format"))
+		return
+	}
+
+	templateCreate.UserId = userId
+	template, err := templateController.templateService.AddTemplate(templateCreate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, template))
+}
+
+func (templateController *TemplateController) AddTemplateFromTodos(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var templateFromTodos request.TemplateFromTodos
+	if err := ctx.ShouldBindJSON(&templateFromTodos); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter template in valid format"))
+		return
+	}
+
+	templateFromTodos.UserId = userId
+	template, err := templateController.templateService.AddTemplateFromTodos(templateFromTodos)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, template))
+}
+
+func (templateController *TemplateController) UpdateTemplate(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	templateId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid template id"))
+		return
+	}
+
+	var templateUpdate request.TemplateUpdate
+	if err := ctx.ShouldBindJSON(&templateUpdate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter template in valid format"))
+		return
+	}
+
+	template, err := templateController.templateService.UpdateTemplate(userId, templateId, templateUpdate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, template))
+}
+
+func (templateController *TemplateController) DeleteTemplate(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	templateId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid template id"))
+		return
+	}
+
+	err = templateController.templateService.DeleteTemplate(userId, templateId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+func (templateController *TemplateController) InstantiateTemplate(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	templateId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid template id"))
+		return
+	}
+
+	var templateInstantiate request.TemplateInstantiate
+	if err := ctx.ShouldBindJSON(&templateInstantiate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter start date and project in valid format"))
+		return
+	}
+
+	todos, err := templateController.templateService.InstantiateTemplate(userId, templateId, templateInstantiate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, todos))
+}
@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type TimeEntryController struct {
+	timeEntryService service.ITimeEntryService
+}
+
+func NewTimeEntryController(timeEntryService service.ITimeEntryService) *TimeEntryController {
+	return &TimeEntryController{timeEntryService: timeEntryService}
+}
+
+func (timeEntryController *TimeEntryController) RegisterTimeEntryRoutes(router *gin.Engine) {
+	todoTimeGroup := router.Group("/todos/:id")
+	{
+		todoTimeGroup.Use(middlewares.Authenticate)
+		todoTimeGroup.GET("/time-entries", timeEntryController.GetTimeEntries)
+		todoTimeGroup.POST("/time-entries", timeEntryController.AddTimeEntry)
+		todoTimeGroup.DELETE("/time-entries/:timeEntryId", timeEntryController.DeleteTimeEntry)
+		todoTimeGroup.POST("/timer/start", timeEntryController.StartTimer)
+		todoTimeGroup.POST("/timer/stop", timeEntryController.StopTimer)
+		todoTimeGroup.GET("/time-report", timeEntryController.GetTodoTimeReport)
+	}
+
+	timeEntryGroup := router.Group("/time-entries")
+	{
+		timeEntryGroup.Use(middlewares.Authenticate)
+		timeEntryGroup.GET("/running", timeEntryController.GetRunningTimer)
+		timeEntryGroup.GET("/daily", timeEntryController.GetDailyTimeReport)
+	}
+}
+
+func (timeEntryController *TimeEntryController) GetTimeEntries(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	timeEntries, err := timeEntryController.timeEntryService.GetTimeEntries(userId, todoId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, timeEntries))
+}
+
+func (timeEntryController *TimeEntryController) AddTimeEntry(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var timeEntryCreate request.TimeEntryCreate
+	if err := ctx.ShouldBindJSON(&timeEntryCreate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter time entry in valid format"))
+		return
+	}
+
+	timeEntry, err := timeEntryController.timeEntryService.AddTimeEntry(userId, todoId, timeEntryCreate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, timeEntry))
+}
+
+func (timeEntryController *TimeEntryController) DeleteTimeEntry(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	timeEntryId, err := strconv.Atoi(ctx.Param("timeEntryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid time entry id"))
+		return
+	}
+
+	err = timeEntryController.timeEntryService.DeleteTimeEntry(userId, todoId, timeEntryId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+func (timeEntryController *TimeEntryController) StartTimer(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	timeEntry, err := timeEntryController.timeEntryService.StartTimer(userId, todoId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, timeEntry))
+}
+
+func (timeEntryController *TimeEntryController) StopTimer(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	timeEntry, err := timeEntryController.timeEntryService.StopTimer(userId, todoId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, timeEntry))
+}
+
+func (timeEntryController *TimeEntryController) GetTodoTimeReport(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todoId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	timeReport, err := timeEntryController.timeEntryService.GetTodoTimeReport(userId, todoId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, timeReport))
+}
+
+func (timeEntryController *TimeEntryController) GetRunningTimer(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	timeEntry, err := timeEntryController.timeEntryService.GetRunningTimer(userId)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, timeEntry))
+}
+
+func (timeEntryController *TimeEntryController) GetDailyTimeReport(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var reportFilter request.TimeReportFilter
+	if err := ctx.ShouldBindQuery(&reportFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	dailyReports, err := timeEntryController.timeEntryService.GetDailyTimeReport(userId, reportFilter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, dailyReports))
+}
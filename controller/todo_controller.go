@@ -8,6 +8,7 @@ import (
 	"todo-app--go-gin/common/util/results"
 	"todo-app--go-gin/controller/constants"
 	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain"
 	"todo-app--go-gin/domain/request"
 	"todo-app--go-gin/service"
 )
@@ -25,11 +26,35 @@ func (todoController *TodoController) RegisterTodoRoutes(router *gin.Engine) {
 	{
 		todoGroup.Use(middlewares.Authenticate)
 		todoGroup.GET("", todoController.GetAllTodos)
+		todoGroup.GET("/overdue", todoController.GetTodosByDueView(domain.DueViewOverdue))
+		todoGroup.GET("/today", todoController.GetTodosByDueView(domain.DueViewToday))
+		todoGroup.GET("/upcoming", todoController.GetTodosByDueView(domain.DueViewUpcoming))
+		todoGroup.GET("/trash", todoController.GetTrash)
+		todoGroup.GET("/search", todoController.SearchTodos)
+		todoGroup.GET("/board", todoController.GetBoard)
+		todoGroup.GET("/assigned", todoController.GetAssignedTodos)
 		todoGroup.GET("/:id", todoController.GetTodoById)
 		todoGroup.POST("/", todoController.AddTodo)
+		todoGroup.POST("/bulk", todoController.BulkUpdateTodos)
 		todoGroup.PUT("/:id", todoController.UpdateTodo)
+		todoGroup.PATCH("/:id", todoController.PatchTodo)
 		todoGroup.PUT("/toggle/:id", todoController.ToggleTodo)
+		todoGroup.PUT("/:id/status", todoController.ChangeTodoStatus)
+		todoGroup.PUT("/:id/assignee", todoController.AssignTodo)
+		todoGroup.GET("/:id/history", todoController.GetTodoHistory)
+		todoGroup.POST("/:id/history/:revisionId/revert", todoController.RevertTodo)
+		todoGroup.PUT("/:id/project", todoController.MoveTodoToProject)
+		todoGroup.PUT("/:id/parent", todoController.ReparentTodo)
+		todoGroup.POST("/:id/move", todoController.MoveTodo)
+		todoGroup.GET("/:id/subtasks", todoController.GetSubtasks)
+		todoGroup.POST("/:id/subtasks", todoController.AddSubtask)
+		todoGroup.GET("/:id/occurrences", todoController.GetOccurrences)
+		todoGroup.GET("/:id/dependencies", todoController.GetTodoDependencies)
+		todoGroup.POST("/:id/dependencies", todoController.AddTodoDependency)
+		todoGroup.DELETE("/:id/dependencies/:dependsOnId", todoController.RemoveTodoDependency)
+		todoGroup.POST("/:id/restore", todoController.RestoreTodo)
 		todoGroup.DELETE("/:id", todoController.DeleteTodo)
+		todoGroup.DELETE("/:id/permanent", todoController.PermanentlyDeleteTodo)
 	}
 }
 
@@ -40,13 +65,119 @@ func (todoController *TodoController) GetAllTodos(ctx *gin.Context) {
 		return
 	}
 
-	todos, err := todoController.todoService.GetAllTodos(userId)
+	var todoFilter request.TodoFilter
+	if err := ctx.ShouldBindQuery(&todoFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	todoFilter.Timezone = getTimezone(ctx, todoFilter.Timezone)
+	todoPage, err := todoController.todoService.GetTodosByFilter(userId, todoFilter)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		ctx.JSON(queryErrorStatus(err), results.NewResult(false, err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, todos))
+	pagedResult := results.NewPagedDataResult(true, constants.DataFetched, todoPage.Todos, newPagination(ctx, todoPage.Total, todoPage.Limit, todoPage.Offset))
+	respondWithETag(ctx, weakETag(pagedResult), pagedResult)
+}
+
+func (todoController *TodoController) GetAssignedTodos(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var todoFilter request.TodoFilter
+	if err := ctx.ShouldBindQuery(&todoFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	todoFilter.Timezone = getTimezone(ctx, todoFilter.Timezone)
+	todoPage, err := todoController.todoService.GetAssignedTodos(userId, todoFilter)
+	if err != nil {
+		ctx.JSON(queryErrorStatus(err), results.NewResult(false, err.Error()))
+		return
+	}
+
+	pagedResult := results.NewPagedDataResult(true, constants.DataFetched, todoPage.Todos, newPagination(ctx, todoPage.Total, todoPage.Limit, todoPage.Offset))
+	respondWithETag(ctx, weakETag(pagedResult), pagedResult)
+}
+
+func (todoController *TodoController) GetTodosByDueView(dueView string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId, err := util.GetUserIdFromContext(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+			return
+		}
+
+		var dueViewFilter request.DueViewFilter
+		if err := ctx.ShouldBindQuery(&dueViewFilter); err != nil {
+			ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+			return
+		}
+
+		dueViewFilter.Timezone = getTimezone(ctx, dueViewFilter.Timezone)
+		todoPage, err := todoController.todoService.GetTodosByDueView(userId, dueView, dueViewFilter)
+		if err != nil {
+			ctx.JSON(queryErrorStatus(err), results.NewResult(false, err.Error()))
+			return
+		}
+
+		pagedResult := results.NewPagedDataResult(true, constants.DataFetched, todoPage.Todos, newPagination(ctx, todoPage.Total, todoPage.Limit, todoPage.Offset))
+		respondWithETag(ctx, weakETag(pagedResult), pagedResult)
+	}
+}
+
+func (todoController *TodoController) SearchTodos(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var searchFilter request.TodoSearchFilter
+	if err := ctx.ShouldBindQuery(&searchFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	searchFilter.Timezone = getTimezone(ctx, searchFilter.Timezone)
+	searchPage, err := todoController.todoService.SearchTodos(userId, searchFilter)
+	if err != nil {
+		ctx.JSON(queryErrorStatus(err), results.NewResult(false, err.Error()))
+		return
+	}
+
+	pagedResult := results.NewPagedDataResult(true, constants.DataFetched, searchPage.Results, newPagination(ctx, searchPage.Total, searchPage.Limit, searchPage.Offset))
+	respondWithETag(ctx, weakETag(pagedResult), pagedResult)
+}
+
+func (todoController *TodoController) GetBoard(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var todoFilter request.TodoFilter
+	if err := ctx.ShouldBindQuery(&todoFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	todoFilter.Timezone = getTimezone(ctx, todoFilter.Timezone)
+	board, err := todoController.todoService.GetBoard(userId, todoFilter)
+	if err != nil {
+		ctx.JSON(queryErrorStatus(err), results.NewResult(false, err.Error()))
+		return
+	}
+
+	dataResult := results.NewDataResult(true, constants.DataFetched, board)
+	respondWithETag(ctx, weakETag(dataResult), dataResult)
 }
 
 func (todoController *TodoController) GetTodoById(ctx *gin.Context) {
@@ -68,7 +199,7 @@ func (todoController *TodoController) GetTodoById(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, todo))
+	respondWithETag(ctx, todoETag(todo), results.NewDataResult(true, constants.DataFetched, todo))
 }
 
 func (todoController *TodoController) AddTodo(ctx *gin.Context) {
@@ -91,6 +222,7 @@ func (todoController *TodoController) AddTodo(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", todoETag(todo))
 	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, todo))
 }
 
@@ -113,13 +245,73 @@ func (todoController *TodoController) UpdateTodo(ctx *gin.Context) {
 		return
 	}
 
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
 	updatedTodo.UserId = userId
+	updatedTodo.ExpectedVersion = expectedVersion
 	todo, err := todoController.todoService.UpdateTodo(id, updatedTodo)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusInternalServerError), results.NewResult(false, err.Error()))
 		return
 	}
 
+	ctx.Header("ETag", todoETag(todo))
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
+}
+
+// PatchTodo accepts a JSON Merge Patch, or a JSON Patch when sent as application/json-patch+json.
+func (todoController *TodoController) PatchTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	body, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter todo patch in valid format"))
+		return
+	}
+
+	var todoPatch request.TodoPatch
+	switch ctx.ContentType() {
+	case "application/json-patch+json":
+		todoPatch, err = request.ParseTodoJsonPatch(body)
+	case "application/merge-patch+json", "application/json":
+		todoPatch, err = request.ParseTodoMergePatch(body)
+	default:
+		ctx.JSON(http.StatusUnsupportedMediaType, results.NewResult(false, "Patch must be application/merge-patch+json or application/json-patch+json"))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
+	todoPatch.ExpectedVersion = expectedVersion
+	todo, err := todoController.todoService.PatchTodo(userId, id, todoPatch)
+	if err != nil {
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusBadRequest), results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.Header("ETag", todoETag(todo))
 	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
 }
 
@@ -136,7 +328,220 @@ func (todoController *TodoController) ToggleTodo(ctx *gin.Context) {
 		return
 	}
 
-	todo, err := todoController.todoService.ToggleTodo(userId, id)
+	var todoToggle request.TodoToggle
+	if err := ctx.ShouldBindQuery(&todoToggle); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
+	todoToggle.ExpectedVersion = expectedVersion
+	todo, err := todoController.todoService.ToggleTodo(userId, id, todoToggle)
+	if err != nil {
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusInternalServerError), results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.Header("ETag", todoETag(todo))
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
+}
+
+func (todoController *TodoController) ChangeTodoStatus(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var todoStatusChange request.TodoStatusChange
+	if err := ctx.ShouldBindJSON(&todoStatusChange); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter todo status in valid format"))
+		return
+	}
+
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
+	todoStatusChange.ExpectedVersion = expectedVersion
+	todo, err := todoController.todoService.ChangeTodoStatus(userId, id, todoStatusChange)
+	if err != nil {
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusBadRequest), results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.Header("ETag", todoETag(todo))
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
+}
+
+func (todoController *TodoController) AssignTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var todoAssign request.TodoAssign
+	if err := ctx.ShouldBindJSON(&todoAssign); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter todo assignee in valid format"))
+		return
+	}
+
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
+	todoAssign.ExpectedVersion = expectedVersion
+	todo, err := todoController.todoService.AssignTodo(userId, id, todoAssign)
+	if err != nil {
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusBadRequest), results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.Header("ETag", todoETag(todo))
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
+}
+
+func (todoController *TodoController) GetTodoHistory(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var historyFilter request.TodoHistoryFilter
+	if err := ctx.ShouldBindQuery(&historyFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter query parameters in valid format"))
+		return
+	}
+
+	historyPage, err := todoController.todoService.GetTodoHistory(userId, id, historyFilter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewPagedDataResult(true, constants.DataFetched, historyPage.Revisions, newPagination(ctx, historyPage.Total, historyPage.Limit, historyPage.Offset)))
+}
+
+func (todoController *TodoController) RevertTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	revisionId, err := strconv.Atoi(ctx.Param("revisionId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid revision id"))
+		return
+	}
+
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
+	todo, err := todoController.todoService.RevertTodo(userId, id, request.TodoRevert{RevisionId: revisionId, VersionPrecondition: request.VersionPrecondition{ExpectedVersion: expectedVersion}})
+	if err != nil {
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusBadRequest), results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.Header("ETag", todoETag(todo))
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
+}
+
+func (todoController *TodoController) MoveTodoToProject(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var todoMove request.TodoMove
+	if err := ctx.ShouldBindJSON(&todoMove); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
+	todoMove.ExpectedVersion = expectedVersion
+	todo, err := todoController.todoService.MoveTodoToProject(userId, id, todoMove)
+	if err != nil {
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusInternalServerError), results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.Header("ETag", todoETag(todo))
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
+}
+
+func (todoController *TodoController) ReparentTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var todoReparent request.TodoReparent
+	if err := ctx.ShouldBindJSON(&todoReparent); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	todo, err := todoController.todoService.ReparentTodo(userId, id, todoReparent)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
 		return
@@ -145,6 +550,193 @@ func (todoController *TodoController) ToggleTodo(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
 }
 
+func (todoController *TodoController) MoveTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var todoPosition request.TodoPosition
+	if err := ctx.ShouldBindJSON(&todoPosition); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter todo position in valid format"))
+		return
+	}
+
+	todo, err := todoController.todoService.MoveTodo(userId, id, todoPosition)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.Header("ETag", todoETag(todo))
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, todo))
+}
+
+func (todoController *TodoController) GetSubtasks(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	subtasks, err := todoController.todoService.GetSubtasks(userId, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, subtasks))
+}
+
+func (todoController *TodoController) AddSubtask(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var newTodo request.TodoCreate
+	if err := ctx.ShouldBindJSON(&newTodo); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter todo in valid format"))
+		return
+	}
+
+	newTodo.UserId = userId
+	newTodo.ParentId = &id
+	todo, err := todoController.todoService.AddTodo(newTodo)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, todo))
+}
+
+func (todoController *TodoController) GetOccurrences(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	count, err := strconv.Atoi(ctx.DefaultQuery("count", "5"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid occurrence count"))
+		return
+	}
+
+	occurrences, err := todoController.todoService.GetOccurrences(userId, id, count)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, occurrences))
+}
+
+func (todoController *TodoController) GetTodoDependencies(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	dependencies, err := todoController.todoService.GetTodoDependencies(userId, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, dependencies))
+}
+
+func (todoController *TodoController) AddTodoDependency(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	var todoDependency request.TodoDependency
+	if err := ctx.ShouldBindJSON(&todoDependency); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter todo dependency in valid format"))
+		return
+	}
+
+	todo, err := todoController.todoService.AddTodoDependency(userId, id, todoDependency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, results.NewDataResult(true, constants.DataAdded, todo))
+}
+
+func (todoController *TodoController) RemoveTodoDependency(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	dependsOnId, err := strconv.Atoi(ctx.Param("dependsOnId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	err = todoController.todoService.RemoveTodoDependency(userId, id, dependsOnId)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
 func (todoController *TodoController) DeleteTodo(ctx *gin.Context) {
 	userId, err := util.GetUserIdFromContext(ctx)
 	if err != nil {
@@ -158,11 +750,112 @@ func (todoController *TodoController) DeleteTodo(ctx *gin.Context) {
 		return
 	}
 
-	err = todoController.todoService.DeleteTodo(userId, id)
+	expectedVersion, matchable := getExpectedVersion(ctx)
+	if !matchable {
+		ctx.JSON(http.StatusPreconditionFailed, results.NewResult(false, service.ErrTodoVersionMismatch.Error()))
+		return
+	}
+
+	err = todoController.todoService.DeleteTodoWithVersion(userId, id, expectedVersion)
+	if err != nil {
+		ctx.JSON(todoWriteErrorStatus(err, http.StatusInternalServerError), results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
+}
+
+func (todoController *TodoController) GetTrash(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	todos, err := todoController.todoService.GetTrash(userId)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
 		return
 	}
 
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, todos))
+}
+
+func (todoController *TodoController) RestoreTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	todo, err := todoController.todoService.RestoreTodo(userId, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataRestored, todo))
+}
+
+func (todoController *TodoController) PermanentlyDeleteTodo(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Invalid todo id"))
+		return
+	}
+
+	err = todoController.todoService.PermanentlyDeleteTodo(userId, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
 	ctx.JSON(http.StatusOK, results.NewResult(true, constants.DataDeleted))
 }
+
+func (todoController *TodoController) BulkUpdateTodos(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var todoBulk request.TodoBulk
+	if err := ctx.ShouldBindJSON(&todoBulk); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter todo ids and action in valid format"))
+		return
+	}
+
+	bulkResponse, err := todoController.todoService.BulkUpdateTodos(userId, todoBulk)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	if bulkResponse.RolledBack {
+		ctx.JSON(http.StatusConflict, results.NewDataResult(false, "No todos were updated because at least one failed", bulkResponse))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, bulkResponse))
+}
+
+func getTimezone(ctx *gin.Context, timezone string) string {
+	if timezone != "" {
+		return timezone
+	}
+
+	return ctx.GetHeader("X-Timezone")
+}
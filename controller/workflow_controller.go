@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"todo-app--go-gin/common/util"
+	"todo-app--go-gin/common/util/results"
+	"todo-app--go-gin/controller/constants"
+	"todo-app--go-gin/controller/middlewares"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+type WorkflowController struct {
+	workflowService service.IWorkflowService
+}
+
+func NewWorkflowController(workflowService service.IWorkflowService) *WorkflowController {
+	return &WorkflowController{workflowService: workflowService}
+}
+
+func (workflowController *WorkflowController) RegisterWorkflowRoutes(router *gin.Engine) {
+	workflowGroup := router.Group("/workflow")
+	{
+		workflowGroup.Use(middlewares.Authenticate)
+		workflowGroup.GET("", workflowController.GetWorkflow)
+		workflowGroup.PUT("", workflowController.UpdateWorkflow)
+	}
+}
+
+func (workflowController *WorkflowController) GetWorkflow(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	workflow, err := workflowController.workflowService.GetWorkflow(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataFetched, workflow))
+}
+
+func (workflowController *WorkflowController) UpdateWorkflow(ctx *gin.Context) {
+	userId, err := util.GetUserIdFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, results.NewResult(false, constants.Unauthorized))
+		return
+	}
+
+	var workflowUpdate request.WorkflowUpdate
+	if err := ctx.ShouldBindJSON(&workflowUpdate); err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, "Enter workflow in valid format"))
+		return
+	}
+
+	workflowUpdate.UserId = userId
+	workflow, err := workflowController.workflowService.UpdateWorkflow(workflowUpdate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, results.NewResult(false, err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results.NewDataResult(true, constants.DataUpdated, workflow))
+}
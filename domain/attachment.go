@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+)
+
+// Attachment is a file uploaded to a todo. Its content lives in blob storage under BlobKey; the todo id
+// is cleared when the todo is permanently deleted, leaving the attachment to be cleaned up.
+type Attachment struct {
+	Id          int       `json:"id"`
+	TodoId      *int      `json:"todoId"`
+	UserId      int       `json:"userId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	BlobKey     string    `json:"blobKey"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+)
+
+type Comment struct {
+	Id        int        `json:"id"`
+	TodoId    int        `json:"todoId"`
+	UserId    int        `json:"userId"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EditedAt  *time.Time `json:"editedAt"`
+}
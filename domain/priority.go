@@ -0,0 +1,28 @@
+package domain
+
+const (
+	PriorityNone = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// PriorityNames are the names the API uses for each priority level.
+var PriorityNames = map[int]string{
+	PriorityNone:   "none",
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+func ParsePriority(name string) (int, bool) {
+	for priority, priorityName := range PriorityNames {
+		if priorityName == name {
+			return priority, true
+		}
+	}
+
+	return PriorityNone, false
+}
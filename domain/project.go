@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	ProjectDeleteModeInbox   = "inbox"
+	ProjectDeleteModeCascade = "cascade"
+)
+
+type Project struct {
+	Id                 int       `json:"id"`
+	UserId             int       `json:"userId"`
+	Name               string    `json:"name"`
+	Color              string    `json:"color"`
+	IsArchived         bool      `json:"isArchived"`
+	Position           int       `json:"position"`
+	TodoCount          int       `json:"todoCount"`
+	CompletedTodoCount int       `json:"completedTodoCount"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,12 @@
+package request
+
+import (
+	"io"
+)
+
+type AttachmentCreate struct {
+	UserId   int
+	TodoId   int
+	FileName string
+	Content  io.Reader
+}
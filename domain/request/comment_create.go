@@ -0,0 +1,7 @@
+package request
+
+type CommentCreate struct {
+	UserId int    `json:"userId"`
+	TodoId int    `json:"todoId"`
+	Body   string `json:"body"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type CommentFilter struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type CommentUpdate struct {
+	Body string `json:"body"`
+}
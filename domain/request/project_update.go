@@ -0,0 +1,8 @@
+package request
+
+type ProjectUpdate struct {
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	IsArchived bool   `json:"isArchived"`
+	Position   int    `json:"position"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type SavedFilterCreate struct {
+	UserId     int    `json:"userId"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
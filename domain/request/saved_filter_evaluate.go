@@ -0,0 +1,8 @@
+package request
+
+// SavedFilterEvaluate pages through the todos of a saved filter, with relative dates resolved in Timezone.
+type SavedFilterEvaluate struct {
+	Timezone string `form:"tz"`
+	Limit    int    `form:"limit"`
+	Offset   int    `form:"offset"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type SavedFilterUpdate struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type ShareCreate struct {
+	UserId     int    `json:"userId"`
+	Email      string `json:"email"`
+	Permission string `json:"permission"`
+}
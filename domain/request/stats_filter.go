@@ -0,0 +1,12 @@
+package request
+
+import (
+	"time"
+)
+
+type StatsFilter struct {
+	From     *time.Time `form:"from" time_format:"2006-01-02"`
+	To       *time.Time `form:"to" time_format:"2006-01-02"`
+	Period   string     `form:"period"`
+	Timezone string     `form:"tz"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type TagCreate struct {
+	UserId int    `json:"userId"`
+	Name   string `json:"name"`
+	Color  string `json:"color"`
+}
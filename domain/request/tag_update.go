@@ -0,0 +1,6 @@
+package request
+
+type TagUpdate struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
@@ -0,0 +1,29 @@
+package request
+
+type TemplateCreate struct {
+	UserId      int            `json:"userId"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Items       []TemplateItem `json:"items"`
+}
+
+type TemplateItem struct {
+	Title           string         `json:"title"`
+	Description     string         `json:"description"`
+	Priority        string         `json:"priority"`
+	DueOffsetDays   *int           `json:"dueOffsetDays"`
+	DueTime         *string        `json:"dueTime"`
+	DueTimezone     *string        `json:"dueTimezone"`
+	EstimateMinutes *int           `json:"estimateMinutes"`
+	TagIds          []int          `json:"tagIds"`
+	Subtasks        []TemplateItem `json:"subtasks"`
+}
+
+// TemplateFromTodos creates a template from existing todos and their subtasks. Due dates become offsets
+// from the earliest due date among them.
+type TemplateFromTodos struct {
+	UserId      int    `json:"userId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TodoIds     []int  `json:"todoIds"`
+}
@@ -0,0 +1,8 @@
+package request
+
+// TemplateInstantiate creates the todos of a template with due dates counted from StartDate, which
+// defaults to today.
+type TemplateInstantiate struct {
+	StartDate *string `json:"startDate"`
+	ProjectId *int    `json:"projectId"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type TemplateUpdate struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Items       []TemplateItem `json:"items"`
+}
@@ -0,0 +1,11 @@
+package request
+
+import (
+	"time"
+)
+
+type TimeEntryCreate struct {
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+	Note      string    `json:"note"`
+}
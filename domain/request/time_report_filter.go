@@ -0,0 +1,11 @@
+package request
+
+import (
+	"time"
+)
+
+type TimeReportFilter struct {
+	From     *time.Time `form:"from" time_format:"2006-01-02"`
+	To       *time.Time `form:"to" time_format:"2006-01-02"`
+	Timezone string     `form:"tz"`
+}
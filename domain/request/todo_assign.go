@@ -0,0 +1,6 @@
+package request
+
+type TodoAssign struct {
+	AssigneeId *int `json:"assigneeId"`
+	VersionPrecondition
+}
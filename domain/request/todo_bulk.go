@@ -0,0 +1,11 @@
+package request
+
+// TodoBulk applies one action to several todos. ProjectId is the target of the move action,
+// where null moves the todos to the Inbox, and TagId is the tag attached by the addTag action.
+type TodoBulk struct {
+	Ids          []int  `json:"ids" binding:"required"`
+	Action       string `json:"action" binding:"required"`
+	ProjectId    *int   `json:"projectId"`
+	TagId        *int   `json:"tagId"`
+	AllOrNothing bool   `json:"allOrNothing"`
+}
@@ -1,7 +1,17 @@
 package request
 
 type TodoCreate struct {
-	UserId      int    `json:"userId"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	UserId          int     `json:"userId"`
+	ProjectId       *int    `json:"projectId"`
+	ParentId        *int    `json:"parentId"`
+	Title           string  `json:"title"`
+	Description     string  `json:"description"`
+	Priority        string  `json:"priority"`
+	Status          string  `json:"status"`
+	DueDate         *string `json:"dueDate"`
+	DueTime         *string `json:"dueTime"`
+	DueTimezone     *string `json:"dueTimezone"`
+	RecurrenceRule  *string `json:"recurrenceRule"`
+	EstimateMinutes *int    `json:"estimateMinutes"`
+	TagIds          []int   `json:"tagIds"`
 }
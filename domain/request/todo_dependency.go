@@ -0,0 +1,5 @@
+package request
+
+type TodoDependency struct {
+	DependsOnId int `json:"dependsOnId"`
+}
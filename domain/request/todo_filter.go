@@ -0,0 +1,41 @@
+package request
+
+import (
+	"time"
+)
+
+type TodoFilter struct {
+	ProjectId     string     `form:"projectId"`
+	ParentId      string     `form:"parentId"`
+	IsCompleted   *bool      `form:"isCompleted"`
+	Priority      string     `form:"priority"`
+	Status        string     `form:"status"`
+	CreatedFrom   *time.Time `form:"createdFrom"`
+	CreatedTo     *time.Time `form:"createdTo"`
+	UpdatedFrom   *time.Time `form:"updatedFrom"`
+	UpdatedTo     *time.Time `form:"updatedTo"`
+	CompletedFrom *time.Time `form:"completedFrom"`
+	CompletedTo   *time.Time `form:"completedTo"`
+	Title         string     `form:"title"`
+	DueFrom       *time.Time `form:"dueFrom" time_format:"2006-01-02"`
+	DueTo         *time.Time `form:"dueTo" time_format:"2006-01-02"`
+	Timezone      string     `form:"tz"`
+	Tags          []string   `form:"tag"`
+	TagMode       string     `form:"tagMode"`
+	Owner         string     `form:"owner"`
+	Assignee      string     `form:"assignee"`
+	Sort          string     `form:"sort"`
+	Order         string     `form:"order"`
+	Limit         int        `form:"limit"`
+	Offset        int        `form:"offset"`
+}
+
+type TodoSearchFilter struct {
+	TodoFilter
+	Query string `form:"q"`
+}
+
+type DueViewFilter struct {
+	TodoFilter
+	Days int `form:"days"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type TodoHistoryFilter struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
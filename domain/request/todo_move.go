@@ -0,0 +1,6 @@
+package request
+
+type TodoMove struct {
+	ProjectId *int `json:"projectId"`
+	VersionPrecondition
+}
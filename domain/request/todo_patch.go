@@ -0,0 +1,104 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// Patchable is one member of a JSON Merge Patch (RFC 7396). Set reports whether the member was
+// present at all and Null whether it was explicitly null, which clears the field.
+type Patchable[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (patchable *Patchable[T]) UnmarshalJSON(data []byte) error {
+	patchable.Set = true
+	if string(data) == "null" {
+		patchable.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &patchable.Value)
+}
+
+// Pointer returns the patched value of a nullable field, or nil when the patch clears it.
+func (patchable Patchable[T]) Pointer() *T {
+	if patchable.Null {
+		return nil
+	}
+
+	value := patchable.Value
+	return &value
+}
+
+type TodoPatch struct {
+	Title           Patchable[string] `json:"title"`
+	Description     Patchable[string] `json:"description"`
+	IsCompleted     Patchable[bool]   `json:"isCompleted"`
+	Priority        Patchable[string] `json:"priority"`
+	Status          Patchable[string] `json:"status"`
+	DueDate         Patchable[string] `json:"dueDate"`
+	DueTime         Patchable[string] `json:"dueTime"`
+	DueTimezone     Patchable[string] `json:"dueTimezone"`
+	RecurrenceRule  Patchable[string] `json:"recurrenceRule"`
+	EstimateMinutes Patchable[int]    `json:"estimateMinutes"`
+	VersionPrecondition
+}
+
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func ParseTodoMergePatch(data []byte) (TodoPatch, error) {
+	var todoPatch TodoPatch
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&todoPatch); err != nil {
+		return TodoPatch{}, errors.New(fmt.Sprintf("Invalid merge patch: %v", err))
+	}
+
+	return todoPatch, nil
+}
+
+// ParseTodoJsonPatch converts a JSON Patch (RFC 6902) document into the equivalent merge patch.
+// Only add, replace and remove operations on top-level todo fields are supported.
+func ParseTodoJsonPatch(data []byte) (TodoPatch, error) {
+	var operations []jsonPatchOperation
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return TodoPatch{}, errors.New(fmt.Sprintf("Invalid JSON patch: %v", err))
+	}
+
+	mergePatch := map[string]json.RawMessage{}
+	for _, operation := range operations {
+		field := strings.TrimPrefix(operation.Path, "/")
+		if !strings.HasPrefix(operation.Path, "/") || field == "" || strings.Contains(field, "/") {
+			return TodoPatch{}, errors.New(fmt.Sprintf("Unsupported patch path %s", operation.Path))
+		}
+
+		switch operation.Op {
+		case "add", "replace":
+			if operation.Value == nil {
+				return TodoPatch{}, errors.New(fmt.Sprintf("Patch operation %s on %s requires a value", operation.Op, operation.Path))
+			}
+			mergePatch[field] = operation.Value
+		case "remove":
+			mergePatch[field] = json.RawMessage("null")
+		default:
+			return TodoPatch{}, errors.New(fmt.Sprintf("Unsupported patch operation %s", operation.Op))
+		}
+	}
+
+	mergePatchData, err := json.Marshal(mergePatch)
+	if err != nil {
+		return TodoPatch{}, err
+	}
+
+	return ParseTodoMergePatch(mergePatchData)
+}
@@ -0,0 +1,7 @@
+package request
+
+// TodoPosition places a todo directly before or directly after another todo; exactly one is set.
+type TodoPosition struct {
+	BeforeId *int `json:"beforeId"`
+	AfterId  *int `json:"afterId"`
+}
@@ -0,0 +1,11 @@
+package request
+
+// TodoQuickAdd creates a todo from a single line of text. Relative dates are resolved in Timezone,
+// which defaults to UTC, and Description defaults to the text as typed.
+type TodoQuickAdd struct {
+	UserId      int     `json:"userId"`
+	Text        string  `json:"text"`
+	Description string  `json:"description"`
+	ProjectId   *int    `json:"projectId"`
+	Timezone    *string `json:"timezone"`
+}
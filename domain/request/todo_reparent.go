@@ -0,0 +1,5 @@
+package request
+
+type TodoReparent struct {
+	ParentId *int `json:"parentId"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type TodoRevert struct {
+	RevisionId int `json:"-"`
+	VersionPrecondition
+}
@@ -0,0 +1,6 @@
+package request
+
+type TodoStatusChange struct {
+	Status string `json:"status"`
+	VersionPrecondition
+}
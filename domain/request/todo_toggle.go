@@ -0,0 +1,6 @@
+package request
+
+type TodoToggle struct {
+	CompleteSubtasks bool `form:"completeSubtasks"`
+	VersionPrecondition
+}
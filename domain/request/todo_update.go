@@ -1,8 +1,18 @@
 package request
 
 type TodoUpdate struct {
-	UserId      int    `json:"userId"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	IsCompleted bool   `json:"isCompleted"`
+	UserId          int     `json:"userId"`
+	Title           string  `json:"title"`
+	Description     string  `json:"description"`
+	IsCompleted     bool    `json:"isCompleted"`
+	Priority        *string `json:"priority"`
+	Status          *string `json:"status"`
+	DueDate         *string `json:"dueDate"`
+	DueTime         *string `json:"dueTime"`
+	DueTimezone     *string `json:"dueTimezone"`
+	RecurrenceRule  *string `json:"recurrenceRule"`
+	EstimateMinutes *int    `json:"estimateMinutes"`
+	AttachTagIds    []int   `json:"attachTagIds"`
+	DetachTagIds    []int   `json:"detachTagIds"`
+	VersionPrecondition
 }
@@ -0,0 +1,7 @@
+package request
+
+// VersionPrecondition is embedded in the requests of conditional todo writes. ExpectedVersion is
+// taken from the If-Match header rather than the body.
+type VersionPrecondition struct {
+	ExpectedVersion *int `json:"-" form:"-"`
+}
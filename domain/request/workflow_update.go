@@ -0,0 +1,18 @@
+package request
+
+type WorkflowUpdate struct {
+	UserId      int                  `json:"userId"`
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+type WorkflowStatus struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	IsDone bool   `json:"isDone"`
+}
+
+type WorkflowTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type AttachmentResponse struct {
+	Id          int       `json:"id"`
+	TodoId      *int      `json:"todoId"`
+	UploaderId  int       `json:"uploaderId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func NewAttachmentResponse(attachment domain.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		Id:          attachment.Id,
+		TodoId:      attachment.TodoId,
+		UploaderId:  attachment.UserId,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func NewAttachmentResponses(attachments []domain.Attachment) []AttachmentResponse {
+	attachmentResponses := make([]AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentResponses = append(attachmentResponses, NewAttachmentResponse(attachment))
+	}
+
+	return attachmentResponses
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type CommentResponse struct {
+	Id        int        `json:"id"`
+	TodoId    int        `json:"todoId"`
+	AuthorId  int        `json:"authorId"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EditedAt  *time.Time `json:"editedAt"`
+}
+
+type CommentPageResponse struct {
+	Comments []CommentResponse `json:"comments"`
+	Total    int               `json:"total"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+func NewCommentResponse(comment domain.Comment) CommentResponse {
+	return CommentResponse{
+		Id:        comment.Id,
+		TodoId:    comment.TodoId,
+		AuthorId:  comment.UserId,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		EditedAt:  comment.EditedAt,
+	}
+}
+
+func NewCommentPageResponse(comments []domain.Comment, total int, limit int, offset int) CommentPageResponse {
+	commentResponses := make([]CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		commentResponses = append(commentResponses, NewCommentResponse(comment))
+	}
+
+	return CommentPageResponse{
+		Comments: commentResponses,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+}
@@ -0,0 +1,8 @@
+package response
+
+type OccurrenceResponse struct {
+	OccurrenceIndex int     `json:"occurrenceIndex"`
+	DueDate         string  `json:"dueDate"`
+	DueTime         *string `json:"dueTime"`
+	DueTimezone     *string `json:"dueTimezone"`
+}
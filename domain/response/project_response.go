@@ -0,0 +1,32 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type ProjectResponse struct {
+	Id                 int       `json:"id"`
+	Name               string    `json:"name"`
+	Color              string    `json:"color"`
+	IsArchived         bool      `json:"isArchived"`
+	Position           int       `json:"position"`
+	TodoCount          int       `json:"todoCount"`
+	CompletedTodoCount int       `json:"completedTodoCount"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+func NewProjectResponse(project domain.Project) ProjectResponse {
+	return ProjectResponse{
+		Id:                 project.Id,
+		Name:               project.Name,
+		Color:              project.Color,
+		IsArchived:         project.IsArchived,
+		Position:           project.Position,
+		TodoCount:          project.TodoCount,
+		CompletedTodoCount: project.CompletedTodoCount,
+		CreatedAt:          project.CreatedAt,
+		UpdatedAt:          project.UpdatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type SavedFilterResponse struct {
+	Id         int       `json:"id"`
+	Name       string    `json:"name"`
+	Expression string    `json:"expression"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func NewSavedFilterResponse(savedFilter domain.SavedFilter) SavedFilterResponse {
+	return SavedFilterResponse{
+		Id:         savedFilter.Id,
+		Name:       savedFilter.Name,
+		Expression: savedFilter.Expression,
+		CreatedAt:  savedFilter.CreatedAt,
+		UpdatedAt:  savedFilter.UpdatedAt,
+	}
+}
+
+func NewSavedFilterResponses(savedFilters []domain.SavedFilter) []SavedFilterResponse {
+	savedFilterResponses := make([]SavedFilterResponse, 0, len(savedFilters))
+	for _, savedFilter := range savedFilters {
+		savedFilterResponses = append(savedFilterResponses, NewSavedFilterResponse(savedFilter))
+	}
+
+	return savedFilterResponses
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type ShareResponse struct {
+	UserId     int       `json:"userId"`
+	Email      string    `json:"email"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func NewShareResponse(share domain.Share) ShareResponse {
+	return ShareResponse{
+		UserId:     share.UserId,
+		Email:      share.Email,
+		Permission: share.Permission,
+		CreatedAt:  share.CreatedAt,
+	}
+}
+
+func NewShareResponses(shares []domain.Share) []ShareResponse {
+	shareResponses := []ShareResponse{}
+	for _, share := range shares {
+		shareResponses = append(shareResponses, NewShareResponse(share))
+	}
+
+	return shareResponses
+}
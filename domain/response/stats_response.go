@@ -0,0 +1,20 @@
+package response
+
+type CompletionCountResponse struct {
+	PeriodStart string `json:"periodStart"`
+	Count       int    `json:"count"`
+}
+
+// StatsResponse summarises the todos of a user. Completions holds one entry per day or week between
+// From and To, including the periods in which nothing was completed.
+type StatsResponse struct {
+	OpenCount                int                       `json:"openCount"`
+	CompletedCount           int                       `json:"completedCount"`
+	OverdueCount             int                       `json:"overdueCount"`
+	AverageMinutesToComplete *int                      `json:"averageMinutesToComplete"`
+	CurrentStreakDays        int                       `json:"currentStreakDays"`
+	Period                   string                    `json:"period"`
+	From                     string                    `json:"from"`
+	To                       string                    `json:"to"`
+	Completions              []CompletionCountResponse `json:"completions"`
+}
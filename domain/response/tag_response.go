@@ -0,0 +1,28 @@
+package response
+
+import (
+	"todo-app--go-gin/domain"
+)
+
+type TagResponse struct {
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func NewTagResponse(tag domain.Tag) TagResponse {
+	return TagResponse{
+		Id:    tag.Id,
+		Name:  tag.Name,
+		Color: tag.Color,
+	}
+}
+
+func NewTagResponses(tags []domain.Tag) []TagResponse {
+	tagResponses := make([]TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		tagResponses = append(tagResponses, NewTagResponse(tag))
+	}
+
+	return tagResponses
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type TemplateResponse struct {
+	Id          int                   `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	ItemCount   int                   `json:"itemCount"`
+	Items       []domain.TemplateItem `json:"items"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	UpdatedAt   time.Time             `json:"updatedAt"`
+}
+
+func NewTemplateResponse(template domain.Template) TemplateResponse {
+	return TemplateResponse{
+		Id:          template.Id,
+		Name:        template.Name,
+		Description: template.Description,
+		ItemCount:   domain.CountTemplateItems(template.Items),
+		Items:       template.Items,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+}
+
+func NewTemplateResponses(templates []domain.Template) []TemplateResponse {
+	templateResponses := make([]TemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		templateResponses = append(templateResponses, NewTemplateResponse(template))
+	}
+
+	return templateResponses
+}
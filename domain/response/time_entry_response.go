@@ -0,0 +1,40 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type TimeEntryResponse struct {
+	Id              int        `json:"id"`
+	TodoId          int        `json:"todoId"`
+	UserId          int        `json:"userId"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt"`
+	Note            string     `json:"note"`
+	IsRunning       bool       `json:"isRunning"`
+	DurationSeconds int64      `json:"durationSeconds"`
+}
+
+// NewTimeEntryResponse converts a time entry, measuring a running timer up to now.
+func NewTimeEntryResponse(timeEntry domain.TimeEntry, now time.Time) TimeEntryResponse {
+	return TimeEntryResponse{
+		Id:              timeEntry.Id,
+		TodoId:          timeEntry.TodoId,
+		UserId:          timeEntry.UserId,
+		StartedAt:       timeEntry.StartedAt,
+		EndedAt:         timeEntry.EndedAt,
+		Note:            timeEntry.Note,
+		IsRunning:       timeEntry.IsRunning(),
+		DurationSeconds: int64(timeEntry.Duration(now) / time.Second),
+	}
+}
+
+func NewTimeEntryResponses(timeEntries []domain.TimeEntry, now time.Time) []TimeEntryResponse {
+	timeEntryResponses := make([]TimeEntryResponse, 0, len(timeEntries))
+	for _, timeEntry := range timeEntries {
+		timeEntryResponses = append(timeEntryResponses, NewTimeEntryResponse(timeEntry, now))
+	}
+
+	return timeEntryResponses
+}
@@ -0,0 +1,29 @@
+package response
+
+type UserTimeResponse struct {
+	UserId         int `json:"userId"`
+	TrackedMinutes int `json:"trackedMinutes"`
+}
+
+// TodoTimeReportResponse compares the time tracked on a todo by everyone with its estimate.
+// RemainingMinutes is negative once the todo has gone over its estimate.
+type TodoTimeReportResponse struct {
+	TodoId           int                `json:"todoId"`
+	EstimateMinutes  *int               `json:"estimateMinutes"`
+	TrackedMinutes   int                `json:"trackedMinutes"`
+	RemainingMinutes *int               `json:"remainingMinutes"`
+	Users            []UserTimeResponse `json:"users"`
+}
+
+type DailyTodoTimeResponse struct {
+	TodoId          int    `json:"todoId"`
+	Title           string `json:"title"`
+	TrackedMinutes  int    `json:"trackedMinutes"`
+	EstimateMinutes *int   `json:"estimateMinutes"`
+}
+
+type DailyTimeReportResponse struct {
+	Date           string                  `json:"date"`
+	TrackedMinutes int                     `json:"trackedMinutes"`
+	Todos          []DailyTodoTimeResponse `json:"todos"`
+}
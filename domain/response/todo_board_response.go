@@ -0,0 +1,12 @@
+package response
+
+// TodoBoardColumn holds a page of the todos in one status of the workflow.
+type TodoBoardColumn struct {
+	WorkflowStatusResponse
+	Todos []TodoResponse `json:"todos"`
+	Total int            `json:"total"`
+}
+
+type TodoBoardResponse struct {
+	Columns []TodoBoardColumn `json:"columns"`
+}
@@ -0,0 +1,14 @@
+package response
+
+type TodoBulkResponse struct {
+	Results    []TodoBulkItemResult `json:"results"`
+	Succeeded  int                  `json:"succeeded"`
+	Failed     int                  `json:"failed"`
+	RolledBack bool                 `json:"rolledBack"`
+}
+
+type TodoBulkItemResult struct {
+	Id      int    `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
@@ -0,0 +1,21 @@
+package response
+
+type TodoPageResponse struct {
+	Todos  []TodoResponse `json:"todos"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+func NewTodoPageResponse(todos []TodoResponse, total int, limit int, offset int) TodoPageResponse {
+	if todos == nil {
+		todos = []TodoResponse{}
+	}
+
+	return TodoPageResponse{
+		Todos:  todos,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
@@ -0,0 +1,26 @@
+package response
+
+type TodoQuickAddResponse struct {
+	Todo   TodoResponse           `json:"todo"`
+	Parsed QuickAddParsedResponse `json:"parsed"`
+}
+
+// QuickAddParsedResponse is what was recognised in the text of a quick add. UnknownTags are tags the
+// user does not have, which are left out of the todo.
+type QuickAddParsedResponse struct {
+	Title          string                  `json:"title"`
+	DueDate        *string                 `json:"dueDate"`
+	DueTime        *string                 `json:"dueTime"`
+	DueTimezone    *string                 `json:"dueTimezone"`
+	Priority       string                  `json:"priority"`
+	RecurrenceRule *string                 `json:"recurrenceRule"`
+	Tags           []string                `json:"tags"`
+	UnknownTags    []string                `json:"unknownTags"`
+	Tokens         []QuickAddTokenResponse `json:"tokens"`
+}
+
+type QuickAddTokenResponse struct {
+	Text  string `json:"text"`
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
@@ -6,23 +6,74 @@ import (
 )
 
 type TodoResponse struct {
-	Id          int       `json:"id"`
-	UserId      int       `json:"userId"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	IsCompleted bool      `json:"isCompleted"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Id              int            `json:"id"`
+	UserId          int            `json:"userId"`
+	ProjectId       *int           `json:"projectId"`
+	ParentId        *int           `json:"parentId"`
+	Title           string         `json:"title"`
+	Description     string         `json:"description"`
+	IsCompleted     bool           `json:"isCompleted"`
+	Status          string         `json:"status"`
+	Priority        string         `json:"priority"`
+	Position        float64        `json:"position"`
+	EstimateMinutes *int           `json:"estimateMinutes"`
+	AssigneeId      *int           `json:"assigneeId"`
+	AssignedBy      *int           `json:"assignedBy,omitempty"`
+	AssignedAt      *time.Time     `json:"assignedAt,omitempty"`
+	CompletedAt     *time.Time     `json:"completedAt,omitempty"`
+	CompletedBy     *int           `json:"completedBy,omitempty"`
+	DueDate         *string        `json:"dueDate"`
+	DueTime         *string        `json:"dueTime"`
+	DueTimezone     *string        `json:"dueTimezone"`
+	Tags            []TagResponse  `json:"tags"`
+	IsBlocked       bool           `json:"isBlocked"`
+	BlockedBy       []int          `json:"blockedBy,omitempty"`
+	RecurrenceRule  *string        `json:"recurrenceRule"`
+	SeriesId        *int           `json:"seriesId"`
+	OccurrenceIndex int            `json:"occurrenceIndex"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	DeletedAt       *time.Time     `json:"deletedAt,omitempty"`
+	Version         int            `json:"version"`
+	Subtasks        []TodoResponse `json:"subtasks,omitempty"`
 }
 
 func NewTodoResponse(todo domain.Todo) TodoResponse {
+	var dueDate *string
+	if todo.DueDate != nil {
+		formattedDueDate := todo.DueDate.Format(domain.DueDateLayout)
+		dueDate = &formattedDueDate
+	}
+
 	return TodoResponse{
-		Id:          todo.Id,
-		UserId:      todo.UserId,
-		Title:       todo.Title,
-		Description: todo.Description,
-		IsCompleted: todo.IsCompleted,
-		CreatedAt:   todo.CreatedAt,
-		UpdatedAt:   todo.UpdatedAt,
+		Id:              todo.Id,
+		UserId:          todo.UserId,
+		ProjectId:       todo.ProjectId,
+		ParentId:        todo.ParentId,
+		Title:           todo.Title,
+		Description:     todo.Description,
+		IsCompleted:     todo.IsCompleted,
+		Status:          todo.Status,
+		Priority:        domain.PriorityNames[todo.Priority],
+		Position:        todo.Position,
+		EstimateMinutes: todo.EstimateMinutes,
+		AssigneeId:      todo.AssigneeId,
+		AssignedBy:      todo.AssignedBy,
+		AssignedAt:      todo.AssignedAt,
+		CompletedAt:     todo.CompletedAt,
+		CompletedBy:     todo.CompletedBy,
+		DueDate:         dueDate,
+		DueTime:         todo.DueTime,
+		DueTimezone:     todo.DueTimezone,
+		Tags:            NewTagResponses(todo.Tags),
+		IsBlocked:       len(todo.BlockedByIds) > 0,
+		BlockedBy:       todo.BlockedByIds,
+		RecurrenceRule:  todo.RecurrenceRule,
+		SeriesId:        todo.SeriesId,
+		OccurrenceIndex: todo.OccurrenceIndex,
+		CreatedAt:       todo.CreatedAt,
+		UpdatedAt:       todo.UpdatedAt,
+		DeletedAt:       todo.DeletedAt,
+		Version:         todo.Version,
 	}
 }
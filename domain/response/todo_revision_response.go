@@ -0,0 +1,49 @@
+package response
+
+import (
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+type TodoRevisionResponse struct {
+	Id        int                      `json:"id"`
+	TodoId    int                      `json:"todoId"`
+	ActorId   int                      `json:"actorId"`
+	Action    string                   `json:"action"`
+	Changes   []domain.TodoFieldChange `json:"changes"`
+	Snapshot  domain.TodoSnapshot      `json:"snapshot"`
+	CreatedAt time.Time                `json:"createdAt"`
+}
+
+type TodoHistoryPageResponse struct {
+	Revisions []TodoRevisionResponse `json:"revisions"`
+	Total     int                    `json:"total"`
+	Limit     int                    `json:"limit"`
+	Offset    int                    `json:"offset"`
+}
+
+func NewTodoRevisionResponse(revision domain.TodoRevision) TodoRevisionResponse {
+	return TodoRevisionResponse{
+		Id:        revision.Id,
+		TodoId:    revision.TodoId,
+		ActorId:   revision.UserId,
+		Action:    revision.Action,
+		Changes:   revision.Changes,
+		Snapshot:  revision.Snapshot,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+func NewTodoHistoryPageResponse(revisions []domain.TodoRevision, total int, limit int, offset int) TodoHistoryPageResponse {
+	revisionResponses := make([]TodoRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionResponses = append(revisionResponses, NewTodoRevisionResponse(revision))
+	}
+
+	return TodoHistoryPageResponse{
+		Revisions: revisionResponses,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
@@ -0,0 +1,47 @@
+package response
+
+import "todo-app--go-gin/domain"
+
+type TodoSearchResponse struct {
+	TodoResponse
+	Rank       float64        `json:"rank"`
+	Highlights TodoHighlights `json:"highlights"`
+}
+
+// TodoHighlights holds the title and a snippet of the description with matched words wrapped in <mark> tags.
+type TodoHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type TodoSearchPageResponse struct {
+	Results []TodoSearchResponse `json:"results"`
+	Total   int                  `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+func NewTodoSearchResponse(searchResult domain.TodoSearchResult) TodoSearchResponse {
+	return TodoSearchResponse{
+		TodoResponse: NewTodoResponse(searchResult.Todo),
+		Rank:         searchResult.Rank,
+		Highlights: TodoHighlights{
+			Title:       searchResult.TitleHighlight,
+			Description: searchResult.DescriptionHighlight,
+		},
+	}
+}
+
+func NewTodoSearchPageResponse(searchResults []domain.TodoSearchResult, total int, limit int, offset int) TodoSearchPageResponse {
+	results := []TodoSearchResponse{}
+	for _, searchResult := range searchResults {
+		results = append(results, NewTodoSearchResponse(searchResult))
+	}
+
+	return TodoSearchPageResponse{
+		Results: results,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
+}
@@ -0,0 +1,44 @@
+package response
+
+import (
+	"todo-app--go-gin/domain"
+)
+
+type WorkflowStatusResponse struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	IsDone bool   `json:"isDone"`
+}
+
+type WorkflowTransitionResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type WorkflowResponse struct {
+	Statuses    []WorkflowStatusResponse     `json:"statuses"`
+	Transitions []WorkflowTransitionResponse `json:"transitions"`
+}
+
+func NewWorkflowResponse(workflow domain.Workflow) WorkflowResponse {
+	workflowResponse := WorkflowResponse{
+		Statuses:    make([]WorkflowStatusResponse, 0, len(workflow.Statuses)),
+		Transitions: make([]WorkflowTransitionResponse, 0, len(workflow.Transitions)),
+	}
+	for _, status := range workflow.Statuses {
+		workflowResponse.Statuses = append(workflowResponse.Statuses, NewWorkflowStatusResponse(status))
+	}
+	for _, transition := range workflow.Transitions {
+		workflowResponse.Transitions = append(workflowResponse.Transitions, WorkflowTransitionResponse{From: transition.From, To: transition.To})
+	}
+
+	return workflowResponse
+}
+
+func NewWorkflowStatusResponse(status domain.WorkflowStatus) WorkflowStatusResponse {
+	return WorkflowStatusResponse{
+		Key:    status.Key,
+		Name:   status.Name,
+		IsDone: status.IsDone,
+	}
+}
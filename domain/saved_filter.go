@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+)
+
+// SavedFilter is a named filter expression a user keeps to list matching todos again, like a smart
+// list. Relative dates in the expression are resolved whenever the filter is evaluated.
+type SavedFilter struct {
+	Id         int       `json:"id"`
+	UserId     int       `json:"userId"`
+	Name       string    `json:"name"`
+	Expression string    `json:"expression"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	PermissionViewer = "viewer"
+	PermissionEditor = "editor"
+)
+
+// TodoAccess is what a user may do with a todo, each level including the ones below it.
+type TodoAccess int
+
+const (
+	TodoAccessNone TodoAccess = iota
+	TodoAccessView
+	TodoAccessEdit
+	TodoAccessOwner
+)
+
+// Share gives another user access to a todo or to every todo of a project. Exactly one of TodoId
+// and ProjectId is set.
+type Share struct {
+	Id         int       `json:"id"`
+	TodoId     *int      `json:"todoId"`
+	ProjectId  *int      `json:"projectId"`
+	UserId     int       `json:"userId"`
+	Email      string    `json:"email"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Access returns the access level the share grants.
+func (share Share) Access() TodoAccess {
+	switch share.Permission {
+	case PermissionEditor:
+		return TodoAccessEdit
+	case PermissionViewer:
+		return TodoAccessView
+	}
+	return TodoAccessNone
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"time"
+)
+
+type Tag struct {
+	Id        int       `json:"id"`
+	UserId    int       `json:"userId"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"createdAt"`
+}
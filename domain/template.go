@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+// MaxTemplateItemCount is the largest number of todos, subtasks included, a template can create.
+const MaxTemplateItemCount = 100
+
+// Template is a saved set of todos that can be created again in one go, for example a recurring checklist.
+type Template struct {
+	Id          int            `json:"id"`
+	UserId      int            `json:"userId"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Items       []TemplateItem `json:"items"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// TemplateItem is a todo of a template with its subtasks. DueOffsetDays is the number of days between
+// the start date of an instantiation and the due date of the todo created from the item.
+type TemplateItem struct {
+	Title           string         `json:"title"`
+	Description     string         `json:"description"`
+	Priority        string         `json:"priority"`
+	DueOffsetDays   *int           `json:"dueOffsetDays"`
+	DueTime         *string        `json:"dueTime"`
+	DueTimezone     *string        `json:"dueTimezone"`
+	EstimateMinutes *int           `json:"estimateMinutes"`
+	TagIds          []int          `json:"tagIds"`
+	Subtasks        []TemplateItem `json:"subtasks"`
+}
+
+// CountTemplateItems returns the number of items including all nested subtasks.
+func CountTemplateItems(items []TemplateItem) int {
+	count := len(items)
+	for _, item := range items {
+		count += CountTemplateItems(item.Subtasks)
+	}
+
+	return count
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// TimeEntry is a span of time a user tracked on a todo. An entry without an end is a running timer;
+// each user has at most one.
+type TimeEntry struct {
+	Id        int        `json:"id"`
+	TodoId    int        `json:"todoId"`
+	UserId    int        `json:"userId"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt"`
+	Note      string     `json:"note"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func (timeEntry TimeEntry) IsRunning() bool {
+	return timeEntry.EndedAt == nil
+}
+
+// Duration returns the tracked time of the entry, counting a running timer up to now.
+func (timeEntry TimeEntry) Duration(now time.Time) time.Duration {
+	return timeEntry.DurationBetween(timeEntry.StartedAt, now)
+}
+
+// DurationBetween returns the part of the tracked time of the entry that falls between from and to.
+func (timeEntry TimeEntry) DurationBetween(from time.Time, to time.Time) time.Duration {
+	start, end := timeEntry.StartedAt, to
+	if timeEntry.EndedAt != nil && timeEntry.EndedAt.Before(end) {
+		end = *timeEntry.EndedAt
+	}
+	if from.After(start) {
+		start = from
+	}
+
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
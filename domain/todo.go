@@ -4,12 +4,101 @@ import (
 	"time"
 )
 
+const DueDateLayout = "2006-01-02"
+const DueTimeLayout = "15:04"
+
+// Todo fields that a partial update can write individually.
+const (
+	TodoFieldTitle          = "title"
+	TodoFieldDescription    = "description"
+	TodoFieldIsCompleted    = "isCompleted"
+	TodoFieldDueDate        = "dueDate"
+	TodoFieldDueTime        = "dueTime"
+	TodoFieldDueTimezone    = "dueTimezone"
+	TodoFieldRecurrenceRule = "recurrenceRule"
+	TodoFieldPriority       = "priority"
+	TodoFieldStatus         = "status"
+	TodoFieldEstimate       = "estimateMinutes"
+	TodoFieldCompletedAt    = "completedAt"
+	TodoFieldCompletedBy    = "completedBy"
+)
+
+// TodoPositionGap is the distance between the positions of neighbouring todos when they are appended
+// or renumbered, leaving room to move todos between them without touching other rows.
+const TodoPositionGap = 1024.0
+
+// MaxSubtaskDepth is the deepest level a subtask can be nested at; top-level todos are at depth 0.
+const MaxSubtaskDepth = 3
+
 type Todo struct {
-	Id          int       `json:"id"`
-	UserId      int       `json:"userId"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	IsCompleted bool      `json:"isCompleted"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Id              int        `json:"id"`
+	UserId          int        `json:"userId"`
+	ProjectId       *int       `json:"projectId"`
+	ParentId        *int       `json:"parentId"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	IsCompleted     bool       `json:"isCompleted"`
+	Status          string     `json:"status"`
+	Priority        int        `json:"priority"`
+	Position        float64    `json:"position"`
+	EstimateMinutes *int       `json:"estimateMinutes"`
+	AssigneeId      *int       `json:"assigneeId"`
+	AssignedBy      *int       `json:"assignedBy"`
+	AssignedAt      *time.Time `json:"assignedAt"`
+	CompletedAt     *time.Time `json:"completedAt"`
+	CompletedBy     *int       `json:"completedBy"`
+	DueDate         *time.Time `json:"dueDate"`
+	DueTime         *string    `json:"dueTime"`
+	DueTimezone     *string    `json:"dueTimezone"`
+	Tags            []Tag      `json:"tags"`
+	BlockedByIds    []int      `json:"blockedByIds"`
+	RecurrenceRule  *string    `json:"recurrenceRule"`
+	SeriesId        *int       `json:"seriesId"`
+	OccurrenceIndex int        `json:"occurrenceIndex"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	DeletedAt       *time.Time `json:"deletedAt"`
+	Version         int        `json:"version"`
+}
+
+// SyncCompletion records that the user completed the todo at now once it is completed, and clears the
+// record when it is reopened. A todo that stays completed keeps its original completion.
+func (todo *Todo) SyncCompletion(userId int, now time.Time) {
+	if !todo.IsCompleted {
+		todo.CompletedAt = nil
+		todo.CompletedBy = nil
+		return
+	}
+
+	if todo.CompletedAt == nil {
+		todo.CompletedAt = &now
+		todo.CompletedBy = &userId
+	}
+}
+
+// DueMoment returns the instant a todo becomes overdue. Todos without a due time
+// are due at the end of their due date in the given fallback location.
+func (todo Todo) DueMoment(fallbackLocation *time.Location) (time.Time, bool) {
+	if todo.DueDate == nil {
+		return time.Time{}, false
+	}
+
+	year, month, day := todo.DueDate.Date()
+	if todo.DueTime == nil {
+		return time.Date(year, month, day+1, 0, 0, 0, 0, fallbackLocation), true
+	}
+
+	location := fallbackLocation
+	if todo.DueTimezone != nil {
+		if dueLocation, err := time.LoadLocation(*todo.DueTimezone); err == nil {
+			location = dueLocation
+		}
+	}
+
+	dueTime, err := time.Parse(DueTimeLayout, *todo.DueTime)
+	if err != nil {
+		return time.Date(year, month, day+1, 0, 0, 0, 0, location), true
+	}
+
+	return time.Date(year, month, day, dueTime.Hour(), dueTime.Minute(), 0, 0, location), true
 }
@@ -0,0 +1,9 @@
+package domain
+
+const (
+	BulkActionComplete   = "complete"
+	BulkActionUncomplete = "uncomplete"
+	BulkActionDelete     = "delete"
+	BulkActionMove       = "move"
+	BulkActionAddTag     = "addTag"
+)
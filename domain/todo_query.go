@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	TodoSortByCreatedAt   = "createdAt"
+	TodoSortByUpdatedAt   = "updatedAt"
+	TodoSortByTitle       = "title"
+	TodoSortByDueDate     = "dueDate"
+	TodoSortByRelevance   = "relevance"
+	TodoSortByPosition    = "position"
+	TodoSortByPriority    = "priority"
+	TodoSortByCompletedAt = "completedAt"
+
+	SortDirectionAsc  = "asc"
+	SortDirectionDesc = "desc"
+
+	DueViewOverdue  = "overdue"
+	DueViewToday    = "today"
+	DueViewUpcoming = "upcoming"
+
+	TagMatchAll = "and"
+	TagMatchAny = "or"
+
+	TodoOwnerMe     = "me"
+	TodoOwnerOthers = "others"
+
+	TodoAssigneeMe   = "me"
+	TodoAssigneeNone = "none"
+)
+
+type TodoQuery struct {
+	UserId        int
+	ProjectId     *int
+	InboxOnly     bool
+	ParentId      *int
+	TopLevelOnly  bool
+	IsCompleted   *bool
+	Priority      *int
+	Priorities    []int
+	Status        string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	UpdatedFrom   *time.Time
+	UpdatedTo     *time.Time
+	CompletedFrom *time.Time
+	CompletedTo   *time.Time
+	Title         string
+	Search        string
+	DueFrom       *time.Time
+	DueTo         *time.Time
+	DueBefore     *time.Time
+	Timezone      string
+	Tags          []string
+	TagMode       string
+	AssigneeId    *int
+	Unassigned    bool
+	SortBy        string
+	SortDirection string
+	Limit         int
+	Offset        int
+
+	// SharedTodoIds and SharedProjectIds are the todos and projects of other users shared with the
+	// user, whose todos are listed together with the user's own. SharedOnly lists only those.
+	SharedTodoIds    []int
+	SharedProjectIds []int
+	SharedOnly       bool
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Actions recorded in the history of a todo.
+const (
+	TodoActionCreated  = "created"
+	TodoActionUpdated  = "updated"
+	TodoActionToggled  = "toggled"
+	TodoActionDeleted  = "deleted"
+	TodoActionRestored = "restored"
+	TodoActionReverted = "reverted"
+	TodoActionAssigned = "assigned"
+	TodoActionMoved    = "moved"
+)
+
+// Fields tracked in the history of a todo that are written apart from its other fields.
+const (
+	TodoFieldAssigneeId = "assigneeId"
+	TodoFieldProjectId  = "projectId"
+	TodoFieldParentId   = "parentId"
+	TodoFieldPosition   = "position"
+	TodoFieldTagIds     = "tagIds"
+)
+
+// TodoSnapshot holds the fields of a todo that its history tracks. A revert restores the fields an update
+// writes, but not the assignee, project, parent, position or tags, whose targets may have changed since.
+type TodoSnapshot struct {
+	Title           string  `json:"title"`
+	Description     string  `json:"description"`
+	IsCompleted     bool    `json:"isCompleted"`
+	Status          string  `json:"status"`
+	Priority        string  `json:"priority"`
+	EstimateMinutes *int    `json:"estimateMinutes"`
+	DueDate         *string `json:"dueDate"`
+	DueTime         *string `json:"dueTime"`
+	DueTimezone     *string `json:"dueTimezone"`
+	RecurrenceRule  *string `json:"recurrenceRule"`
+	AssigneeId      *int    `json:"assigneeId"`
+	ProjectId       *int    `json:"projectId"`
+	ParentId        *int    `json:"parentId"`
+	Position        float64 `json:"position"`
+	TagIds          []int   `json:"tagIds"`
+}
+
+type TodoFieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+// TodoRevision is an entry of the append-only history of a todo: who did what to it, which fields
+// changed and what the tracked fields looked like afterwards.
+type TodoRevision struct {
+	Id        int               `json:"id"`
+	TodoId    int               `json:"todoId"`
+	UserId    int               `json:"userId"`
+	Action    string            `json:"action"`
+	Changes   []TodoFieldChange `json:"changes"`
+	Snapshot  TodoSnapshot      `json:"snapshot"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+func NewTodoSnapshot(todo Todo) TodoSnapshot {
+	var dueDate *string
+	if todo.DueDate != nil {
+		formattedDueDate := todo.DueDate.Format(DueDateLayout)
+		dueDate = &formattedDueDate
+	}
+
+	tagIds := []int{}
+	for _, tag := range todo.Tags {
+		tagIds = append(tagIds, tag.Id)
+	}
+	slices.Sort(tagIds)
+
+	return TodoSnapshot{
+		Title:           todo.Title,
+		Description:     todo.Description,
+		IsCompleted:     todo.IsCompleted,
+		Status:          todo.Status,
+		Priority:        PriorityNames[todo.Priority],
+		EstimateMinutes: todo.EstimateMinutes,
+		DueDate:         dueDate,
+		DueTime:         todo.DueTime,
+		DueTimezone:     todo.DueTimezone,
+		RecurrenceRule:  todo.RecurrenceRule,
+		AssigneeId:      todo.AssigneeId,
+		ProjectId:       todo.ProjectId,
+		ParentId:        todo.ParentId,
+		Position:        todo.Position,
+		TagIds:          tagIds,
+	}
+}
+
+// Changes returns the fields whose value differs between the previous snapshot and this one.
+func (snapshot TodoSnapshot) Changes(previous TodoSnapshot) []TodoFieldChange {
+	changes := []TodoFieldChange{}
+	addChange := func(field string, oldValue interface{}, newValue interface{}) {
+		if oldValue != newValue {
+			changes = append(changes, TodoFieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	addChange(TodoFieldTitle, previous.Title, snapshot.Title)
+	addChange(TodoFieldDescription, previous.Description, snapshot.Description)
+	addChange(TodoFieldIsCompleted, previous.IsCompleted, snapshot.IsCompleted)
+	addChange(TodoFieldStatus, previous.Status, snapshot.Status)
+	addChange(TodoFieldPriority, previous.Priority, snapshot.Priority)
+	addChange(TodoFieldEstimate, nullableValue(previous.EstimateMinutes), nullableValue(snapshot.EstimateMinutes))
+	addChange(TodoFieldDueDate, nullableValue(previous.DueDate), nullableValue(snapshot.DueDate))
+	addChange(TodoFieldDueTime, nullableValue(previous.DueTime), nullableValue(snapshot.DueTime))
+	addChange(TodoFieldDueTimezone, nullableValue(previous.DueTimezone), nullableValue(snapshot.DueTimezone))
+	addChange(TodoFieldRecurrenceRule, nullableValue(previous.RecurrenceRule), nullableValue(snapshot.RecurrenceRule))
+	addChange(TodoFieldAssigneeId, nullableValue(previous.AssigneeId), nullableValue(snapshot.AssigneeId))
+	addChange(TodoFieldProjectId, nullableValue(previous.ProjectId), nullableValue(snapshot.ProjectId))
+	addChange(TodoFieldParentId, nullableValue(previous.ParentId), nullableValue(snapshot.ParentId))
+	addChange(TodoFieldPosition, previous.Position, snapshot.Position)
+	if !slices.Equal(previous.TagIds, snapshot.TagIds) {
+		changes = append(changes, TodoFieldChange{Field: TodoFieldTagIds, OldValue: previous.TagIds, NewValue: snapshot.TagIds})
+	}
+
+	return changes
+}
+
+func nullableValue[T comparable](value *T) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
@@ -0,0 +1,10 @@
+package domain
+
+// TodoSearchResult is a todo matched by a full-text search together with its relevance and
+// the matched words of its title and description highlighted.
+type TodoSearchResult struct {
+	Todo                 Todo
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// Periods that completions can be grouped by.
+const (
+	StatsPeriodDay  = "day"
+	StatsPeriodWeek = "week"
+)
+
+// TodoStats are the aggregate counts of the todos a user owns. A todo is overdue while it is open and
+// past its due moment, and the completion streak is the number of consecutive days up to today, or up
+// to yesterday while nothing was completed today yet, on which the user completed at least one todo.
+type TodoStats struct {
+	OpenCount             int
+	CompletedCount        int
+	OverdueCount          int
+	AverageTimeToComplete *time.Duration
+	CurrentStreakDays     int
+}
+
+// CompletionCount is the number of todos completed in the day or week starting on PeriodStart.
+type CompletionCount struct {
+	PeriodStart time.Time
+	Count       int
+}
@@ -0,0 +1,90 @@
+package domain
+
+// Keys of the statuses in the workflow users get until they configure their own.
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusDone       = "done"
+)
+
+// WorkflowStatus is a column of a user's board. Todos in a status that is done count as completed.
+type WorkflowStatus struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	IsDone bool   `json:"isDone"`
+}
+
+type WorkflowTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Workflow lists the statuses of a user in board order. When it has no transitions a todo may move
+// from any status to any other.
+type Workflow struct {
+	UserId      int                  `json:"userId"`
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+func DefaultWorkflow(userId int) Workflow {
+	return Workflow{
+		UserId: userId,
+		Statuses: []WorkflowStatus{
+			{Key: StatusTodo, Name: "To do"},
+			{Key: StatusInProgress, Name: "In progress"},
+			{Key: StatusBlocked, Name: "Blocked"},
+			{Key: StatusDone, Name: "Done", IsDone: true},
+		},
+	}
+}
+
+func (workflow Workflow) Status(key string) (WorkflowStatus, bool) {
+	for _, status := range workflow.Statuses {
+		if status.Key == key {
+			return status, true
+		}
+	}
+
+	return WorkflowStatus{}, false
+}
+
+// CompletionStatus returns the first done status for completed todos and the first open status otherwise.
+func (workflow Workflow) CompletionStatus(isCompleted bool) WorkflowStatus {
+	for _, status := range workflow.Statuses {
+		if status.IsDone == isCompleted {
+			return status
+		}
+	}
+
+	return WorkflowStatus{}
+}
+
+// StatusOf returns the status of todo, falling back to its completion when the todo has a status
+// the workflow no longer knows.
+func (workflow Workflow) StatusOf(todo Todo) WorkflowStatus {
+	status, found := workflow.Status(todo.Status)
+	if !found || status.IsDone != todo.IsCompleted {
+		return workflow.CompletionStatus(todo.IsCompleted)
+	}
+
+	return status
+}
+
+func (workflow Workflow) CanTransition(from string, to string) bool {
+	if len(workflow.Transitions) == 0 || from == to {
+		return true
+	}
+	if _, found := workflow.Status(from); !found {
+		return true
+	}
+
+	for _, transition := range workflow.Transitions {
+		if transition.From == from && transition.To == to {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+const attachmentColumns = `id, todo_id, user_id, file_name, content_type, size, blob_key, created_at`
+
+type IAttachmentRepository interface {
+	GetAttachmentsByTodoId(todoId int) ([]domain.Attachment, error)
+	GetAttachmentById(attachmentId int) (domain.Attachment, error)
+	AddAttachment(attachment domain.Attachment) (domain.Attachment, error)
+	DeleteAttachment(attachmentId int) error
+	CountAttachmentsByBlobKey(blobKey string) (int, error)
+	GetOrphanedAttachments() ([]domain.Attachment, error)
+}
+
+type AttachmentRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewAttachmentRepository(dbPool *pgxpool.Pool) IAttachmentRepository {
+	return &AttachmentRepository{dbPool: dbPool}
+}
+
+func (attachmentRepository *AttachmentRepository) GetAttachmentsByTodoId(todoId int) ([]domain.Attachment, error) {
+	ctx := context.Background()
+	getByTodoIdSql := `SELECT ` + attachmentColumns + ` FROM attachments WHERE todo_id = $1 ORDER BY created_at, id`
+	queryRow, err := attachmentRepository.dbPool.Query(ctx, getByTodoIdSql, todoId)
+	if err != nil {
+		return []domain.Attachment{}, err
+	}
+
+	return extractAttachmentsFromRows(queryRow), nil
+}
+
+func (attachmentRepository *AttachmentRepository) GetAttachmentById(attachmentId int) (domain.Attachment, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1`
+	queryRow := attachmentRepository.dbPool.QueryRow(ctx, getByIdSql, attachmentId)
+
+	var attachment domain.Attachment
+	scanErr := scanAttachment(queryRow, &attachment)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Attachment{}, errors.New(fmt.Sprintf("Attachment with id %d not found", attachmentId))
+		}
+		return domain.Attachment{}, errors.New(fmt.Sprintf("Error while getting attachment with id %d: %v", attachmentId, scanErr))
+	}
+
+	return attachment, nil
+}
+
+func (attachmentRepository *AttachmentRepository) AddAttachment(attachment domain.Attachment) (domain.Attachment, error) {
+	ctx := context.Background()
+	insertSql := `INSERT INTO attachments (todo_id, user_id, file_name, content_type, size, blob_key, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	queryRow := attachmentRepository.dbPool.QueryRow(ctx, insertSql, attachment.TodoId, attachment.UserId, attachment.FileName, attachment.ContentType, attachment.Size, attachment.BlobKey, attachment.CreatedAt)
+	scanErr := queryRow.Scan(&attachment.Id)
+	if scanErr != nil {
+		return domain.Attachment{}, scanErr
+	}
+
+	return attachment, nil
+}
+
+func (attachmentRepository *AttachmentRepository) DeleteAttachment(attachmentId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM attachments WHERE id = $1`
+	commandTag, err := attachmentRepository.dbPool.Exec(ctx, deleteSql, attachmentId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while deleting attachment with id %d", attachmentId))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Attachment with id %d not found", attachmentId))
+	}
+
+	return nil
+}
+
+func (attachmentRepository *AttachmentRepository) CountAttachmentsByBlobKey(blobKey string) (int, error) {
+	ctx := context.Background()
+	var count int
+	countSql := `SELECT COUNT(*) FROM attachments WHERE blob_key = $1`
+	err := attachmentRepository.dbPool.QueryRow(ctx, countSql, blobKey).Scan(&count)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Error while counting attachments of blob %s: %v", blobKey, err))
+	}
+
+	return count, nil
+}
+
+// GetOrphanedAttachments returns the attachments whose todo has been permanently deleted.
+func (attachmentRepository *AttachmentRepository) GetOrphanedAttachments() ([]domain.Attachment, error) {
+	ctx := context.Background()
+	getOrphanedSql := `SELECT ` + attachmentColumns + ` FROM attachments WHERE todo_id IS NULL ORDER BY id`
+	queryRow, err := attachmentRepository.dbPool.Query(ctx, getOrphanedSql)
+	if err != nil {
+		return []domain.Attachment{}, err
+	}
+
+	return extractAttachmentsFromRows(queryRow), nil
+}
+
+func scanAttachment(queryRow pgx.Row, attachment *domain.Attachment) error {
+	return queryRow.Scan(
+		&attachment.Id,
+		&attachment.TodoId,
+		&attachment.UserId,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.BlobKey,
+		&attachment.CreatedAt,
+	)
+}
+
+func extractAttachmentsFromRows(queryRow pgx.Rows) []domain.Attachment {
+	var attachments = []domain.Attachment{}
+	for queryRow.Next() {
+		var attachment domain.Attachment
+		err := scanAttachment(queryRow, &attachment)
+		if err != nil {
+			continue
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments
+}
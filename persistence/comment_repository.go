@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+const commentColumns = `id, todo_id, user_id, body, created_at, edited_at`
+
+type ICommentRepository interface {
+	GetCommentsByTodoId(todoId int, limit int, offset int) ([]domain.Comment, int, error)
+	GetCommentById(commentId int) (domain.Comment, error)
+	AddComment(comment domain.Comment) (domain.Comment, error)
+	UpdateComment(commentId int, comment domain.Comment) (domain.Comment, error)
+	DeleteComment(commentId int) error
+}
+
+type CommentRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewCommentRepository(dbPool *pgxpool.Pool) ICommentRepository {
+	return &CommentRepository{dbPool: dbPool}
+}
+
+// GetCommentsByTodoId returns a page of the comments on a todo, oldest first, with the total number of comments.
+func (commentRepository *CommentRepository) GetCommentsByTodoId(todoId int, limit int, offset int) ([]domain.Comment, int, error) {
+	ctx := context.Background()
+	var total int
+	countSql := `SELECT COUNT(*) FROM comments WHERE todo_id = $1`
+	err := commentRepository.dbPool.QueryRow(ctx, countSql, todoId).Scan(&total)
+	if err != nil {
+		return []domain.Comment{}, 0, errors.New(fmt.Sprintf("Error while counting comments of todo with id %d: %v", todoId, err))
+	}
+
+	getByTodoIdSql := `SELECT ` + commentColumns + ` FROM comments WHERE todo_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`
+	queryRow, err := commentRepository.dbPool.Query(ctx, getByTodoIdSql, todoId, limit, offset)
+	if err != nil {
+		return []domain.Comment{}, 0, errors.New(fmt.Sprintf("Error while getting comments of todo with id %d: %v", todoId, err))
+	}
+
+	return extractCommentsFromRows(queryRow), total, nil
+}
+
+func (commentRepository *CommentRepository) GetCommentById(commentId int) (domain.Comment, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + commentColumns + ` FROM comments WHERE id = $1`
+	queryRow := commentRepository.dbPool.QueryRow(ctx, getByIdSql, commentId)
+
+	var comment domain.Comment
+	scanErr := scanComment(queryRow, &comment)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Comment{}, errors.New(fmt.Sprintf("Comment with id %d not found", commentId))
+		}
+		return domain.Comment{}, errors.New(fmt.Sprintf("Error while getting comment with id %d: %v", commentId, scanErr))
+	}
+
+	return comment, nil
+}
+
+func (commentRepository *CommentRepository) AddComment(comment domain.Comment) (domain.Comment, error) {
+	ctx := context.Background()
+	insertSql := `INSERT INTO comments (todo_id, user_id, body, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	queryRow := commentRepository.dbPool.QueryRow(ctx, insertSql, comment.TodoId, comment.UserId, comment.Body, comment.CreatedAt)
+	scanErr := queryRow.Scan(&comment.Id)
+	if scanErr != nil {
+		return domain.Comment{}, scanErr
+	}
+
+	return comment, nil
+}
+
+func (commentRepository *CommentRepository) UpdateComment(commentId int, comment domain.Comment) (domain.Comment, error) {
+	ctx := context.Background()
+	updateCommentSql := `UPDATE comments SET body = $1, edited_at = $2 WHERE id = $3 RETURNING ` + commentColumns
+	queryRow := commentRepository.dbPool.QueryRow(ctx, updateCommentSql, comment.Body, comment.EditedAt, commentId)
+	scanErr := scanComment(queryRow, &comment)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Comment{}, errors.New(fmt.Sprintf("Comment with id %d not found", commentId))
+		}
+		return domain.Comment{}, errors.New(fmt.Sprintf("Failed to update comment: %v", scanErr))
+	}
+
+	return comment, nil
+}
+
+func (commentRepository *CommentRepository) DeleteComment(commentId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM comments WHERE id = $1`
+	commandTag, err := commentRepository.dbPool.Exec(ctx, deleteSql, commentId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while deleting comment with id %d", commentId))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Comment with id %d not found", commentId))
+	}
+
+	return nil
+}
+
+func scanComment(queryRow pgx.Row, comment *domain.Comment) error {
+	return queryRow.Scan(
+		&comment.Id,
+		&comment.TodoId,
+		&comment.UserId,
+		&comment.Body,
+		&comment.CreatedAt,
+		&comment.EditedAt,
+	)
+}
+
+func extractCommentsFromRows(queryRow pgx.Rows) []domain.Comment {
+	var comments = []domain.Comment{}
+	for queryRow.Next() {
+		var comment domain.Comment
+		err := scanComment(queryRow, &comment)
+		if err != nil {
+			continue
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments
+}
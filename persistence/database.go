@@ -0,0 +1,16 @@
+package persistence
+
+import (
+	"context"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// database is satisfied by both *pgxpool.Pool and pgx.Tx, so a repository runs the same
+// queries whether or not it is bound to a transaction. Begin on a pgx.Tx creates a savepoint.
+type database interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
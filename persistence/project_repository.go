@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+const projectColumns = `projects.id, projects.user_id, projects.name, projects.color, projects.is_archived, projects.position,
+	COUNT(todos.id), COUNT(todos.id) FILTER (WHERE todos.is_completed), projects.created_at, projects.updated_at`
+
+const projectFromSql = ` FROM projects LEFT JOIN todos ON todos.project_id = projects.id AND todos.deleted_at IS NULL `
+
+type IProjectRepository interface {
+	GetAllProjectsByUserId(userId int, includeArchived bool) ([]domain.Project, error)
+	GetProjectById(projectId int) (domain.Project, error)
+	AddProject(project domain.Project) (domain.Project, error)
+	UpdateProject(projectId int, project domain.Project) (domain.Project, error)
+	DeleteProject(projectId int, deleteMode string) error
+}
+
+type ProjectRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewProjectRepository(dbPool *pgxpool.Pool) IProjectRepository {
+	return &ProjectRepository{dbPool: dbPool}
+}
+
+func (projectRepository *ProjectRepository) GetAllProjectsByUserId(userId int, includeArchived bool) ([]domain.Project, error) {
+	ctx := context.Background()
+	getByUserIdSql := `SELECT ` + projectColumns + projectFromSql + `
+		WHERE projects.user_id = $1 AND ($2 OR NOT projects.is_archived)
+		GROUP BY projects.id ORDER BY projects.position, projects.id`
+	queryRow, err := projectRepository.dbPool.Query(ctx, getByUserIdSql, userId, includeArchived)
+	if err != nil {
+		return []domain.Project{}, err
+	}
+
+	return extractProjectsFromRows(queryRow), nil
+}
+
+func (projectRepository *ProjectRepository) GetProjectById(projectId int) (domain.Project, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + projectColumns + projectFromSql + `WHERE projects.id = $1 GROUP BY projects.id`
+	queryRow := projectRepository.dbPool.QueryRow(ctx, getByIdSql, projectId)
+
+	var project domain.Project
+	scanErr := scanProject(queryRow, &project)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Project{}, errors.New(fmt.Sprintf("Project with id %d not found", projectId))
+		}
+		return domain.Project{}, errors.New(fmt.Sprintf("Error while getting project with id %d: %v", projectId, scanErr))
+	}
+
+	return project, nil
+}
+
+func (projectRepository *ProjectRepository) AddProject(project domain.Project) (domain.Project, error) {
+	ctx := context.Background()
+	insertSql := `INSERT INTO projects (user_id, name, color, is_archived, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(position), 0) + 1 FROM projects WHERE user_id = $1), $5, $6)
+		RETURNING id, position`
+	queryRow := projectRepository.dbPool.QueryRow(ctx, insertSql, project.UserId, project.Name, project.Color, project.IsArchived, project.CreatedAt, project.UpdatedAt)
+	scanErr := queryRow.Scan(&project.Id, &project.Position)
+	if scanErr != nil {
+		return domain.Project{}, scanErr
+	}
+
+	return project, nil
+}
+
+func (projectRepository *ProjectRepository) UpdateProject(projectId int, project domain.Project) (domain.Project, error) {
+	ctx := context.Background()
+	updateProjectSql := `UPDATE projects SET name = $1, color = $2, is_archived = $3, position = $4, updated_at = $5 WHERE id = $6`
+	commandTag, err := projectRepository.dbPool.Exec(ctx, updateProjectSql, project.Name, project.Color, project.IsArchived, project.Position, project.UpdatedAt, projectId)
+	if err != nil {
+		return domain.Project{}, errors.New(fmt.Sprintf("Failed to update project: %v", err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return domain.Project{}, errors.New(fmt.Sprintf("Project with id %d not found", projectId))
+	}
+
+	return projectRepository.GetProjectById(projectId)
+}
+
+func (projectRepository *ProjectRepository) DeleteProject(projectId int, deleteMode string) error {
+	ctx := context.Background()
+	return projectRepository.dbPool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var todosSql string
+		if deleteMode == domain.ProjectDeleteModeCascade {
+			todosSql = `UPDATE todos SET project_id = NULL, deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP) WHERE project_id = $1`
+		} else {
+			todosSql = `UPDATE todos SET project_id = NULL WHERE project_id = $1`
+		}
+
+		_, err := tx.Exec(ctx, todosSql, projectId)
+		if err != nil {
+			return errors.New(fmt.Sprintf("Error while releasing todos of project with id %d: %v", projectId, err))
+		}
+
+		commandTag, err := tx.Exec(ctx, `DELETE FROM projects WHERE id = $1`, projectId)
+		if err != nil {
+			return errors.New(fmt.Sprintf("Error while deleting project with id %d", projectId))
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			return errors.New(fmt.Sprintf("Project with id %d not found", projectId))
+		}
+
+		return nil
+	})
+}
+
+func scanProject(queryRow pgx.Row, project *domain.Project) error {
+	return queryRow.Scan(
+		&project.Id,
+		&project.UserId,
+		&project.Name,
+		&project.Color,
+		&project.IsArchived,
+		&project.Position,
+		&project.TodoCount,
+		&project.CompletedTodoCount,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+}
+
+func extractProjectsFromRows(queryRow pgx.Rows) []domain.Project {
+	var projects = []domain.Project{}
+	for queryRow.Next() {
+		var project domain.Project
+		err := scanProject(queryRow, &project)
+		if err != nil {
+			continue
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects
+}
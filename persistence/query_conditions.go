@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"fmt"
+	"strings"
+)
+
+type queryConditions struct {
+	clauses []string
+	args    []interface{}
+}
+
+func (conditions *queryConditions) add(clauseFormat string, values ...interface{}) {
+	placeholders := make([]interface{}, len(values))
+	for i, value := range values {
+		conditions.args = append(conditions.args, value)
+		placeholders[i] = len(conditions.args)
+	}
+
+	conditions.clauses = append(conditions.clauses, fmt.Sprintf(clauseFormat, placeholders...))
+}
+
+func (conditions *queryConditions) nextPlaceholder(value interface{}) string {
+	conditions.args = append(conditions.args, value)
+
+	return fmt.Sprintf("$%d", len(conditions.args))
+}
+
+func (conditions *queryConditions) where() string {
+	if len(conditions.clauses) == 0 {
+		return "TRUE"
+	}
+
+	return strings.Join(conditions.clauses, " AND ")
+}
+
+func escapeLikePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+	return replacer.Replace(value)
+}
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+const savedFilterColumns = `id, user_id, name, expression, created_at, updated_at`
+
+type ISavedFilterRepository interface {
+	GetSavedFiltersByUserId(userId int) ([]domain.SavedFilter, error)
+	GetSavedFilterById(savedFilterId int) (domain.SavedFilter, error)
+	AddSavedFilter(savedFilter domain.SavedFilter) (domain.SavedFilter, error)
+	UpdateSavedFilter(savedFilter domain.SavedFilter) (domain.SavedFilter, error)
+	DeleteSavedFilter(savedFilterId int) error
+}
+
+type SavedFilterRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewSavedFilterRepository(dbPool *pgxpool.Pool) ISavedFilterRepository {
+	return &SavedFilterRepository{dbPool: dbPool}
+}
+
+func (savedFilterRepository *SavedFilterRepository) GetSavedFiltersByUserId(userId int) ([]domain.SavedFilter, error) {
+	ctx := context.Background()
+	getByUserIdSql := `SELECT ` + savedFilterColumns + ` FROM saved_filters WHERE user_id = $1 ORDER BY name, id`
+	queryRow, err := savedFilterRepository.dbPool.Query(ctx, getByUserIdSql, userId)
+	if err != nil {
+		return []domain.SavedFilter{}, errors.New(fmt.Sprintf("Error while getting saved filters of user with id %d: %v", userId, err))
+	}
+
+	return extractSavedFiltersFromRows(queryRow), nil
+}
+
+func (savedFilterRepository *SavedFilterRepository) GetSavedFilterById(savedFilterId int) (domain.SavedFilter, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + savedFilterColumns + ` FROM saved_filters WHERE id = $1`
+	queryRow := savedFilterRepository.dbPool.QueryRow(ctx, getByIdSql, savedFilterId)
+
+	var savedFilter domain.SavedFilter
+	scanErr := scanSavedFilter(queryRow, &savedFilter)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.SavedFilter{}, errors.New(fmt.Sprintf("Saved filter with id %d not found", savedFilterId))
+		}
+		return domain.SavedFilter{}, errors.New(fmt.Sprintf("Error while getting saved filter with id %d: %v", savedFilterId, scanErr))
+	}
+
+	return savedFilter, nil
+}
+
+func (savedFilterRepository *SavedFilterRepository) AddSavedFilter(savedFilter domain.SavedFilter) (domain.SavedFilter, error) {
+	ctx := context.Background()
+	insertSql := `INSERT INTO saved_filters (user_id, name, expression, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	queryRow := savedFilterRepository.dbPool.QueryRow(ctx, insertSql, savedFilter.UserId, savedFilter.Name, savedFilter.Expression, savedFilter.CreatedAt, savedFilter.UpdatedAt)
+	scanErr := queryRow.Scan(&savedFilter.Id)
+	if scanErr != nil {
+		return domain.SavedFilter{}, scanErr
+	}
+
+	return savedFilter, nil
+}
+
+func (savedFilterRepository *SavedFilterRepository) UpdateSavedFilter(savedFilter domain.SavedFilter) (domain.SavedFilter, error) {
+	ctx := context.Background()
+	updateSql := `UPDATE saved_filters SET name = $1, expression = $2, updated_at = $3 WHERE id = $4 RETURNING ` + savedFilterColumns
+	queryRow := savedFilterRepository.dbPool.QueryRow(ctx, updateSql, savedFilter.Name, savedFilter.Expression, savedFilter.UpdatedAt, savedFilter.Id)
+
+	var updatedSavedFilter domain.SavedFilter
+	scanErr := scanSavedFilter(queryRow, &updatedSavedFilter)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.SavedFilter{}, errors.New(fmt.Sprintf("Saved filter with id %d not found", savedFilter.Id))
+		}
+		return domain.SavedFilter{}, errors.New(fmt.Sprintf("Failed to update saved filter: %v", scanErr))
+	}
+
+	return updatedSavedFilter, nil
+}
+
+func (savedFilterRepository *SavedFilterRepository) DeleteSavedFilter(savedFilterId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM saved_filters WHERE id = $1`
+	commandTag, err := savedFilterRepository.dbPool.Exec(ctx, deleteSql, savedFilterId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while deleting saved filter with id %d", savedFilterId))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Saved filter with id %d not found", savedFilterId))
+	}
+
+	return nil
+}
+
+func scanSavedFilter(queryRow pgx.Row, savedFilter *domain.SavedFilter) error {
+	return queryRow.Scan(
+		&savedFilter.Id,
+		&savedFilter.UserId,
+		&savedFilter.Name,
+		&savedFilter.Expression,
+		&savedFilter.CreatedAt,
+		&savedFilter.UpdatedAt,
+	)
+}
+
+func extractSavedFiltersFromRows(queryRow pgx.Rows) []domain.SavedFilter {
+	var savedFilters = []domain.SavedFilter{}
+	for queryRow.Next() {
+		var savedFilter domain.SavedFilter
+		err := scanSavedFilter(queryRow, &savedFilter)
+		if err != nil {
+			continue
+		}
+
+		savedFilters = append(savedFilters, savedFilter)
+	}
+
+	return savedFilters
+}
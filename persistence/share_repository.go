@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+const shareColumns = `shares.id, shares.todo_id, shares.project_id, shares.user_id, users.email, shares.permission, shares.created_at`
+
+type IShareRepository interface {
+	GetSharesByTodoId(todoId int) ([]domain.Share, error)
+	GetSharesByProjectId(projectId int) ([]domain.Share, error)
+	GetSharesByUserId(userId int) ([]domain.Share, error)
+	SaveShare(share domain.Share) (domain.Share, error)
+	DeleteTodoShare(todoId int, userId int) error
+	DeleteProjectShare(projectId int, userId int) error
+}
+
+type ShareRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewShareRepository(dbPool *pgxpool.Pool) IShareRepository {
+	return &ShareRepository{dbPool: dbPool}
+}
+
+func (shareRepository *ShareRepository) GetSharesByTodoId(todoId int) ([]domain.Share, error) {
+	return shareRepository.getShares(`shares.todo_id = $1`, todoId)
+}
+
+func (shareRepository *ShareRepository) GetSharesByProjectId(projectId int) ([]domain.Share, error) {
+	return shareRepository.getShares(`shares.project_id = $1`, projectId)
+}
+
+// GetSharesByUserId returns the todo and project shares that give the user access to todos of other users.
+func (shareRepository *ShareRepository) GetSharesByUserId(userId int) ([]domain.Share, error) {
+	return shareRepository.getShares(`shares.user_id = $1`, userId)
+}
+
+// SaveShare shares a todo or project with a user, replacing the permission when it is already shared with them.
+func (shareRepository *ShareRepository) SaveShare(share domain.Share) (domain.Share, error) {
+	ctx := context.Background()
+	conflictTarget := `(todo_id, user_id) WHERE todo_id IS NOT NULL`
+	if share.ProjectId != nil {
+		conflictTarget = `(project_id, user_id) WHERE project_id IS NOT NULL`
+	}
+
+	upsertSql := `INSERT INTO shares (todo_id, project_id, user_id, permission, created_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT ` + conflictTarget + ` DO UPDATE SET permission = EXCLUDED.permission RETURNING id, created_at`
+	queryRow := shareRepository.dbPool.QueryRow(ctx, upsertSql, share.TodoId, share.ProjectId, share.UserId, share.Permission, share.CreatedAt)
+	scanErr := queryRow.Scan(&share.Id, &share.CreatedAt)
+	if scanErr != nil {
+		return domain.Share{}, errors.New(fmt.Sprintf("Failed to save share: %v", scanErr))
+	}
+
+	return share, nil
+}
+
+func (shareRepository *ShareRepository) DeleteTodoShare(todoId int, userId int) error {
+	return shareRepository.deleteShare(`DELETE FROM shares WHERE todo_id = $1 AND user_id = $2`, todoId, userId)
+}
+
+func (shareRepository *ShareRepository) DeleteProjectShare(projectId int, userId int) error {
+	return shareRepository.deleteShare(`DELETE FROM shares WHERE project_id = $1 AND user_id = $2`, projectId, userId)
+}
+
+func (shareRepository *ShareRepository) getShares(condition string, args ...interface{}) ([]domain.Share, error) {
+	ctx := context.Background()
+	getSharesSql := `SELECT ` + shareColumns + ` FROM shares JOIN users ON users.id = shares.user_id WHERE ` + condition + ` ORDER BY shares.created_at, shares.id`
+	queryRow, err := shareRepository.dbPool.Query(ctx, getSharesSql, args...)
+	if err != nil {
+		return []domain.Share{}, errors.New(fmt.Sprintf("Error while getting shares: %v", err))
+	}
+
+	return extractSharesFromRows(queryRow), nil
+}
+
+func (shareRepository *ShareRepository) deleteShare(deleteSql string, id int, userId int) error {
+	ctx := context.Background()
+	commandTag, err := shareRepository.dbPool.Exec(ctx, deleteSql, id, userId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while deleting share: %v", err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Not shared with user with id %d", userId))
+	}
+
+	return nil
+}
+
+func scanShare(queryRow pgx.Row, share *domain.Share) error {
+	return queryRow.Scan(
+		&share.Id,
+		&share.TodoId,
+		&share.ProjectId,
+		&share.UserId,
+		&share.Email,
+		&share.Permission,
+		&share.CreatedAt,
+	)
+}
+
+func extractSharesFromRows(queryRow pgx.Rows) []domain.Share {
+	var shares = []domain.Share{}
+	for queryRow.Next() {
+		var share domain.Share
+		err := scanShare(queryRow, &share)
+		if err != nil {
+			continue
+		}
+
+		shares = append(shares, share)
+	}
+
+	return shares
+}
@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+const tagColumns = `id, user_id, name, color, created_at`
+
+type ITagRepository interface {
+	GetAllTagsByUserId(userId int) ([]domain.Tag, error)
+	GetTagById(tagId int) (domain.Tag, error)
+	AddTag(tag domain.Tag) (domain.Tag, error)
+	UpdateTag(tagId int, tag domain.Tag) (domain.Tag, error)
+	DeleteTag(tagId int) error
+}
+
+type TagRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTagRepository(dbPool *pgxpool.Pool) ITagRepository {
+	return &TagRepository{dbPool: dbPool}
+}
+
+func (tagRepository *TagRepository) GetAllTagsByUserId(userId int) ([]domain.Tag, error) {
+	ctx := context.Background()
+	getByUserIdSql := `SELECT ` + tagColumns + ` FROM tags WHERE user_id = $1 ORDER BY name`
+	queryRow, err := tagRepository.dbPool.Query(ctx, getByUserIdSql, userId)
+	if err != nil {
+		return []domain.Tag{}, err
+	}
+
+	return extractTagsFromRows(queryRow), nil
+}
+
+func (tagRepository *TagRepository) GetTagById(tagId int) (domain.Tag, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + tagColumns + ` FROM tags WHERE id = $1`
+	queryRow := tagRepository.dbPool.QueryRow(ctx, getByIdSql, tagId)
+
+	var tag domain.Tag
+	scanErr := scanTag(queryRow, &tag)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Tag{}, errors.New(fmt.Sprintf("Tag with id %d not found", tagId))
+		}
+		return domain.Tag{}, errors.New(fmt.Sprintf("Error while getting tag with id %d: %v", tagId, scanErr))
+	}
+
+	return tag, nil
+}
+
+func (tagRepository *TagRepository) AddTag(tag domain.Tag) (domain.Tag, error) {
+	ctx := context.Background()
+	insertSql := `INSERT INTO tags (user_id, name, color, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	queryRow := tagRepository.dbPool.QueryRow(ctx, insertSql, tag.UserId, tag.Name, tag.Color, tag.CreatedAt)
+	scanErr := queryRow.Scan(&tag.Id)
+	if scanErr != nil {
+		return domain.Tag{}, scanErr
+	}
+
+	return tag, nil
+}
+
+func (tagRepository *TagRepository) UpdateTag(tagId int, tag domain.Tag) (domain.Tag, error) {
+	ctx := context.Background()
+	updateTagSql := `UPDATE tags SET name = $1, color = $2 WHERE id = $3 RETURNING ` + tagColumns
+	queryRow := tagRepository.dbPool.QueryRow(ctx, updateTagSql, tag.Name, tag.Color, tagId)
+	scanErr := scanTag(queryRow, &tag)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Tag{}, errors.New(fmt.Sprintf("Tag with id %d not found", tagId))
+		}
+		return domain.Tag{}, errors.New(fmt.Sprintf("Failed to update tag: %v", scanErr))
+	}
+
+	return tag, nil
+}
+
+func (tagRepository *TagRepository) DeleteTag(tagId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM tags WHERE id = $1`
+	commandTag, err := tagRepository.dbPool.Exec(ctx, deleteSql, tagId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while deleting tag with id %d", tagId))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Tag with id %d not found", tagId))
+	}
+
+	return nil
+}
+
+func scanTag(queryRow pgx.Row, tag *domain.Tag) error {
+	return queryRow.Scan(
+		&tag.Id,
+		&tag.UserId,
+		&tag.Name,
+		&tag.Color,
+		&tag.CreatedAt,
+	)
+}
+
+func extractTagsFromRows(queryRow pgx.Rows) []domain.Tag {
+	var tags = []domain.Tag{}
+	for queryRow.Next() {
+		var tag domain.Tag
+		err := scanTag(queryRow, &tag)
+		if err != nil {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+const templateColumns = `id, user_id, name, description, items, created_at, updated_at`
+
+type ITemplateRepository interface {
+	GetTemplatesByUserId(userId int) ([]domain.Template, error)
+	GetTemplateById(templateId int) (domain.Template, error)
+	AddTemplate(template domain.Template) (domain.Template, error)
+	UpdateTemplate(template domain.Template) (domain.Template, error)
+	DeleteTemplate(templateId int) error
+}
+
+type TemplateRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTemplateRepository(dbPool *pgxpool.Pool) ITemplateRepository {
+	return &TemplateRepository{dbPool: dbPool}
+}
+
+func (templateRepository *TemplateRepository) GetTemplatesByUserId(userId int) ([]domain.Template, error) {
+	ctx := context.Background()
+	getByUserIdSql := `SELECT ` + templateColumns + ` FROM templates WHERE user_id = $1 ORDER BY name, id`
+	queryRow, err := templateRepository.dbPool.Query(ctx, getByUserIdSql, userId)
+	if err != nil {
+		return []domain.Template{}, errors.New(fmt.Sprintf("Error while getting templates of user with id %d: %v", userId, err))
+	}
+
+	return extractTemplatesFromRows(queryRow), nil
+}
+
+func (templateRepository *TemplateRepository) GetTemplateById(templateId int) (domain.Template, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + templateColumns + ` FROM templates WHERE id = $1`
+	queryRow := templateRepository.dbPool.QueryRow(ctx, getByIdSql, templateId)
+
+	var template domain.Template
+	scanErr := scanTemplate(queryRow, &template)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Template{}, errors.New(fmt.Sprintf("Template with id %d not found", templateId))
+		}
+		return domain.Template{}, errors.New(fmt.Sprintf("Error while getting template with id %d: %v", templateId, scanErr))
+	}
+
+	return template, nil
+}
+
+func (templateRepository *TemplateRepository) AddTemplate(template domain.Template) (domain.Template, error) {
+	ctx := context.Background()
+	insertSql := `INSERT INTO templates (user_id, name, description, items, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	queryRow := templateRepository.dbPool.QueryRow(ctx, insertSql, template.UserId, template.Name, template.Description, template.Items, template.CreatedAt, template.UpdatedAt)
+	scanErr := queryRow.Scan(&template.Id)
+	if scanErr != nil {
+		return domain.Template{}, scanErr
+	}
+
+	return template, nil
+}
+
+func (templateRepository *TemplateRepository) UpdateTemplate(template domain.Template) (domain.Template, error) {
+	ctx := context.Background()
+	updateSql := `UPDATE templates SET name = $1, description = $2, items = $3, updated_at = $4 WHERE id = $5 RETURNING ` + templateColumns
+	queryRow := templateRepository.dbPool.QueryRow(ctx, updateSql, template.Name, template.Description, template.Items, template.UpdatedAt, template.Id)
+
+	var updatedTemplate domain.Template
+	scanErr := scanTemplate(queryRow, &updatedTemplate)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Template{}, errors.New(fmt.Sprintf("Template with id %d not found", template.Id))
+		}
+		return domain.Template{}, errors.New(fmt.Sprintf("Failed to update template: %v", scanErr))
+	}
+
+	return updatedTemplate, nil
+}
+
+func (templateRepository *TemplateRepository) DeleteTemplate(templateId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM templates WHERE id = $1`
+	commandTag, err := templateRepository.dbPool.Exec(ctx, deleteSql, templateId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while deleting template with id %d", templateId))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Template with id %d not found", templateId))
+	}
+
+	return nil
+}
+
+func scanTemplate(queryRow pgx.Row, template *domain.Template) error {
+	return queryRow.Scan(
+		&template.Id,
+		&template.UserId,
+		&template.Name,
+		&template.Description,
+		&template.Items,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+}
+
+func extractTemplatesFromRows(queryRow pgx.Rows) []domain.Template {
+	var templates = []domain.Template{}
+	for queryRow.Next() {
+		var template domain.Template
+		err := scanTemplate(queryRow, &template)
+		if err != nil {
+			continue
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates
+}
@@ -0,0 +1,164 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+const timeEntryColumns = `id, todo_id, user_id, started_at, ended_at, note, created_at`
+
+type ITimeEntryRepository interface {
+	GetTimeEntriesByTodoId(todoId int) ([]domain.TimeEntry, error)
+	GetTimeEntriesByUserId(userId int, from time.Time, to time.Time) ([]domain.TimeEntry, error)
+	GetTimeEntryById(timeEntryId int) (domain.TimeEntry, error)
+	GetRunningTimeEntry(userId int) (*domain.TimeEntry, error)
+	AddTimeEntry(timeEntry domain.TimeEntry) (domain.TimeEntry, error)
+	StopTimeEntry(timeEntryId int, endedAt time.Time) (domain.TimeEntry, error)
+	DeleteTimeEntry(timeEntryId int) error
+}
+
+type TimeEntryRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimeEntryRepository(dbPool *pgxpool.Pool) ITimeEntryRepository {
+	return &TimeEntryRepository{dbPool: dbPool}
+}
+
+// GetTimeEntriesByTodoId returns the time entries of a todo, oldest first.
+func (timeEntryRepository *TimeEntryRepository) GetTimeEntriesByTodoId(todoId int) ([]domain.TimeEntry, error) {
+	ctx := context.Background()
+	getByTodoIdSql := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE todo_id = $1 ORDER BY started_at, id`
+	queryRow, err := timeEntryRepository.dbPool.Query(ctx, getByTodoIdSql, todoId)
+	if err != nil {
+		return []domain.TimeEntry{}, errors.New(fmt.Sprintf("Error while getting time entries of todo with id %d: %v", todoId, err))
+	}
+
+	return extractTimeEntriesFromRows(queryRow), nil
+}
+
+// GetTimeEntriesByUserId returns the time entries of a user that overlap the period between from and to,
+// including a running timer started before to.
+func (timeEntryRepository *TimeEntryRepository) GetTimeEntriesByUserId(userId int, from time.Time, to time.Time) ([]domain.TimeEntry, error) {
+	ctx := context.Background()
+	getByUserIdSql := `SELECT ` + timeEntryColumns + ` FROM time_entries
+		WHERE user_id = $1 AND started_at < $3 AND (ended_at IS NULL OR ended_at > $2)
+		ORDER BY started_at, id`
+	queryRow, err := timeEntryRepository.dbPool.Query(ctx, getByUserIdSql, userId, from, to)
+	if err != nil {
+		return []domain.TimeEntry{}, errors.New(fmt.Sprintf("Error while getting time entries of user with id %d: %v", userId, err))
+	}
+
+	return extractTimeEntriesFromRows(queryRow), nil
+}
+
+func (timeEntryRepository *TimeEntryRepository) GetTimeEntryById(timeEntryId int) (domain.TimeEntry, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE id = $1`
+	queryRow := timeEntryRepository.dbPool.QueryRow(ctx, getByIdSql, timeEntryId)
+
+	var timeEntry domain.TimeEntry
+	scanErr := scanTimeEntry(queryRow, &timeEntry)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.TimeEntry{}, errors.New(fmt.Sprintf("Time entry with id %d not found", timeEntryId))
+		}
+		return domain.TimeEntry{}, errors.New(fmt.Sprintf("Error while getting time entry with id %d: %v", timeEntryId, scanErr))
+	}
+
+	return timeEntry, nil
+}
+
+// GetRunningTimeEntry returns the running timer of a user, or nil when no timer is running.
+func (timeEntryRepository *TimeEntryRepository) GetRunningTimeEntry(userId int) (*domain.TimeEntry, error) {
+	ctx := context.Background()
+	getRunningSql := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE user_id = $1 AND ended_at IS NULL`
+	queryRow := timeEntryRepository.dbPool.QueryRow(ctx, getRunningSql, userId)
+
+	var timeEntry domain.TimeEntry
+	scanErr := scanTimeEntry(queryRow, &timeEntry)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.New(fmt.Sprintf("Error while getting running timer of user with id %d: %v", userId, scanErr))
+	}
+
+	return &timeEntry, nil
+}
+
+func (timeEntryRepository *TimeEntryRepository) AddTimeEntry(timeEntry domain.TimeEntry) (domain.TimeEntry, error) {
+	ctx := context.Background()
+	insertSql := `INSERT INTO time_entries (todo_id, user_id, started_at, ended_at, note, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	queryRow := timeEntryRepository.dbPool.QueryRow(ctx, insertSql, timeEntry.TodoId, timeEntry.UserId, timeEntry.StartedAt, timeEntry.EndedAt, timeEntry.Note, timeEntry.CreatedAt)
+	scanErr := queryRow.Scan(&timeEntry.Id)
+	if scanErr != nil {
+		return domain.TimeEntry{}, scanErr
+	}
+
+	return timeEntry, nil
+}
+
+func (timeEntryRepository *TimeEntryRepository) StopTimeEntry(timeEntryId int, endedAt time.Time) (domain.TimeEntry, error) {
+	ctx := context.Background()
+	stopSql := `UPDATE time_entries SET ended_at = $1 WHERE id = $2 AND ended_at IS NULL RETURNING ` + timeEntryColumns
+	queryRow := timeEntryRepository.dbPool.QueryRow(ctx, stopSql, endedAt, timeEntryId)
+
+	var timeEntry domain.TimeEntry
+	scanErr := scanTimeEntry(queryRow, &timeEntry)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.TimeEntry{}, errors.New(fmt.Sprintf("Running timer with id %d not found", timeEntryId))
+		}
+		return domain.TimeEntry{}, errors.New(fmt.Sprintf("Failed to stop timer: %v", scanErr))
+	}
+
+	return timeEntry, nil
+}
+
+func (timeEntryRepository *TimeEntryRepository) DeleteTimeEntry(timeEntryId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM time_entries WHERE id = $1`
+	commandTag, err := timeEntryRepository.dbPool.Exec(ctx, deleteSql, timeEntryId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while deleting time entry with id %d", timeEntryId))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Time entry with id %d not found", timeEntryId))
+	}
+
+	return nil
+}
+
+func scanTimeEntry(queryRow pgx.Row, timeEntry *domain.TimeEntry) error {
+	return queryRow.Scan(
+		&timeEntry.Id,
+		&timeEntry.TodoId,
+		&timeEntry.UserId,
+		&timeEntry.StartedAt,
+		&timeEntry.EndedAt,
+		&timeEntry.Note,
+		&timeEntry.CreatedAt,
+	)
+}
+
+func extractTimeEntriesFromRows(queryRow pgx.Rows) []domain.TimeEntry {
+	var timeEntries = []domain.TimeEntry{}
+	for queryRow.Next() {
+		var timeEntry domain.TimeEntry
+		err := scanTimeEntry(queryRow, &timeEntry)
+		if err != nil {
+			continue
+		}
+
+		timeEntries = append(timeEntries, timeEntry)
+	}
+
+	return timeEntries
+}
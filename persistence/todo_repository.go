@@ -7,102 +7,324 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/pkg/errors"
+	"strings"
 	"time"
 	"todo-app--go-gin/domain"
+	"unicode"
 )
 
+const todoColumns = `id, user_id, project_id, parent_id, title, description, is_completed, status, priority, position, estimate_minutes, assignee_id, assigned_by, assigned_at, completed_at, completed_by, due_date, due_time, due_timezone, recurrence_rule, series_id, occurrence_index, created_at, updated_at, deleted_at, version`
+
+const todoRevisionColumns = `id, todo_id, user_id, action, changes, snapshot, created_at`
+
+var todoSortColumns = map[string]string{
+	domain.TodoSortByCreatedAt:   "created_at",
+	domain.TodoSortByUpdatedAt:   "updated_at",
+	domain.TodoSortByTitle:       "title",
+	domain.TodoSortByDueDate:     "due_date",
+	domain.TodoSortByPosition:    "position",
+	domain.TodoSortByPriority:    "priority",
+	domain.TodoSortByCompletedAt: "completed_at",
+}
+
+var todoFieldColumns = map[string]string{
+	domain.TodoFieldTitle:          "title",
+	domain.TodoFieldDescription:    "description",
+	domain.TodoFieldIsCompleted:    "is_completed",
+	domain.TodoFieldDueDate:        "due_date",
+	domain.TodoFieldDueTime:        "due_time",
+	domain.TodoFieldDueTimezone:    "due_timezone",
+	domain.TodoFieldRecurrenceRule: "recurrence_rule",
+	domain.TodoFieldPriority:       "priority",
+	domain.TodoFieldStatus:         "status",
+	domain.TodoFieldEstimate:       "estimate_minutes",
+	domain.TodoFieldCompletedAt:    "completed_at",
+	domain.TodoFieldCompletedBy:    "completed_by",
+}
+
 type ITodoRepository interface {
 	GetAllTodos() ([]domain.Todo, error)
 	GetTodoById(todoId int) (domain.Todo, error)
 	GetAllTodosByUserId(userId int) ([]domain.Todo, error)
+	GetSharedTodos(userId int, todoIds []int, projectIds []int) ([]domain.Todo, error)
+	GetTodosByQuery(todoQuery domain.TodoQuery) ([]domain.Todo, int, error)
+	SearchTodos(todoQuery domain.TodoQuery) ([]domain.TodoSearchResult, int, error)
 	AddTodo(todo domain.Todo) (domain.Todo, error)
 	UpdateTodo(todoId int, todo domain.Todo) (domain.Todo, error)
+	UpdateTodoFields(todoId int, todo domain.Todo, fields []string) (int, error)
+	LockTodoVersion(todoId int) (int, error)
 	DeleteTodo(todoId int) error
+	AddTodoTags(todoId int, userId int, tagIds []int) error
+	RemoveTodoTags(todoId int, tagIds []int) error
+	MoveTodoToProject(todoId int, projectId *int) error
+	AssignTodo(todoId int, todo domain.Todo) (int, error)
+	GetSubtasksByParentIds(parentIds []int) ([]domain.Todo, error)
+	SetTodoParent(todoId int, parentId *int) error
+	SetTodosCompletion(todoIds []int, isCompleted bool, status string, userId int) error
+	GetTodoStatusesInUse(userId int) ([]string, error)
+	GetTodoDependencies(todoId int) ([]domain.Todo, error)
+	AddTodoDependency(todoId int, dependsOnId int) error
+	RemoveTodoDependency(todoId int, dependsOnId int) error
+	HasDependencyPath(fromTodoId int, toTodoId int) (bool, error)
+	LockTodoDependencies(userId int) error
+	HasSeriesOccurrence(seriesId int, occurrenceIndex int) (bool, error)
+	GetNeighbourPosition(userId int, excludedTodoId int, anchorTodoId int, before bool) (*float64, error)
+	SetTodoPosition(todoId int, position float64) error
+	RebalanceTodoPositions(userId int) error
+	WithTransaction(fn func(txTodoRepository ITodoRepository) error) error
+	GetTrashedTodosByUserId(userId int) ([]domain.Todo, error)
+	GetTrashedTodoById(todoId int) (domain.Todo, error)
+	RestoreTodo(todoId int) error
+	PermanentlyDeleteTodo(todoId int) error
+	PurgeTrashedTodos(deletedBefore time.Time) (int64, error)
+	AddTodoRevision(revision domain.TodoRevision) error
+	GetTodoRevisions(todoId int, limit int, offset int) ([]domain.TodoRevision, int, error)
+	GetTodoRevisionById(revisionId int) (domain.TodoRevision, error)
+	GetTodoStats(userId int, now time.Time, timezone string) (domain.TodoStats, error)
+	GetCompletionCounts(userId int, from time.Time, to time.Time, period string, timezone string) ([]domain.CompletionCount, error)
 }
 
 type TodoRepository struct {
-	dbPool *pgxpool.Pool
+	db database
 }
 
 func NewTodoRepository(dbPool *pgxpool.Pool) ITodoRepository {
-	return &TodoRepository{dbPool: dbPool}
+	return &TodoRepository{db: dbPool}
+}
+
+// WithTransaction runs fn with a repository bound to a new transaction, committing it when fn
+// succeeds and rolling it back otherwise. Calling it on a transaction-bound repository uses a savepoint.
+func (todoRepository *TodoRepository) WithTransaction(fn func(txTodoRepository ITodoRepository) error) error {
+	ctx := context.Background()
+	tx, err := todoRepository.db.Begin(ctx)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while starting transaction: %v", err))
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&TodoRepository{db: tx}); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (todoRepository *TodoRepository) GetAllTodos() ([]domain.Todo, error) {
 	ctx := context.Background()
-	queryRow, err := todoRepository.dbPool.Query(ctx, "SELECT * FROM todos")
+	queryRow, err := todoRepository.db.Query(ctx, "SELECT "+todoColumns+" FROM todos WHERE deleted_at IS NULL")
 	if err != nil {
 		return []domain.Todo{}, err
 	}
 
-	return extractTodosFromRows(queryRow), nil
+	return extractTodosFromRows(queryRow)
 }
 
 func (todoRepository *TodoRepository) GetTodoById(todoId int) (domain.Todo, error) {
 	ctx := context.Background()
-	getByIdSql := `SELECT * FROM todos WHERE id = $1`
-	queryRow := todoRepository.dbPool.QueryRow(ctx, getByIdSql, todoId)
+	getByIdSql := `SELECT ` + todoColumns + ` FROM todos WHERE id = $1 AND deleted_at IS NULL`
+	queryRow := todoRepository.db.QueryRow(ctx, getByIdSql, todoId)
 
-	var id int
-	var userId int
-	var title string
-	var description string
-	var isCompleted bool
-	var createdAt time.Time
-	var updatedAt time.Time
-
-	scanErr := queryRow.Scan(&id, &userId, &title, &description, &isCompleted, &createdAt, &updatedAt)
+	var todo domain.Todo
+	scanErr := scanTodo(queryRow, &todo)
 	if scanErr != nil {
-		if scanErr == sql.ErrNoRows {
+		if scanErr == sql.ErrNoRows || scanErr == pgx.ErrNoRows {
 			return domain.Todo{}, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
 		}
 		return domain.Todo{}, errors.New(fmt.Sprintf("Error while getting todo with id %d: %v", todoId, scanErr))
 	}
 
-	return domain.Todo{
-		Id:          id,
-		UserId:      userId,
-		Title:       title,
-		Description: description,
-		IsCompleted: isCompleted,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
-	}, nil
+	todos, err := todoRepository.loadTodoRelations([]domain.Todo{todo})
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	return todos[0], nil
 }
 
 func (todoRepository *TodoRepository) GetAllTodosByUserId(userId int) ([]domain.Todo, error) {
 	ctx := context.Background()
-	getByIdSql := `SELECT * FROM todos WHERE user_id = $1`
-	queryRow, err := todoRepository.dbPool.Query(ctx, getByIdSql, userId)
+	getByIdSql := `SELECT ` + todoColumns + ` FROM todos WHERE user_id = $1 AND deleted_at IS NULL ORDER BY position, id`
+	queryRow, err := todoRepository.db.Query(ctx, getByIdSql, userId)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	todos, err := extractTodosFromRows(queryRow)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	return todoRepository.loadTodoRelations(todos)
+}
+
+// GetSharedTodos returns the todos of other users that have one of the ids or belong to one of the projects.
+func (todoRepository *TodoRepository) GetSharedTodos(userId int, todoIds []int, projectIds []int) ([]domain.Todo, error) {
+	ctx := context.Background()
+	getSharedSql := `SELECT ` + todoColumns + ` FROM todos
+		WHERE user_id <> $1 AND (id = ANY($2) OR project_id = ANY($3)) AND deleted_at IS NULL ORDER BY position, id`
+	queryRow, err := todoRepository.db.Query(ctx, getSharedSql, userId, todoIds, projectIds)
 	if err != nil {
 		return []domain.Todo{}, err
 	}
 
-	return extractTodosFromRows(queryRow), nil
+	todos, err := extractTodosFromRows(queryRow)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	return todoRepository.loadTodoRelations(todos)
+}
+
+func (todoRepository *TodoRepository) GetTodosByQuery(todoQuery domain.TodoQuery) ([]domain.Todo, int, error) {
+	ctx := context.Background()
+	conditions := buildTodoQueryConditions(todoQuery)
+
+	var total int
+	countSql := `SELECT COUNT(*) FROM todos WHERE ` + conditions.where()
+	countErr := todoRepository.db.QueryRow(ctx, countSql, conditions.args...).Scan(&total)
+	if countErr != nil {
+		return []domain.Todo{}, 0, errors.New(fmt.Sprintf("Error while counting todos: %v", countErr))
+	}
+
+	sortColumn, found := todoSortColumns[todoQuery.SortBy]
+	if !found {
+		sortColumn = todoSortColumns[domain.TodoSortByCreatedAt]
+	}
+	sortDirection := "ASC"
+	if todoQuery.SortDirection == domain.SortDirectionDesc {
+		sortDirection = "DESC"
+	}
+
+	selectSql := fmt.Sprintf(`SELECT %s FROM todos WHERE %s ORDER BY %s %s NULLS LAST, id %s LIMIT %s OFFSET %s`,
+		todoColumns,
+		conditions.where(),
+		sortColumn,
+		sortDirection,
+		sortDirection,
+		conditions.nextPlaceholder(todoQuery.Limit),
+		conditions.nextPlaceholder(todoQuery.Offset))
+	queryRow, err := todoRepository.db.Query(ctx, selectSql, conditions.args...)
+	if err != nil {
+		return []domain.Todo{}, 0, err
+	}
+
+	todos, err := extractTodosFromRows(queryRow)
+	if err != nil {
+		return []domain.Todo{}, 0, err
+	}
+
+	todos, err = todoRepository.loadTodoRelations(todos)
+	if err != nil {
+		return []domain.Todo{}, 0, err
+	}
+
+	return todos, total, nil
+}
+
+// SearchTodos runs a full-text search over todo titles and descriptions, in which every word of
+// todoQuery.Search must match the start of a word, combined with the other todoQuery filters.
+func (todoRepository *TodoRepository) SearchTodos(todoQuery domain.TodoQuery) ([]domain.TodoSearchResult, int, error) {
+	ctx := context.Background()
+	if prefixTsQuery(todoQuery.Search) == "" {
+		return []domain.TodoSearchResult{}, 0, nil
+	}
+
+	conditions := buildTodoQueryConditions(todoQuery)
+
+	var total int
+	countSql := `SELECT COUNT(*) FROM todos WHERE ` + conditions.where()
+	countErr := todoRepository.db.QueryRow(ctx, countSql, conditions.args...).Scan(&total)
+	if countErr != nil {
+		return []domain.TodoSearchResult{}, 0, errors.New(fmt.Sprintf("Error while counting todos: %v", countErr))
+	}
+
+	sortColumn, found := todoSortColumns[todoQuery.SortBy]
+	if todoQuery.SortBy == domain.TodoSortByRelevance || !found {
+		sortColumn = "rank"
+	}
+	sortDirection := "ASC"
+	if todoQuery.SortDirection == domain.SortDirectionDesc {
+		sortDirection = "DESC"
+	}
+
+	highlightOptions := `'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'`
+	snippetOptions := `'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5'`
+	tsQueryPlaceholder := conditions.nextPlaceholder(prefixTsQuery(todoQuery.Search))
+	selectSql := fmt.Sprintf(`SELECT %s, ts_rank(search_vector, search_query) AS rank,
+			ts_headline('simple', title, search_query, %s),
+			ts_headline('simple', COALESCE(description, ''), search_query, %s)
+		FROM todos, to_tsquery('simple', %s) AS search_query
+		WHERE %s ORDER BY %s %s NULLS LAST, id %s LIMIT %s OFFSET %s`,
+		todoColumns,
+		highlightOptions,
+		snippetOptions,
+		tsQueryPlaceholder,
+		conditions.where(),
+		sortColumn,
+		sortDirection,
+		sortDirection,
+		conditions.nextPlaceholder(todoQuery.Limit),
+		conditions.nextPlaceholder(todoQuery.Offset))
+	queryRow, err := todoRepository.db.Query(ctx, selectSql, conditions.args...)
+	if err != nil {
+		return []domain.TodoSearchResult{}, 0, err
+	}
+	defer queryRow.Close()
+
+	var todos []domain.Todo
+	var searchResults []domain.TodoSearchResult
+	for queryRow.Next() {
+		var searchResult domain.TodoSearchResult
+		scanTargets := append(todoScanTargets(&searchResult.Todo), &searchResult.Rank, &searchResult.TitleHighlight, &searchResult.DescriptionHighlight)
+		if err := queryRow.Scan(scanTargets...); err != nil {
+			return []domain.TodoSearchResult{}, 0, errors.New(fmt.Sprintf("Error while reading search results: %v", err))
+		}
+		todos = append(todos, searchResult.Todo)
+		searchResults = append(searchResults, searchResult)
+	}
+
+	if err := queryRow.Err(); err != nil {
+		return []domain.TodoSearchResult{}, 0, errors.New(fmt.Sprintf("Error while reading search results: %v", err))
+	}
+
+	todos, err = todoRepository.loadTodoRelations(todos)
+	if err != nil {
+		return []domain.TodoSearchResult{}, 0, err
+	}
+
+	for i := range searchResults {
+		searchResults[i].Todo = todos[i]
+	}
+
+	return searchResults, total, nil
 }
 
 func (todoRepository *TodoRepository) AddTodo(todo domain.Todo) (domain.Todo, error) {
 	ctx := context.Background()
-	insertSql := `INSERT INTO todos (user_id, title, description, is_completed, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
-	var id int
-	queryRow := todoRepository.dbPool.QueryRow(ctx, insertSql, todo.UserId, todo.Title, todo.Description, todo.IsCompleted, todo.CreatedAt, todo.UpdatedAt)
-	scanErr := queryRow.Scan(&id)
+	// New todos are appended after the last todo of the user.
+	insertSql := `INSERT INTO todos (user_id, project_id, parent_id, title, description, is_completed, status, priority, position, due_date, due_time, due_timezone, recurrence_rule, series_id, occurrence_index, created_at, updated_at, assignee_id, assigned_by, assigned_at, estimate_minutes, completed_at, completed_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE((SELECT MAX(position) FROM todos WHERE user_id = $1), 0) + $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+		RETURNING id, version, position`
+	var id, version int
+	queryRow := todoRepository.db.QueryRow(ctx, insertSql, todo.UserId, todo.ProjectId, todo.ParentId, todo.Title, todo.Description, todo.IsCompleted, todo.Status, todo.Priority, domain.TodoPositionGap, todo.DueDate, todo.DueTime, todo.DueTimezone, todo.RecurrenceRule, todo.SeriesId, todo.OccurrenceIndex, todo.CreatedAt, todo.UpdatedAt, todo.AssigneeId, todo.AssignedBy, todo.AssignedAt, todo.EstimateMinutes, todo.CompletedAt, todo.CompletedBy)
+	scanErr := queryRow.Scan(&id, &version, &todo.Position)
 	if scanErr != nil {
 		return domain.Todo{}, scanErr
 	}
 
 	todo.Id = id
+	todo.Version = version
 
 	return todo, nil
 }
 
 func (todoRepository *TodoRepository) UpdateTodo(todoId int, todo domain.Todo) (domain.Todo, error) {
 	ctx := context.Background()
-	updateTodoSql := `UPDATE todos SET user_id = $1, title = $2, description = $3, is_completed = $4, updated_at = $5 WHERE id = $6 RETURNING id, user_id, title, description, is_completed, updated_at;`
-	queryRow := todoRepository.dbPool.QueryRow(ctx, updateTodoSql, todo.UserId, todo.Title, todo.Description, todo.IsCompleted, todo.UpdatedAt, todoId)
-	scanErr := queryRow.Scan(&todo.Id, &todo.UserId, &todo.Title, &todo.Description, &todo.IsCompleted, &todo.UpdatedAt)
+	updateTodoSql := `UPDATE todos SET user_id = $1, title = $2, description = $3, is_completed = $4, due_date = $5, due_time = $6, due_timezone = $7, recurrence_rule = $8, priority = $9, updated_at = $10, status = $12, estimate_minutes = $13, completed_at = $14, completed_by = $15, version = version + 1 WHERE id = $11 AND deleted_at IS NULL RETURNING id, user_id, title, description, is_completed, status, due_date, due_time, due_timezone, recurrence_rule, priority, estimate_minutes, completed_at, completed_by, updated_at, version;`
+	queryRow := todoRepository.db.QueryRow(ctx, updateTodoSql, todo.UserId, todo.Title, todo.Description, todo.IsCompleted, todo.DueDate, todo.DueTime, todo.DueTimezone, todo.RecurrenceRule, todo.Priority, todo.UpdatedAt, todoId, todo.Status, todo.EstimateMinutes, todo.CompletedAt, todo.CompletedBy)
+	scanErr := queryRow.Scan(&todo.Id, &todo.UserId, &todo.Title, &todo.Description, &todo.IsCompleted, &todo.Status, &todo.DueDate, &todo.DueTime, &todo.DueTimezone, &todo.RecurrenceRule, &todo.Priority, &todo.EstimateMinutes, &todo.CompletedAt, &todo.CompletedBy, &todo.UpdatedAt, &todo.Version)
 	if scanErr != nil {
-		if scanErr == sql.ErrNoRows {
+		if scanErr == sql.ErrNoRows || scanErr == pgx.ErrNoRows {
 			return domain.Todo{}, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
 		}
 		return domain.Todo{}, errors.New(fmt.Sprintf("Failed to update todo: %v", scanErr))
@@ -111,6 +333,58 @@ func (todoRepository *TodoRepository) UpdateTodo(todoId int, todo domain.Todo) (
 	return todo, nil
 }
 
+// UpdateTodoFields writes only the given fields of todo together with its updated_at and
+// returns the new version of the todo.
+func (todoRepository *TodoRepository) UpdateTodoFields(todoId int, todo domain.Todo, fields []string) (int, error) {
+	ctx := context.Background()
+	var assignments []string
+	var args []interface{}
+	for _, field := range fields {
+		column, found := todoFieldColumns[field]
+		if !found {
+			return 0, errors.New(fmt.Sprintf("Unsupported todo field %s", field))
+		}
+
+		args = append(args, todoFieldValue(todo, field))
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	args = append(args, todo.UpdatedAt)
+	assignments = append(assignments, fmt.Sprintf("updated_at = $%d", len(args)))
+	args = append(args, todoId)
+
+	updateSql := fmt.Sprintf(`UPDATE todos SET %s, version = version + 1 WHERE id = $%d AND deleted_at IS NULL RETURNING version`, strings.Join(assignments, ", "), len(args))
+	var version int
+	err := todoRepository.db.QueryRow(ctx, updateSql, args...).Scan(&version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+		}
+		return 0, errors.New(fmt.Sprintf("Failed to update todo: %v", err))
+	}
+
+	return version, nil
+}
+
+// LockTodoVersion locks the todo row until the surrounding transaction ends and returns its
+// current version, so a write can be made conditional on the version a client last saw.
+func (todoRepository *TodoRepository) LockTodoVersion(todoId int) (int, error) {
+	ctx := context.Background()
+	var version int
+	lockSql := `SELECT version FROM todos WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	err := todoRepository.db.QueryRow(ctx, lockSql, todoId).Scan(&version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+		}
+		return 0, errors.New(fmt.Sprintf("Error while locking todo with id %d: %v", todoId, err))
+	}
+
+	return version, nil
+}
+
+// DeleteTodo moves a todo and its subtasks to the trash. They are stamped with the same
+// deleted_at so that restoring the todo brings back exactly the subtasks trashed with it.
 func (todoRepository *TodoRepository) DeleteTodo(todoId int) error {
 	ctx := context.Background()
 	_, getErr := todoRepository.GetTodoById(todoId)
@@ -118,8 +392,13 @@ func (todoRepository *TodoRepository) DeleteTodo(todoId int) error {
 		return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
 	}
 
-	deleteSql := `DELETE FROM todos WHERE id = $1`
-	_, err := todoRepository.dbPool.Exec(ctx, deleteSql, todoId)
+	deleteSql := `WITH RECURSIVE subtree AS (
+			SELECT id FROM todos WHERE id = $1
+			UNION ALL
+			SELECT todos.id FROM todos JOIN subtree ON todos.parent_id = subtree.id WHERE todos.deleted_at IS NULL
+		)
+		UPDATE todos SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id IN (SELECT id FROM subtree) AND deleted_at IS NULL`
+	_, err := todoRepository.db.Exec(ctx, deleteSql, todoId)
 	if err != nil {
 		return errors.New(fmt.Sprintf("Error while deleting todo with id %d", todoId))
 	}
@@ -127,29 +406,816 @@ func (todoRepository *TodoRepository) DeleteTodo(todoId int) error {
 	return nil
 }
 
-func extractTodosFromRows(queryRow pgx.Rows) []domain.Todo {
+func (todoRepository *TodoRepository) AddTodoTags(todoId int, userId int, tagIds []int) error {
+	ctx := context.Background()
+	tagIds = uniqueIds(tagIds)
+	if len(tagIds) == 0 {
+		return nil
+	}
+
+	var ownedTagCount int
+	countSql := `SELECT COUNT(*) FROM tags WHERE user_id = $1 AND id = ANY($2)`
+	countErr := todoRepository.db.QueryRow(ctx, countSql, userId, tagIds).Scan(&ownedTagCount)
+	if countErr != nil {
+		return errors.New(fmt.Sprintf("Error while checking tags: %v", countErr))
+	}
+
+	if ownedTagCount != len(tagIds) {
+		return errors.New("One or more tags not found")
+	}
+
+	insertSql := `WITH inserted AS (
+			INSERT INTO todo_tags (todo_id, tag_id) SELECT $1, id FROM tags WHERE user_id = $2 AND id = ANY($3) ON CONFLICT DO NOTHING RETURNING todo_id
+		)
+		UPDATE todos SET version = version + 1 WHERE id = $1 AND EXISTS (SELECT 1 FROM inserted)`
+	_, err := todoRepository.db.Exec(ctx, insertSql, todoId, userId, tagIds)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while adding tags to todo with id %d: %v", todoId, err))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) RemoveTodoTags(todoId int, tagIds []int) error {
+	ctx := context.Background()
+	if len(tagIds) == 0 {
+		return nil
+	}
+
+	deleteSql := `WITH deleted AS (
+			DELETE FROM todo_tags WHERE todo_id = $1 AND tag_id = ANY($2) RETURNING todo_id
+		)
+		UPDATE todos SET version = version + 1 WHERE id = $1 AND EXISTS (SELECT 1 FROM deleted)`
+	_, err := todoRepository.db.Exec(ctx, deleteSql, todoId, tagIds)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while removing tags from todo with id %d: %v", todoId, err))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) MoveTodoToProject(todoId int, projectId *int) error {
+	ctx := context.Background()
+	moveSql := `UPDATE todos SET project_id = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $2 AND deleted_at IS NULL`
+	commandTag, err := todoRepository.db.Exec(ctx, moveSql, projectId, todoId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while moving todo with id %d: %v", todoId, err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+	}
+
+	return nil
+}
+
+// AssignTodo writes the assignee of a todo together with who assigned it and when, and returns the new version.
+func (todoRepository *TodoRepository) AssignTodo(todoId int, todo domain.Todo) (int, error) {
+	ctx := context.Background()
+	assignSql := `UPDATE todos SET assignee_id = $1, assigned_by = $2, assigned_at = $3, updated_at = $4, version = version + 1
+		WHERE id = $5 AND deleted_at IS NULL RETURNING version`
+	var version int
+	err := todoRepository.db.QueryRow(ctx, assignSql, todo.AssigneeId, todo.AssignedBy, todo.AssignedAt, todo.UpdatedAt, todoId).Scan(&version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+		}
+		return 0, errors.New(fmt.Sprintf("Error while assigning todo with id %d: %v", todoId, err))
+	}
+
+	return version, nil
+}
+
+func (todoRepository *TodoRepository) GetSubtasksByParentIds(parentIds []int) ([]domain.Todo, error) {
+	ctx := context.Background()
+	if len(parentIds) == 0 {
+		return []domain.Todo{}, nil
+	}
+
+	getByParentIdsSql := `SELECT ` + todoColumns + ` FROM todos WHERE parent_id = ANY($1) AND deleted_at IS NULL ORDER BY created_at, id`
+	queryRow, err := todoRepository.db.Query(ctx, getByParentIdsSql, parentIds)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	todos, err := extractTodosFromRows(queryRow)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	return todoRepository.loadTodoRelations(todos)
+}
+
+func (todoRepository *TodoRepository) SetTodoParent(todoId int, parentId *int) error {
+	ctx := context.Background()
+	updateParentSql := `UPDATE todos SET parent_id = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $2 AND deleted_at IS NULL`
+	commandTag, err := todoRepository.db.Exec(ctx, updateParentSql, parentId, todoId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while changing parent of todo with id %d: %v", todoId, err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+	}
+
+	return nil
+}
+
+// SetTodosCompletion moves the todos that are not yet in the given completion state to status, recording
+// that userId completed them or clearing their completion when they are reopened.
+func (todoRepository *TodoRepository) SetTodosCompletion(todoIds []int, isCompleted bool, status string, userId int) error {
+	ctx := context.Background()
+	if len(todoIds) == 0 {
+		return nil
+	}
+
+	updateCompletionSql := `UPDATE todos SET is_completed = $1, status = $3, updated_at = CURRENT_TIMESTAMP,
+		completed_at = CASE WHEN $1 THEN CURRENT_TIMESTAMP END, completed_by = CASE WHEN $1 THEN $4::int END, version = version + 1
+		WHERE id = ANY($2) AND is_completed <> $1 AND deleted_at IS NULL`
+	_, err := todoRepository.db.Exec(ctx, updateCompletionSql, isCompleted, todoIds, status, userId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while updating completion of todos: %v", err))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) GetTodoStatusesInUse(userId int) ([]string, error) {
+	ctx := context.Background()
+	getStatusesSql := `SELECT DISTINCT status FROM todos WHERE user_id = $1 AND deleted_at IS NULL`
+	queryRow, err := todoRepository.db.Query(ctx, getStatusesSql, userId)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while getting statuses of todos: %v", err))
+	}
+	defer queryRow.Close()
+
+	var statuses []string
+	for queryRow.Next() {
+		var status string
+		if err := queryRow.Scan(&status); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, queryRow.Err()
+}
+
+// GetTodoDependencies returns the todos that todoId depends on, whether or not they are completed.
+func (todoRepository *TodoRepository) GetTodoDependencies(todoId int) ([]domain.Todo, error) {
+	ctx := context.Background()
+	getDependenciesSql := `SELECT ` + todoColumns + ` FROM todos
+		WHERE id IN (SELECT depends_on_id FROM todo_dependencies WHERE todo_id = $1) AND deleted_at IS NULL ORDER BY position, id`
+	queryRow, err := todoRepository.db.Query(ctx, getDependenciesSql, todoId)
+	if err != nil {
+		return []domain.Todo{}, errors.New(fmt.Sprintf("Error while getting dependencies of todo with id %d: %v", todoId, err))
+	}
+
+	todos, err := extractTodosFromRows(queryRow)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	return todoRepository.loadTodoRelations(todos)
+}
+
+func (todoRepository *TodoRepository) AddTodoDependency(todoId int, dependsOnId int) error {
+	ctx := context.Background()
+	insertSql := `INSERT INTO todo_dependencies (todo_id, depends_on_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	_, err := todoRepository.db.Exec(ctx, insertSql, todoId, dependsOnId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while adding dependency to todo with id %d: %v", todoId, err))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) RemoveTodoDependency(todoId int, dependsOnId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM todo_dependencies WHERE todo_id = $1 AND depends_on_id = $2`
+	commandTag, err := todoRepository.db.Exec(ctx, deleteSql, todoId, dependsOnId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while removing dependency from todo with id %d: %v", todoId, err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Todo with id %d does not depend on todo with id %d", todoId, dependsOnId))
+	}
+
+	return nil
+}
+
+// HasDependencyPath reports whether fromTodoId depends on toTodoId directly or through other todos.
+func (todoRepository *TodoRepository) HasDependencyPath(fromTodoId int, toTodoId int) (bool, error) {
+	ctx := context.Background()
+	var exists bool
+	pathSql := `WITH RECURSIVE reachable AS (
+			SELECT depends_on_id FROM todo_dependencies WHERE todo_id = $1
+			UNION
+			SELECT todo_dependencies.depends_on_id FROM todo_dependencies JOIN reachable ON todo_dependencies.todo_id = reachable.depends_on_id
+		)
+		SELECT EXISTS (SELECT 1 FROM reachable WHERE depends_on_id = $2)`
+	err := todoRepository.db.QueryRow(ctx, pathSql, fromTodoId, toTodoId).Scan(&exists)
+	if err != nil {
+		return false, errors.New(fmt.Sprintf("Error while checking dependencies of todo with id %d: %v", fromTodoId, err))
+	}
+
+	return exists, nil
+}
+
+// LockTodoDependencies holds a lock on the dependencies between the todos of a user until the surrounding
+// transaction ends, so that concurrent changes cannot each pass a cycle check and together close a cycle.
+func (todoRepository *TodoRepository) LockTodoDependencies(userId int) error {
+	ctx := context.Background()
+	lockSql := `SELECT pg_advisory_xact_lock(hashtext('todo_dependencies'), $1)`
+	_, err := todoRepository.db.Exec(ctx, lockSql, userId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while locking dependencies of user with id %d: %v", userId, err))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) HasSeriesOccurrence(seriesId int, occurrenceIndex int) (bool, error) {
+	ctx := context.Background()
+	var exists bool
+	existsSql := `SELECT EXISTS (SELECT 1 FROM todos WHERE series_id = $1 AND occurrence_index = $2)`
+	err := todoRepository.db.QueryRow(ctx, existsSql, seriesId, occurrenceIndex).Scan(&exists)
+	if err != nil {
+		return false, errors.New(fmt.Sprintf("Error while checking occurrence %d of series %d: %v", occurrenceIndex, seriesId, err))
+	}
+
+	return exists, nil
+}
+
+// GetNeighbourPosition returns the position of the todo ordered directly before (or after) the anchor
+// todo, ignoring the todo being moved, or nil when the anchor is the first (or last) todo.
+func (todoRepository *TodoRepository) GetNeighbourPosition(userId int, excludedTodoId int, anchorTodoId int, before bool) (*float64, error) {
+	ctx := context.Background()
+	comparison, direction := ">", "ASC"
+	if before {
+		comparison, direction = "<", "DESC"
+	}
+
+	neighbourSql := fmt.Sprintf(`SELECT position FROM todos
+		WHERE user_id = $1 AND id <> $2 AND deleted_at IS NULL AND (position, id) %s (SELECT position, id FROM todos WHERE id = $3)
+		ORDER BY position %s, id %s LIMIT 1`, comparison, direction, direction)
+	var position float64
+	err := todoRepository.db.QueryRow(ctx, neighbourSql, userId, excludedTodoId, anchorTodoId).Scan(&position)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.New(fmt.Sprintf("Error while getting neighbour of todo with id %d: %v", anchorTodoId, err))
+	}
+
+	return &position, nil
+}
+
+func (todoRepository *TodoRepository) SetTodoPosition(todoId int, position float64) error {
+	ctx := context.Background()
+	updatePositionSql := `UPDATE todos SET position = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $2 AND deleted_at IS NULL`
+	commandTag, err := todoRepository.db.Exec(ctx, updatePositionSql, position, todoId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while moving todo with id %d: %v", todoId, err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+	}
+
+	return nil
+}
+
+// RebalanceTodoPositions spreads the positions of all todos of a user evenly, keeping their order. Only
+// the todos whose position changes are written, so the versions of the others stay valid.
+func (todoRepository *TodoRepository) RebalanceTodoPositions(userId int) error {
+	ctx := context.Background()
+	rebalanceSql := `UPDATE todos SET position = ranked.row_number * $2, version = version + 1
+		FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS row_number FROM todos WHERE user_id = $1 AND deleted_at IS NULL) AS ranked
+		WHERE todos.id = ranked.id AND todos.position <> ranked.row_number * $2`
+	_, err := todoRepository.db.Exec(ctx, rebalanceSql, userId, domain.TodoPositionGap)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while rebalancing todo positions: %v", err))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) GetTrashedTodosByUserId(userId int) ([]domain.Todo, error) {
+	ctx := context.Background()
+	getTrashedSql := `SELECT ` + todoColumns + ` FROM todos WHERE user_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id`
+	queryRow, err := todoRepository.db.Query(ctx, getTrashedSql, userId)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	todos, err := extractTodosFromRows(queryRow)
+	if err != nil {
+		return []domain.Todo{}, err
+	}
+
+	return todoRepository.loadTodoRelations(todos)
+}
+
+func (todoRepository *TodoRepository) GetTrashedTodoById(todoId int) (domain.Todo, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + todoColumns + ` FROM todos WHERE id = $1 AND deleted_at IS NOT NULL`
+	queryRow := todoRepository.db.QueryRow(ctx, getByIdSql, todoId)
+
+	var todo domain.Todo
+	scanErr := scanTodo(queryRow, &todo)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.Todo{}, errors.New(fmt.Sprintf("Todo with id %d not found in trash", todoId))
+		}
+		return domain.Todo{}, errors.New(fmt.Sprintf("Error while getting trashed todo with id %d: %v", todoId, scanErr))
+	}
+
+	return todo, nil
+}
+
+func (todoRepository *TodoRepository) RestoreTodo(todoId int) error {
+	ctx := context.Background()
+	restoreSql := `WITH RECURSIVE subtree AS (
+			SELECT id, deleted_at FROM todos WHERE id = $1 AND deleted_at IS NOT NULL
+			UNION ALL
+			SELECT todos.id, todos.deleted_at FROM todos JOIN subtree ON todos.parent_id = subtree.id WHERE todos.deleted_at = subtree.deleted_at
+		)
+		UPDATE todos SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id IN (SELECT id FROM subtree)`
+	commandTag, err := todoRepository.db.Exec(ctx, restoreSql, todoId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while restoring todo with id %d: %v", todoId, err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Todo with id %d not found in trash", todoId))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) PermanentlyDeleteTodo(todoId int) error {
+	ctx := context.Background()
+	deleteSql := `DELETE FROM todos WHERE id = $1 AND deleted_at IS NOT NULL`
+	commandTag, err := todoRepository.db.Exec(ctx, deleteSql, todoId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while permanently deleting todo with id %d", todoId))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New(fmt.Sprintf("Todo with id %d not found in trash", todoId))
+	}
+
+	return nil
+}
+
+func (todoRepository *TodoRepository) PurgeTrashedTodos(deletedBefore time.Time) (int64, error) {
+	ctx := context.Background()
+	purgeSql := `DELETE FROM todos WHERE deleted_at < $1`
+	commandTag, err := todoRepository.db.Exec(ctx, purgeSql, deletedBefore)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Error while purging trashed todos: %v", err))
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+func (todoRepository *TodoRepository) AddTodoRevision(revision domain.TodoRevision) error {
+	ctx := context.Background()
+	insertSql := `INSERT INTO todo_history (todo_id, user_id, action, changes, snapshot, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := todoRepository.db.Exec(ctx, insertSql, revision.TodoId, revision.UserId, revision.Action, revision.Changes, revision.Snapshot, revision.CreatedAt)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while recording history of todo with id %d: %v", revision.TodoId, err))
+	}
+
+	return nil
+}
+
+// GetTodoRevisions returns a page of the history of a todo, newest first, with the total number of revisions.
+func (todoRepository *TodoRepository) GetTodoRevisions(todoId int, limit int, offset int) ([]domain.TodoRevision, int, error) {
+	ctx := context.Background()
+	var total int
+	countSql := `SELECT COUNT(*) FROM todo_history WHERE todo_id = $1`
+	err := todoRepository.db.QueryRow(ctx, countSql, todoId).Scan(&total)
+	if err != nil {
+		return []domain.TodoRevision{}, 0, errors.New(fmt.Sprintf("Error while counting history of todo with id %d: %v", todoId, err))
+	}
+
+	getByTodoIdSql := `SELECT ` + todoRevisionColumns + ` FROM todo_history WHERE todo_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`
+	queryRow, err := todoRepository.db.Query(ctx, getByTodoIdSql, todoId, limit, offset)
+	if err != nil {
+		return []domain.TodoRevision{}, 0, errors.New(fmt.Sprintf("Error while getting history of todo with id %d: %v", todoId, err))
+	}
+	defer queryRow.Close()
+
+	revisions := []domain.TodoRevision{}
+	for queryRow.Next() {
+		var revision domain.TodoRevision
+		if err := scanTodoRevision(queryRow, &revision); err != nil {
+			return []domain.TodoRevision{}, 0, errors.New(fmt.Sprintf("Error while reading history of todo with id %d: %v", todoId, err))
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := queryRow.Err(); err != nil {
+		return []domain.TodoRevision{}, 0, errors.New(fmt.Sprintf("Error while reading history of todo with id %d: %v", todoId, err))
+	}
+
+	return revisions, total, nil
+}
+
+func (todoRepository *TodoRepository) GetTodoRevisionById(revisionId int) (domain.TodoRevision, error) {
+	ctx := context.Background()
+	getByIdSql := `SELECT ` + todoRevisionColumns + ` FROM todo_history WHERE id = $1`
+	queryRow := todoRepository.db.QueryRow(ctx, getByIdSql, revisionId)
+
+	var revision domain.TodoRevision
+	scanErr := scanTodoRevision(queryRow, &revision)
+	if scanErr != nil {
+		if scanErr == pgx.ErrNoRows {
+			return domain.TodoRevision{}, errors.New(fmt.Sprintf("Revision with id %d not found", revisionId))
+		}
+		return domain.TodoRevision{}, errors.New(fmt.Sprintf("Error while getting revision with id %d: %v", revisionId, scanErr))
+	}
+
+	return revision, nil
+}
+
+// GetTodoStats counts the open, completed and overdue todos of a user and works out the average time
+// from creating a todo to completing it and the current completion streak, with days and due moments
+// taken in timezone.
+func (todoRepository *TodoRepository) GetTodoStats(userId int, now time.Time, timezone string) (domain.TodoStats, error) {
+	ctx := context.Background()
+	statsSql := `WITH user_todos AS (
+			SELECT is_completed, created_at, completed_at
+			FROM todos WHERE user_id = $1 AND deleted_at IS NULL
+		), completion_days AS (
+			SELECT DISTINCT (completed_at AT TIME ZONE $2)::date AS day FROM user_todos
+			WHERE completed_at IS NOT NULL AND (completed_at AT TIME ZONE $2)::date <= ($3::timestamptz AT TIME ZONE $2)::date
+		), streaks AS (
+			SELECT day, day + (ROW_NUMBER() OVER (ORDER BY day DESC))::int AS streak_end FROM completion_days
+		)
+		SELECT
+			(SELECT COUNT(*) FROM user_todos WHERE NOT is_completed),
+			(SELECT COUNT(*) FROM user_todos WHERE is_completed),
+			(SELECT AVG(EXTRACT(EPOCH FROM completed_at - created_at))::float8 FROM user_todos WHERE is_completed),
+			(SELECT COUNT(*) FROM streaks
+				WHERE streak_end = (SELECT MAX(day) + 1 FROM streaks)
+				AND (SELECT MAX(day) FROM streaks) >= ($3::timestamptz AT TIME ZONE $2)::date - 1)`
+
+	var todoStats domain.TodoStats
+	var averageSeconds *float64
+	err := todoRepository.db.QueryRow(ctx, statsSql, userId, timezone, now).Scan(&todoStats.OpenCount, &todoStats.CompletedCount, &averageSeconds, &todoStats.CurrentStreakDays)
+	if err != nil {
+		return domain.TodoStats{}, errors.New(fmt.Sprintf("Error while getting stats of user with id %d: %v", userId, err))
+	}
+
+	if averageSeconds != nil {
+		averageTimeToComplete := time.Duration(*averageSeconds * float64(time.Second))
+		todoStats.AverageTimeToComplete = &averageTimeToComplete
+	}
+
+	isCompleted := false
+	conditions := buildTodoQueryConditions(domain.TodoQuery{UserId: userId, IsCompleted: &isCompleted, DueBefore: &now, Timezone: timezone})
+	overdueSql := `SELECT COUNT(*) FROM todos WHERE ` + conditions.where()
+	err = todoRepository.db.QueryRow(ctx, overdueSql, conditions.args...).Scan(&todoStats.OverdueCount)
+	if err != nil {
+		return domain.TodoStats{}, errors.New(fmt.Sprintf("Error while counting overdue todos: %v", err))
+	}
+
+	return todoStats, nil
+}
+
+// GetCompletionCounts counts the todos of a user completed per day or week between the dates from and to,
+// in timezone. Periods without completions are left out.
+func (todoRepository *TodoRepository) GetCompletionCounts(userId int, from time.Time, to time.Time, period string, timezone string) ([]domain.CompletionCount, error) {
+	ctx := context.Background()
+	completionCountsSql := `SELECT date_trunc($2::text, completed_at AT TIME ZONE $3)::date AS period_start, COUNT(*)
+		FROM todos
+		WHERE user_id = $1 AND is_completed AND deleted_at IS NULL AND (completed_at AT TIME ZONE $3)::date BETWEEN $4::date AND $5::date
+		GROUP BY period_start ORDER BY period_start`
+	queryRow, err := todoRepository.db.Query(ctx, completionCountsSql, userId, period, timezone, from.Format(domain.DueDateLayout), to.Format(domain.DueDateLayout))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while counting completed todos: %v", err))
+	}
+	defer queryRow.Close()
+
+	completionCounts := []domain.CompletionCount{}
+	for queryRow.Next() {
+		var completionCount domain.CompletionCount
+		if err := queryRow.Scan(&completionCount.PeriodStart, &completionCount.Count); err != nil {
+			return nil, err
+		}
+		completionCounts = append(completionCounts, completionCount)
+	}
+
+	return completionCounts, queryRow.Err()
+}
+
+// loadTodoRelations fills in the tags of the todos and the open todos each of them depends on.
+func (todoRepository *TodoRepository) loadTodoRelations(todos []domain.Todo) ([]domain.Todo, error) {
+	todos, err := todoRepository.loadTodoTags(todos)
+	if err != nil {
+		return todos, err
+	}
+
+	return todoRepository.loadTodoBlockers(todos)
+}
+
+func (todoRepository *TodoRepository) loadTodoBlockers(todos []domain.Todo) ([]domain.Todo, error) {
+	ctx := context.Background()
+	if len(todos) == 0 {
+		return todos, nil
+	}
+
+	todoIds := make([]int, len(todos))
+	for i, todo := range todos {
+		todoIds[i] = todo.Id
+	}
+
+	selectSql := `SELECT todo_dependencies.todo_id, todo_dependencies.depends_on_id
+		FROM todo_dependencies JOIN todos ON todos.id = todo_dependencies.depends_on_id
+		WHERE todo_dependencies.todo_id = ANY($1) AND NOT todos.is_completed AND todos.deleted_at IS NULL
+		ORDER BY todo_dependencies.depends_on_id`
+	queryRow, err := todoRepository.db.Query(ctx, selectSql, todoIds)
+	if err != nil {
+		return todos, errors.New(fmt.Sprintf("Error while getting todo dependencies: %v", err))
+	}
+	defer queryRow.Close()
+
+	blockedByIdsByTodoId := map[int][]int{}
+	for queryRow.Next() {
+		var todoId, dependsOnId int
+		if err := queryRow.Scan(&todoId, &dependsOnId); err != nil {
+			return todos, errors.New(fmt.Sprintf("Error while reading todo dependencies: %v", err))
+		}
+		blockedByIdsByTodoId[todoId] = append(blockedByIdsByTodoId[todoId], dependsOnId)
+	}
+
+	if err := queryRow.Err(); err != nil {
+		return todos, errors.New(fmt.Sprintf("Error while reading todo dependencies: %v", err))
+	}
+
+	for i := range todos {
+		todos[i].BlockedByIds = blockedByIdsByTodoId[todos[i].Id]
+	}
+
+	return todos, nil
+}
+
+func (todoRepository *TodoRepository) loadTodoTags(todos []domain.Todo) ([]domain.Todo, error) {
+	ctx := context.Background()
+	if len(todos) == 0 {
+		return todos, nil
+	}
+
+	todoIds := make([]int, len(todos))
+	for i, todo := range todos {
+		todoIds[i] = todo.Id
+	}
+
+	selectSql := `SELECT todo_tags.todo_id, tags.id, tags.user_id, tags.name, tags.color, tags.created_at
+		FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id
+		WHERE todo_tags.todo_id = ANY($1) ORDER BY tags.name`
+	queryRow, err := todoRepository.db.Query(ctx, selectSql, todoIds)
+	if err != nil {
+		return todos, errors.New(fmt.Sprintf("Error while getting todo tags: %v", err))
+	}
+	defer queryRow.Close()
+
+	tagsByTodoId := map[int][]domain.Tag{}
+	for queryRow.Next() {
+		var todoId int
+		var tag domain.Tag
+		if err := queryRow.Scan(&todoId, &tag.Id, &tag.UserId, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+			return todos, errors.New(fmt.Sprintf("Error while reading todo tags: %v", err))
+		}
+		tagsByTodoId[todoId] = append(tagsByTodoId[todoId], tag)
+	}
+
+	if err := queryRow.Err(); err != nil {
+		return todos, errors.New(fmt.Sprintf("Error while reading todo tags: %v", err))
+	}
+
+	for i := range todos {
+		todos[i].Tags = tagsByTodoId[todos[i].Id]
+	}
+
+	return todos, nil
+}
+
+func buildTodoQueryConditions(todoQuery domain.TodoQuery) *queryConditions {
+	conditions := &queryConditions{}
+	if todoQuery.SharedOnly {
+		conditions.add("user_id <> $%d AND (id = ANY($%d) OR project_id = ANY($%d))", todoQuery.UserId, todoQuery.SharedTodoIds, todoQuery.SharedProjectIds)
+	} else if len(todoQuery.SharedTodoIds) > 0 || len(todoQuery.SharedProjectIds) > 0 {
+		conditions.add("(user_id = $%d OR id = ANY($%d) OR project_id = ANY($%d))", todoQuery.UserId, todoQuery.SharedTodoIds, todoQuery.SharedProjectIds)
+	} else {
+		conditions.add("user_id = $%d", todoQuery.UserId)
+	}
+	conditions.add("deleted_at IS NULL")
+
+	if todoQuery.InboxOnly {
+		conditions.add("project_id IS NULL")
+	} else if todoQuery.ProjectId != nil {
+		conditions.add("project_id = $%d", *todoQuery.ProjectId)
+	}
+	if todoQuery.TopLevelOnly {
+		conditions.add("parent_id IS NULL")
+	} else if todoQuery.ParentId != nil {
+		conditions.add("parent_id = $%d", *todoQuery.ParentId)
+	}
+
+	if todoQuery.IsCompleted != nil {
+		conditions.add("is_completed = $%d", *todoQuery.IsCompleted)
+	}
+	if todoQuery.Priority != nil {
+		conditions.add("priority = $%d", *todoQuery.Priority)
+	}
+	if len(todoQuery.Priorities) > 0 {
+		conditions.add("priority = ANY($%d)", todoQuery.Priorities)
+	}
+	if todoQuery.Status != "" {
+		conditions.add("status = $%d", todoQuery.Status)
+	}
+	if todoQuery.AssigneeId != nil {
+		conditions.add("assignee_id = $%d", *todoQuery.AssigneeId)
+	} else if todoQuery.Unassigned {
+		conditions.add("assignee_id IS NULL")
+	}
+	if todoQuery.CreatedFrom != nil {
+		conditions.add("created_at >= $%d", *todoQuery.CreatedFrom)
+	}
+	if todoQuery.CreatedTo != nil {
+		conditions.add("created_at <= $%d", *todoQuery.CreatedTo)
+	}
+	if todoQuery.CompletedFrom != nil {
+		conditions.add("completed_at >= $%d", *todoQuery.CompletedFrom)
+	}
+	if todoQuery.CompletedTo != nil {
+		conditions.add("completed_at <= $%d", *todoQuery.CompletedTo)
+	}
+	if todoQuery.UpdatedFrom != nil {
+		conditions.add("updated_at >= $%d", *todoQuery.UpdatedFrom)
+	}
+	if todoQuery.UpdatedTo != nil {
+		conditions.add("updated_at <= $%d", *todoQuery.UpdatedTo)
+	}
+	if todoQuery.Title != "" {
+		conditions.add("title ILIKE $%d", "%"+escapeLikePattern(todoQuery.Title)+"%")
+	}
+	if todoQuery.Search != "" {
+		conditions.add("search_vector @@ to_tsquery('simple', $%d)", prefixTsQuery(todoQuery.Search))
+	}
+	if todoQuery.DueFrom != nil {
+		conditions.add("due_date >= $%d::date", todoQuery.DueFrom.Format(domain.DueDateLayout))
+	}
+	if todoQuery.DueTo != nil {
+		conditions.add("due_date <= $%d::date", todoQuery.DueTo.Format(domain.DueDateLayout))
+	}
+	if todoQuery.DueBefore != nil {
+		// Todos without a due time are due at the end of their due date in the caller's timezone.
+		conditions.add(`due_date IS NOT NULL AND CASE
+			WHEN due_time IS NULL THEN (due_date + 1)::timestamp AT TIME ZONE $%[1]d
+			ELSE (due_date + due_time::time) AT TIME ZONE COALESCE(due_timezone, $%[1]d)
+		END < $%[2]d`, todoQuery.Timezone, *todoQuery.DueBefore)
+	}
+	if len(todoQuery.Tags) > 0 {
+		tagSubquery := `SELECT todo_tags.todo_id FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id
+			WHERE tags.user_id = todos.user_id AND tags.name = ANY($%d)`
+		if todoQuery.TagMode == domain.TagMatchAll {
+			conditions.add("id IN ("+tagSubquery+" GROUP BY todo_tags.todo_id HAVING COUNT(DISTINCT tags.name) = $%d)", todoQuery.Tags, len(todoQuery.Tags))
+		} else {
+			conditions.add("id IN ("+tagSubquery+")", todoQuery.Tags)
+		}
+	}
+
+	return conditions
+}
+
+func todoFieldValue(todo domain.Todo, field string) interface{} {
+	switch field {
+	case domain.TodoFieldTitle:
+		return todo.Title
+	case domain.TodoFieldDescription:
+		return todo.Description
+	case domain.TodoFieldIsCompleted:
+		return todo.IsCompleted
+	case domain.TodoFieldDueDate:
+		return todo.DueDate
+	case domain.TodoFieldDueTime:
+		return todo.DueTime
+	case domain.TodoFieldDueTimezone:
+		return todo.DueTimezone
+	case domain.TodoFieldRecurrenceRule:
+		return todo.RecurrenceRule
+	case domain.TodoFieldPriority:
+		return todo.Priority
+	case domain.TodoFieldStatus:
+		return todo.Status
+	case domain.TodoFieldEstimate:
+		return todo.EstimateMinutes
+	case domain.TodoFieldCompletedAt:
+		return todo.CompletedAt
+	case domain.TodoFieldCompletedBy:
+		return todo.CompletedBy
+	}
+
+	return nil
+}
+
+func scanTodo(queryRow pgx.Row, todo *domain.Todo) error {
+	return queryRow.Scan(todoScanTargets(todo)...)
+}
+
+// todoScanTargets lists the fields of todo in the order of todoColumns.
+func todoScanTargets(todo *domain.Todo) []interface{} {
+	return []interface{}{
+		&todo.Id,
+		&todo.UserId,
+		&todo.ProjectId,
+		&todo.ParentId,
+		&todo.Title,
+		&todo.Description,
+		&todo.IsCompleted,
+		&todo.Status,
+		&todo.Priority,
+		&todo.Position,
+		&todo.EstimateMinutes,
+		&todo.AssigneeId,
+		&todo.AssignedBy,
+		&todo.AssignedAt,
+		&todo.CompletedAt,
+		&todo.CompletedBy,
+		&todo.DueDate,
+		&todo.DueTime,
+		&todo.DueTimezone,
+		&todo.RecurrenceRule,
+		&todo.SeriesId,
+		&todo.OccurrenceIndex,
+		&todo.CreatedAt,
+		&todo.UpdatedAt,
+		&todo.DeletedAt,
+		&todo.Version,
+	}
+}
+
+func scanTodoRevision(queryRow pgx.Row, revision *domain.TodoRevision) error {
+	return queryRow.Scan(
+		&revision.Id,
+		&revision.TodoId,
+		&revision.UserId,
+		&revision.Action,
+		&revision.Changes,
+		&revision.Snapshot,
+		&revision.CreatedAt,
+	)
+}
+
+func extractTodosFromRows(queryRow pgx.Rows) ([]domain.Todo, error) {
+	defer queryRow.Close()
+
 	var todos = []domain.Todo{}
 	for queryRow.Next() {
 		var todo domain.Todo
-		err := queryRow.Scan(
-			&todo.Id,
-			&todo.UserId,
-			&todo.Title,
-			&todo.Description,
-			&todo.IsCompleted,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
+		err := scanTodo(queryRow, &todo)
 		if err != nil {
-			continue
+			return []domain.Todo{}, errors.New(fmt.Sprintf("Error while reading todos: %v", err))
 		}
 
 		todos = append(todos, todo)
 	}
 
 	if err := queryRow.Err(); err != nil {
-		return todos
+		return []domain.Todo{}, errors.New(fmt.Sprintf("Error while reading todos: %v", err))
+	}
+
+	return todos, nil
+}
+
+// prefixTsQuery turns free text into a tsquery in which every word must match as a prefix. Anything
+// other than letters and digits separates words, so tsquery operators in the text are ignored.
+func prefixTsQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(character rune) bool {
+		return !unicode.IsLetter(character) && !unicode.IsDigit(character)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+func uniqueIds(ids []int) []int {
+	seen := map[int]bool{}
+	var unique []int
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
 	}
 
-	return todos
+	return unique
 }
@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+)
+
+type IWorkflowRepository interface {
+	GetWorkflowByUserId(userId int) (domain.Workflow, error)
+	SaveWorkflow(workflow domain.Workflow) error
+}
+
+type WorkflowRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewWorkflowRepository(dbPool *pgxpool.Pool) IWorkflowRepository {
+	return &WorkflowRepository{dbPool: dbPool}
+}
+
+// GetWorkflowByUserId returns a workflow without statuses when the user has not configured one.
+func (workflowRepository *WorkflowRepository) GetWorkflowByUserId(userId int) (domain.Workflow, error) {
+	ctx := context.Background()
+	workflow := domain.Workflow{UserId: userId}
+
+	getStatusesSql := `SELECT status_key, name, is_done FROM workflow_statuses WHERE user_id = $1 ORDER BY position`
+	statusRows, err := workflowRepository.dbPool.Query(ctx, getStatusesSql, userId)
+	if err != nil {
+		return domain.Workflow{}, errors.New(fmt.Sprintf("Error while getting workflow of user with id %d: %v", userId, err))
+	}
+	defer statusRows.Close()
+
+	for statusRows.Next() {
+		var status domain.WorkflowStatus
+		if err := statusRows.Scan(&status.Key, &status.Name, &status.IsDone); err != nil {
+			return domain.Workflow{}, err
+		}
+		workflow.Statuses = append(workflow.Statuses, status)
+	}
+	if err := statusRows.Err(); err != nil {
+		return domain.Workflow{}, err
+	}
+
+	getTransitionsSql := `SELECT from_status, to_status FROM workflow_transitions WHERE user_id = $1 ORDER BY from_status, to_status`
+	transitionRows, err := workflowRepository.dbPool.Query(ctx, getTransitionsSql, userId)
+	if err != nil {
+		return domain.Workflow{}, errors.New(fmt.Sprintf("Error while getting workflow of user with id %d: %v", userId, err))
+	}
+	defer transitionRows.Close()
+
+	for transitionRows.Next() {
+		var transition domain.WorkflowTransition
+		if err := transitionRows.Scan(&transition.From, &transition.To); err != nil {
+			return domain.Workflow{}, err
+		}
+		workflow.Transitions = append(workflow.Transitions, transition)
+	}
+
+	return workflow, transitionRows.Err()
+}
+
+// SaveWorkflow replaces the statuses and transitions of the user in a single transaction.
+func (workflowRepository *WorkflowRepository) SaveWorkflow(workflow domain.Workflow) error {
+	ctx := context.Background()
+	tx, err := workflowRepository.dbPool.Begin(ctx)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while starting transaction: %v", err))
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM workflow_statuses WHERE user_id = $1`, workflow.UserId)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error while saving workflow: %v", err))
+	}
+
+	insertStatusSql := `INSERT INTO workflow_statuses (user_id, status_key, name, is_done, position) VALUES ($1, $2, $3, $4, $5)`
+	for position, status := range workflow.Statuses {
+		_, err = tx.Exec(ctx, insertStatusSql, workflow.UserId, status.Key, status.Name, status.IsDone, position)
+		if err != nil {
+			return errors.New(fmt.Sprintf("Error while saving workflow: %v", err))
+		}
+	}
+
+	insertTransitionSql := `INSERT INTO workflow_transitions (user_id, from_status, to_status) VALUES ($1, $2, $3)`
+	for _, transition := range workflow.Transitions {
+		_, err = tx.Exec(ctx, insertTransitionSql, workflow.UserId, transition.From, transition.To)
+		if err != nil {
+			return errors.New(fmt.Sprintf("Error while saving workflow: %v", err))
+		}
+	}
+
+	return tx.Commit(ctx)
+}
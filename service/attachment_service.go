@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"todo-app--go-gin/common/storage"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+// contentSniffLength is the number of bytes http.DetectContentType looks at.
+const contentSniffLength = 512
+
+type IAttachmentService interface {
+	GetAttachments(userId int, todoId int) ([]response.AttachmentResponse, error)
+	AddAttachment(attachmentCreate request.AttachmentCreate) (response.AttachmentResponse, error)
+	OpenAttachment(userId int, todoId int, attachmentId int) (response.AttachmentResponse, io.ReadCloser, error)
+	DeleteAttachment(userId int, todoId int, attachmentId int) error
+	CleanupOrphanedAttachments() (int, error)
+}
+
+type AttachmentService struct {
+	attachmentRepository persistence.IAttachmentRepository
+	todoRepository       persistence.ITodoRepository
+	blobStore            storage.BlobStore
+	maxSize              int64
+	allowedContentTypes  []string
+	todoAuthorizer       ITodoAuthorizer
+}
+
+func NewAttachmentService(attachmentRepository persistence.IAttachmentRepository, todoRepository persistence.ITodoRepository, shareRepository persistence.IShareRepository, blobStore storage.BlobStore, maxSize int64, allowedContentTypes []string) IAttachmentService {
+	return &AttachmentService{
+		attachmentRepository: attachmentRepository,
+		todoRepository:       todoRepository,
+		todoAuthorizer:       NewTodoAuthorizer(todoRepository, shareRepository),
+		blobStore:            blobStore,
+		maxSize:              maxSize,
+		allowedContentTypes:  allowedContentTypes,
+	}
+}
+
+func (attachmentService AttachmentService) GetAttachments(userId int, todoId int) ([]response.AttachmentResponse, error) {
+	err := attachmentService.checkTodoAccess(userId, todoId, domain.TodoAccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := attachmentService.attachmentRepository.GetAttachmentsByTodoId(todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NewAttachmentResponses(attachments), nil
+}
+
+// AddAttachment stores an uploaded file. The content type is detected from the content rather than
+// trusted from the client, and files that are too large or of a type that is not allowed are rejected.
+func (attachmentService AttachmentService) AddAttachment(attachmentCreate request.AttachmentCreate) (response.AttachmentResponse, error) {
+	fileName := strings.TrimSpace(filepath.Base(strings.ReplaceAll(attachmentCreate.FileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return response.AttachmentResponse{}, errors.New("Attachment file name cannot be empty")
+	} else if len(fileName) > 255 {
+		return response.AttachmentResponse{}, errors.New("Attachment file name must be at most 255 characters long")
+	}
+
+	err := attachmentService.checkTodoAccess(attachmentCreate.UserId, attachmentCreate.TodoId, domain.TodoAccessEdit)
+	if err != nil {
+		return response.AttachmentResponse{}, err
+	}
+
+	head := make([]byte, contentSniffLength)
+	headLength, err := io.ReadFull(attachmentCreate.Content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return response.AttachmentResponse{}, errors.New(fmt.Sprintf("Error while reading attachment: %v", err))
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head[:headLength]))
+	if err != nil || !slices.Contains(attachmentService.allowedContentTypes, contentType) {
+		return response.AttachmentResponse{}, errors.New(fmt.Sprintf("Attachments of type %s are not allowed", contentType))
+	}
+
+	blobKey, size, err := attachmentService.blobStore.Put(io.MultiReader(bytes.NewReader(head[:headLength]), attachmentCreate.Content), attachmentService.maxSize)
+	if err == storage.ErrBlobTooLarge {
+		return response.AttachmentResponse{}, errors.New(fmt.Sprintf("Attachment must be at most %d bytes", attachmentService.maxSize))
+	} else if err != nil {
+		return response.AttachmentResponse{}, err
+	}
+
+	addedAttachment, err := attachmentService.attachmentRepository.AddAttachment(domain.Attachment{
+		TodoId:      &attachmentCreate.TodoId,
+		UserId:      attachmentCreate.UserId,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		BlobKey:     blobKey,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		attachmentService.deleteBlobIfUnused(blobKey)
+		return response.AttachmentResponse{}, errors.Wrap(err, "Failed to add new attachment")
+	}
+
+	return response.NewAttachmentResponse(addedAttachment), nil
+}
+
+// OpenAttachment returns an attachment with a reader of its content, which the caller must close.
+func (attachmentService AttachmentService) OpenAttachment(userId int, todoId int, attachmentId int) (response.AttachmentResponse, io.ReadCloser, error) {
+	attachment, err := attachmentService.getTodoAttachment(userId, todoId, attachmentId, domain.TodoAccessView)
+	if err != nil {
+		return response.AttachmentResponse{}, nil, err
+	}
+
+	content, err := attachmentService.blobStore.Open(attachment.BlobKey)
+	if err != nil {
+		return response.AttachmentResponse{}, nil, err
+	}
+
+	return response.NewAttachmentResponse(attachment), content, nil
+}
+
+func (attachmentService AttachmentService) DeleteAttachment(userId int, todoId int, attachmentId int) error {
+	attachment, err := attachmentService.getTodoAttachment(userId, todoId, attachmentId, domain.TodoAccessEdit)
+	if err != nil {
+		return err
+	}
+
+	err = attachmentService.attachmentRepository.DeleteAttachment(attachmentId)
+	if err != nil {
+		return err
+	}
+
+	return attachmentService.deleteBlobIfUnused(attachment.BlobKey)
+}
+
+// CleanupOrphanedAttachments deletes the attachments of permanently deleted todos together with
+// the blobs no other attachment shares, and returns how many attachments were deleted.
+func (attachmentService AttachmentService) CleanupOrphanedAttachments() (int, error) {
+	orphanedAttachments, err := attachmentService.attachmentRepository.GetOrphanedAttachments()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, attachment := range orphanedAttachments {
+		err = attachmentService.attachmentRepository.DeleteAttachment(attachment.Id)
+		if err != nil {
+			return i, err
+		}
+
+		err = attachmentService.deleteBlobIfUnused(attachment.BlobKey)
+		if err != nil {
+			return i + 1, err
+		}
+	}
+
+	return len(orphanedAttachments), nil
+}
+
+// deleteBlobIfUnused removes a blob once no attachment refers to it any more. Identical files
+// uploaded to several todos share one blob.
+func (attachmentService AttachmentService) deleteBlobIfUnused(blobKey string) error {
+	count, err := attachmentService.attachmentRepository.CountAttachmentsByBlobKey(blobKey)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	return attachmentService.blobStore.Delete(blobKey)
+}
+
+func (attachmentService AttachmentService) checkTodoAccess(userId int, todoId int, access domain.TodoAccess) error {
+	todo, err := attachmentService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return err
+	}
+
+	return attachmentService.todoAuthorizer.AuthorizeTodo(userId, todo, access)
+}
+
+func (attachmentService AttachmentService) getTodoAttachment(userId int, todoId int, attachmentId int, access domain.TodoAccess) (domain.Attachment, error) {
+	err := attachmentService.checkTodoAccess(userId, todoId, access)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	attachment, err := attachmentService.attachmentRepository.GetAttachmentById(attachmentId)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	if attachment.TodoId == nil || *attachment.TodoId != todoId {
+		return domain.Attachment{}, errors.New(fmt.Sprintf("Attachment with id %d not found", attachmentId))
+	}
+
+	return attachment, nil
+}
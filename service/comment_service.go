@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+const (
+	defaultCommentPageLimit = 20
+	maxCommentPageLimit     = 100
+	maxCommentLength        = 10000
+)
+
+type ICommentService interface {
+	GetComments(userId int, todoId int, commentFilter request.CommentFilter) (response.CommentPageResponse, error)
+	AddComment(commentCreate request.CommentCreate) (response.CommentResponse, error)
+	UpdateComment(userId int, todoId int, commentId int, commentUpdate request.CommentUpdate) (response.CommentResponse, error)
+	DeleteComment(userId int, todoId int, commentId int) error
+}
+
+type CommentService struct {
+	commentRepository persistence.ICommentRepository
+	todoRepository    persistence.ITodoRepository
+	todoAuthorizer    ITodoAuthorizer
+}
+
+func NewCommentService(commentRepository persistence.ICommentRepository, todoRepository persistence.ITodoRepository, shareRepository persistence.IShareRepository) ICommentService {
+	return &CommentService{
+		commentRepository: commentRepository,
+		todoRepository:    todoRepository,
+		todoAuthorizer:    NewTodoAuthorizer(todoRepository, shareRepository),
+	}
+}
+
+func (commentService CommentService) GetComments(userId int, todoId int, commentFilter request.CommentFilter) (response.CommentPageResponse, error) {
+	if commentFilter.Limit == 0 {
+		commentFilter.Limit = defaultCommentPageLimit
+	}
+	if commentFilter.Limit < 0 || commentFilter.Limit > maxCommentPageLimit {
+		return response.CommentPageResponse{}, errors.New(fmt.Sprintf("Limit must be between 1 and %d", maxCommentPageLimit))
+	}
+	if commentFilter.Offset < 0 {
+		return response.CommentPageResponse{}, errors.New("Offset cannot be negative")
+	}
+
+	err := commentService.checkTodoAccess(userId, todoId)
+	if err != nil {
+		return response.CommentPageResponse{}, err
+	}
+
+	comments, total, err := commentService.commentRepository.GetCommentsByTodoId(todoId, commentFilter.Limit, commentFilter.Offset)
+	if err != nil {
+		return response.CommentPageResponse{}, err
+	}
+
+	return response.NewCommentPageResponse(comments, total, commentFilter.Limit, commentFilter.Offset), nil
+}
+
+func (commentService CommentService) AddComment(commentCreate request.CommentCreate) (response.CommentResponse, error) {
+	validationError := validateComment(commentCreate.Body)
+	if validationError != nil {
+		return response.CommentResponse{}, validationError
+	}
+
+	err := commentService.checkTodoAccess(commentCreate.UserId, commentCreate.TodoId)
+	if err != nil {
+		return response.CommentResponse{}, err
+	}
+
+	addedComment, err := commentService.commentRepository.AddComment(domain.Comment{
+		TodoId:    commentCreate.TodoId,
+		UserId:    commentCreate.UserId,
+		Body:      strings.TrimSpace(commentCreate.Body),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return response.CommentResponse{}, errors.Wrap(err, "Failed to add new comment")
+	}
+
+	return response.NewCommentResponse(addedComment), nil
+}
+
+func (commentService CommentService) UpdateComment(userId int, todoId int, commentId int, commentUpdate request.CommentUpdate) (response.CommentResponse, error) {
+	validationError := validateComment(commentUpdate.Body)
+	if validationError != nil {
+		return response.CommentResponse{}, validationError
+	}
+
+	comment, err := commentService.getAuthoredComment(userId, todoId, commentId)
+	if err != nil {
+		return response.CommentResponse{}, err
+	}
+
+	editedAt := time.Now()
+	comment.Body = strings.TrimSpace(commentUpdate.Body)
+	comment.EditedAt = &editedAt
+
+	updatedComment, err := commentService.commentRepository.UpdateComment(commentId, comment)
+	if err != nil {
+		return response.CommentResponse{}, err
+	}
+
+	return response.NewCommentResponse(updatedComment), nil
+}
+
+func (commentService CommentService) DeleteComment(userId int, todoId int, commentId int) error {
+	_, err := commentService.getAuthoredComment(userId, todoId, commentId)
+	if err != nil {
+		return err
+	}
+
+	return commentService.commentRepository.DeleteComment(commentId)
+}
+
+// checkTodoAccess allows commenting on the todos the user can read, including the ones shared with them for viewing.
+func (commentService CommentService) checkTodoAccess(userId int, todoId int) error {
+	todo, err := commentService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return err
+	}
+
+	return commentService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessView)
+}
+
+// getAuthoredComment returns a comment of the todo that the user wrote. Only the author can change a comment.
+func (commentService CommentService) getAuthoredComment(userId int, todoId int, commentId int) (domain.Comment, error) {
+	err := commentService.checkTodoAccess(userId, todoId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := commentService.commentRepository.GetCommentById(commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if comment.TodoId != todoId {
+		return domain.Comment{}, errors.New(fmt.Sprintf("Comment with id %d not found", commentId))
+	}
+
+	if comment.UserId != userId {
+		return domain.Comment{}, errors.New("This comment is not belongs to you")
+	}
+
+	return comment, nil
+}
+
+func validateComment(body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return errors.New("Comment cannot be empty")
+	} else if len(body) > maxCommentLength {
+		return errors.New(fmt.Sprintf("Comment must be at most %d characters long", maxCommentLength))
+	}
+
+	return nil
+}
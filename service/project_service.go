@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+type IProjectService interface {
+	GetAllProjects(userId int, includeArchived bool) ([]response.ProjectResponse, error)
+	GetProjectById(userId int, projectId int) (response.ProjectResponse, error)
+	AddProject(projectCreate request.ProjectCreate) (response.ProjectResponse, error)
+	UpdateProject(userId int, projectId int, projectUpdate request.ProjectUpdate) (response.ProjectResponse, error)
+	DeleteProject(userId int, projectId int, deleteMode string) error
+}
+
+type ProjectService struct {
+	projectRepository persistence.IProjectRepository
+}
+
+func NewProjectService(projectRepository persistence.IProjectRepository) IProjectService {
+	return &ProjectService{projectRepository: projectRepository}
+}
+
+func (projectService ProjectService) GetAllProjects(userId int, includeArchived bool) ([]response.ProjectResponse, error) {
+	projects, err := projectService.projectRepository.GetAllProjectsByUserId(userId, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertProjectsToResponses(projects), nil
+}
+
+func (projectService ProjectService) GetProjectById(userId int, projectId int) (response.ProjectResponse, error) {
+	project, err := projectService.getOwnedProject(userId, projectId)
+	if err != nil {
+		return response.ProjectResponse{}, err
+	}
+
+	return response.NewProjectResponse(project), nil
+}
+
+func (projectService ProjectService) AddProject(projectCreate request.ProjectCreate) (response.ProjectResponse, error) {
+	validationError := validateProject(projectCreate)
+	if validationError != nil {
+		return response.ProjectResponse{}, validationError
+	}
+
+	addedProject, err := projectService.projectRepository.AddProject(domain.Project{
+		UserId:    projectCreate.UserId,
+		Name:      strings.TrimSpace(projectCreate.Name),
+		Color:     projectCreate.Color,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return response.ProjectResponse{}, errors.Wrap(err, "Failed to add new project")
+	}
+
+	return response.NewProjectResponse(addedProject), nil
+}
+
+func (projectService ProjectService) UpdateProject(userId int, projectId int, projectUpdate request.ProjectUpdate) (response.ProjectResponse, error) {
+	validationError := validateProject(projectUpdate)
+	if validationError != nil {
+		return response.ProjectResponse{}, validationError
+	}
+
+	project, err := projectService.getOwnedProject(userId, projectId)
+	if err != nil {
+		return response.ProjectResponse{}, err
+	}
+
+	project.Name = strings.TrimSpace(projectUpdate.Name)
+	project.Color = projectUpdate.Color
+	project.IsArchived = projectUpdate.IsArchived
+	project.Position = projectUpdate.Position
+	project.UpdatedAt = time.Now()
+
+	updatedProject, err := projectService.projectRepository.UpdateProject(projectId, project)
+	if err != nil {
+		return response.ProjectResponse{}, err
+	}
+
+	return response.NewProjectResponse(updatedProject), nil
+}
+
+func (projectService ProjectService) DeleteProject(userId int, projectId int, deleteMode string) error {
+	if deleteMode == "" {
+		deleteMode = domain.ProjectDeleteModeInbox
+	}
+
+	if deleteMode != domain.ProjectDeleteModeInbox && deleteMode != domain.ProjectDeleteModeCascade {
+		return errors.New(fmt.Sprintf("Unsupported delete mode %s", deleteMode))
+	}
+
+	_, err := projectService.getOwnedProject(userId, projectId)
+	if err != nil {
+		return err
+	}
+
+	return projectService.projectRepository.DeleteProject(projectId, deleteMode)
+}
+
+func (projectService ProjectService) getOwnedProject(userId int, projectId int) (domain.Project, error) {
+	project, err := projectService.projectRepository.GetProjectById(projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	if project.UserId != userId {
+		return domain.Project{}, errors.New("This project is not belongs to you")
+	}
+
+	return project, nil
+}
+
+func convertProjectsToResponses(projects []domain.Project) []response.ProjectResponse {
+	projectResponses := []response.ProjectResponse{}
+	for _, project := range projects {
+		projectResponses = append(projectResponses, response.NewProjectResponse(project))
+	}
+
+	return projectResponses
+}
+
+func validateProject(project interface{}) error {
+	var name string
+	switch p := project.(type) {
+	case request.ProjectCreate:
+		name = p.Name
+	case request.ProjectUpdate:
+		name = p.Name
+		if p.Position < 0 {
+			return errors.New("Project position cannot be negative")
+		}
+	default:
+		return errors.New("Unsupported type")
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return errors.New("Project name cannot be empty")
+	}
+
+	return nil
+}
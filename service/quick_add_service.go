@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"todo-app--go-gin/common/util/quickadd"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+)
+
+type IQuickAddService interface {
+	QuickAddTodo(todoQuickAdd request.TodoQuickAdd) (response.TodoQuickAddResponse, error)
+}
+
+type QuickAddService struct {
+	todoService ITodoService
+	tagService  ITagService
+	now         func() time.Time
+}
+
+// NewQuickAddService returns a service that resolves relative dates against now, which is time.Now
+// outside of tests.
+func NewQuickAddService(todoService ITodoService, tagService ITagService, now func() time.Time) IQuickAddService {
+	return &QuickAddService{
+		todoService: todoService,
+		tagService:  tagService,
+		now:         now,
+	}
+}
+
+// QuickAddTodo parses the text into the fields of a todo and adds it the same way AddTodo does, so the
+// parsed todo goes through the same validation.
+func (quickAddService QuickAddService) QuickAddTodo(todoQuickAdd request.TodoQuickAdd) (response.TodoQuickAddResponse, error) {
+	text := strings.TrimSpace(todoQuickAdd.Text)
+	if text == "" {
+		return response.TodoQuickAddResponse{}, errors.New("Quick add text cannot be empty")
+	}
+
+	location := time.UTC
+	if todoQuickAdd.Timezone != nil {
+		var err error
+		location, err = time.LoadLocation(*todoQuickAdd.Timezone)
+		if err != nil || *todoQuickAdd.Timezone == "" {
+			return response.TodoQuickAddResponse{}, errors.New(fmt.Sprintf("Unknown timezone %s", *todoQuickAdd.Timezone))
+		}
+	}
+
+	result := quickadd.Parse(text, quickAddService.now().In(location))
+
+	tags, err := quickAddService.tagService.GetAllTags(todoQuickAdd.UserId)
+	if err != nil {
+		return response.TodoQuickAddResponse{}, err
+	}
+
+	parsed := response.QuickAddParsedResponse{
+		Title:          result.Title,
+		DueDate:        result.DueDate,
+		DueTime:        result.DueTime,
+		Priority:       result.Priority,
+		RecurrenceRule: result.RecurrenceRule,
+		Tags:           []string{},
+		UnknownTags:    []string{},
+		Tokens:         []response.QuickAddTokenResponse{},
+	}
+	if result.DueTime != nil {
+		parsed.DueTimezone = todoQuickAdd.Timezone
+	}
+
+	var tagIds []int
+	for _, tagName := range result.Tags {
+		found := false
+		for _, tag := range tags {
+			if strings.EqualFold(tag.Name, tagName) {
+				tagIds = append(tagIds, tag.Id)
+				parsed.Tags = append(parsed.Tags, tag.Name)
+				found = true
+				break
+			}
+		}
+		if !found {
+			parsed.UnknownTags = append(parsed.UnknownTags, tagName)
+		}
+	}
+
+	for _, token := range result.Tokens {
+		parsed.Tokens = append(parsed.Tokens, response.QuickAddTokenResponse{Text: token.Text, Kind: token.Kind, Value: token.Value})
+	}
+
+	description := todoQuickAdd.Description
+	if description == "" {
+		description = text
+	}
+
+	todo, err := quickAddService.todoService.AddTodo(request.TodoCreate{
+		UserId:         todoQuickAdd.UserId,
+		ProjectId:      todoQuickAdd.ProjectId,
+		Title:          result.Title,
+		Description:    description,
+		Priority:       result.Priority,
+		DueDate:        result.DueDate,
+		DueTime:        result.DueTime,
+		DueTimezone:    parsed.DueTimezone,
+		RecurrenceRule: result.RecurrenceRule,
+		TagIds:         tagIds,
+	})
+	if err != nil {
+		return response.TodoQuickAddResponse{}, err
+	}
+
+	return response.TodoQuickAddResponse{Todo: todo, Parsed: parsed}, nil
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+	"todo-app--go-gin/common/util/filterexpr"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+const (
+	maxSavedFilterNameLength       = 100
+	maxSavedFilterExpressionLength = 1000
+)
+
+type ISavedFilterService interface {
+	GetSavedFilters(userId int) ([]response.SavedFilterResponse, error)
+	GetSavedFilterById(userId int, savedFilterId int) (response.SavedFilterResponse, error)
+	AddSavedFilter(savedFilterCreate request.SavedFilterCreate) (response.SavedFilterResponse, error)
+	UpdateSavedFilter(userId int, savedFilterId int, savedFilterUpdate request.SavedFilterUpdate) (response.SavedFilterResponse, error)
+	DeleteSavedFilter(userId int, savedFilterId int) error
+	GetSavedFilterTodos(userId int, savedFilterId int, savedFilterEvaluate request.SavedFilterEvaluate) (response.TodoPageResponse, error)
+}
+
+type SavedFilterService struct {
+	savedFilterRepository persistence.ISavedFilterRepository
+	todoService           TodoService
+	now                   func() time.Time
+}
+
+// NewSavedFilterService returns a service that resolves relative dates of filters against now, which
+// is time.Now outside of tests.
+func NewSavedFilterService(savedFilterRepository persistence.ISavedFilterRepository, todoRepository persistence.ITodoRepository, shareRepository persistence.IShareRepository, now func() time.Time) ISavedFilterService {
+	return &SavedFilterService{
+		savedFilterRepository: savedFilterRepository,
+		todoService: TodoService{
+			todoRepository: todoRepository,
+			todoAuthorizer: NewTodoAuthorizer(todoRepository, shareRepository),
+		},
+		now: now,
+	}
+}
+
+func (savedFilterService SavedFilterService) GetSavedFilters(userId int) ([]response.SavedFilterResponse, error) {
+	savedFilters, err := savedFilterService.savedFilterRepository.GetSavedFiltersByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NewSavedFilterResponses(savedFilters), nil
+}
+
+func (savedFilterService SavedFilterService) GetSavedFilterById(userId int, savedFilterId int) (response.SavedFilterResponse, error) {
+	savedFilter, err := savedFilterService.getOwnedSavedFilter(userId, savedFilterId)
+	if err != nil {
+		return response.SavedFilterResponse{}, err
+	}
+
+	return response.NewSavedFilterResponse(savedFilter), nil
+}
+
+func (savedFilterService SavedFilterService) AddSavedFilter(savedFilterCreate request.SavedFilterCreate) (response.SavedFilterResponse, error) {
+	name := strings.TrimSpace(savedFilterCreate.Name)
+	expression := strings.TrimSpace(savedFilterCreate.Expression)
+	err := savedFilterService.validateSavedFilter(savedFilterCreate.UserId, 0, name, expression)
+	if err != nil {
+		return response.SavedFilterResponse{}, err
+	}
+
+	addedSavedFilter, err := savedFilterService.savedFilterRepository.AddSavedFilter(domain.SavedFilter{
+		UserId:     savedFilterCreate.UserId,
+		Name:       name,
+		Expression: expression,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	})
+	if err != nil {
+		return response.SavedFilterResponse{}, errors.Wrap(err, "Failed to add new saved filter")
+	}
+
+	return response.NewSavedFilterResponse(addedSavedFilter), nil
+}
+
+func (savedFilterService SavedFilterService) UpdateSavedFilter(userId int, savedFilterId int, savedFilterUpdate request.SavedFilterUpdate) (response.SavedFilterResponse, error) {
+	savedFilter, err := savedFilterService.getOwnedSavedFilter(userId, savedFilterId)
+	if err != nil {
+		return response.SavedFilterResponse{}, err
+	}
+
+	name := strings.TrimSpace(savedFilterUpdate.Name)
+	expression := strings.TrimSpace(savedFilterUpdate.Expression)
+	err = savedFilterService.validateSavedFilter(userId, savedFilterId, name, expression)
+	if err != nil {
+		return response.SavedFilterResponse{}, err
+	}
+
+	savedFilter.Name = name
+	savedFilter.Expression = expression
+	savedFilter.UpdatedAt = time.Now()
+
+	updatedSavedFilter, err := savedFilterService.savedFilterRepository.UpdateSavedFilter(savedFilter)
+	if err != nil {
+		return response.SavedFilterResponse{}, err
+	}
+
+	return response.NewSavedFilterResponse(updatedSavedFilter), nil
+}
+
+func (savedFilterService SavedFilterService) DeleteSavedFilter(userId int, savedFilterId int) error {
+	_, err := savedFilterService.getOwnedSavedFilter(userId, savedFilterId)
+	if err != nil {
+		return err
+	}
+
+	return savedFilterService.savedFilterRepository.DeleteSavedFilter(savedFilterId)
+}
+
+// GetSavedFilterTodos lists a page of the todos matching a saved filter, including todos shared with
+// the user unless the filter limits the owner.
+func (savedFilterService SavedFilterService) GetSavedFilterTodos(userId int, savedFilterId int, savedFilterEvaluate request.SavedFilterEvaluate) (response.TodoPageResponse, error) {
+	savedFilter, err := savedFilterService.getOwnedSavedFilter(userId, savedFilterId)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	terms, err := filterexpr.Parse(savedFilter.Expression)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	todoQuery, owner, err := newSavedFilterQuery(userId, terms, savedFilterService.now(), savedFilterEvaluate)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	err = savedFilterService.todoService.includeSharedTodos(&todoQuery, owner)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	todos, total, err := savedFilterService.todoService.todoRepository.GetTodosByQuery(todoQuery)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	return response.NewTodoPageResponse(convertTodosToResponses(todos), total, todoQuery.Limit, todoQuery.Offset), nil
+}
+
+func (savedFilterService SavedFilterService) getOwnedSavedFilter(userId int, savedFilterId int) (domain.SavedFilter, error) {
+	savedFilter, err := savedFilterService.savedFilterRepository.GetSavedFilterById(savedFilterId)
+	if err != nil {
+		return domain.SavedFilter{}, err
+	}
+
+	if savedFilter.UserId != userId {
+		return domain.SavedFilter{}, errors.New("This saved filter is not belongs to you")
+	}
+
+	return savedFilter, nil
+}
+
+// validateSavedFilter checks that the name is unique among the other filters of the user and that the
+// expression compiles to a valid todo query.
+func (savedFilterService SavedFilterService) validateSavedFilter(userId int, savedFilterId int, name string, expression string) error {
+	if len(name) == 0 || len(name) > maxSavedFilterNameLength {
+		return errors.New(fmt.Sprintf("Saved filter name must be between 1 and %d characters long", maxSavedFilterNameLength))
+	}
+
+	if len(expression) > maxSavedFilterExpressionLength {
+		return errors.New(fmt.Sprintf("Filter expression must be at most %d characters long", maxSavedFilterExpressionLength))
+	}
+
+	terms, err := filterexpr.Parse(expression)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = newSavedFilterQuery(userId, terms, savedFilterService.now(), request.SavedFilterEvaluate{})
+	if err != nil {
+		return err
+	}
+
+	savedFilters, err := savedFilterService.savedFilterRepository.GetSavedFiltersByUserId(userId)
+	if err != nil {
+		return err
+	}
+
+	for _, savedFilter := range savedFilters {
+		if savedFilter.Id != savedFilterId && strings.EqualFold(savedFilter.Name, name) {
+			return errors.New(fmt.Sprintf("A saved filter named %s already exists", name))
+		}
+	}
+
+	return nil
+}
+
+// newSavedFilterQuery compiles the terms of a filter expression into a todo query the same way list
+// filters are, with relative due dates resolved against now in the requested timezone. It returns the
+// owner the filter is limited to. The supported fields are:
+//
+//   - priority:high,urgent
+//   - status:in_progress and done:true or done:false
+//   - due:today, due:tomorrow, due:overdue, due:this-week, due:next-week, due:next-7-days,
+//     due:2024-06-01 and due:2024-06-01..2024-06-30
+//   - tag:work,home, matching todos with any of the tags
+//   - project:12 or project:inbox and parent:12 or parent:none
+//   - assignee:me, assignee:none or assignee:7 and owner:me or owner:others
+//   - title:"weekly report"
+//   - sort:dueDate and order:desc
+func newSavedFilterQuery(userId int, terms []filterexpr.Term, now time.Time, savedFilterEvaluate request.SavedFilterEvaluate) (domain.TodoQuery, string, error) {
+	todoFilter := request.TodoFilter{
+		Timezone: savedFilterEvaluate.Timezone,
+		Limit:    savedFilterEvaluate.Limit,
+		Offset:   savedFilterEvaluate.Offset,
+	}
+	if todoFilter.Timezone == "" {
+		todoFilter.Timezone = "UTC"
+	}
+
+	location, err := time.LoadLocation(todoFilter.Timezone)
+	if err != nil {
+		return domain.TodoQuery{}, "", errors.New(fmt.Sprintf("Unknown timezone %s", todoFilter.Timezone))
+	}
+
+	now = now.In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var priorities []int
+	var dueBefore *time.Time
+	for _, term := range terms {
+		value := term.Values[0]
+		if len(term.Values) > 1 && term.Field != "priority" && term.Field != "tag" {
+			return domain.TodoQuery{}, "", errors.New(fmt.Sprintf("Filter field %s takes a single value", term.Field))
+		}
+
+		switch term.Field {
+		case "priority":
+			for _, name := range term.Values {
+				priority, err := parsePriority(name)
+				if err != nil {
+					return domain.TodoQuery{}, "", err
+				}
+				priorities = append(priorities, priority)
+			}
+		case "status":
+			todoFilter.Status = value
+		case "done":
+			isCompleted, err := strconv.ParseBool(value)
+			if err != nil {
+				return domain.TodoQuery{}, "", errors.New("Filter field done must be true or false")
+			}
+			todoFilter.IsCompleted = &isCompleted
+		case "due":
+			if value == "overdue" {
+				dueBefore = &now
+				continue
+			}
+
+			dueFrom, dueTo, err := parseDueRange(value, today)
+			if err != nil {
+				return domain.TodoQuery{}, "", err
+			}
+			todoFilter.DueFrom = &dueFrom
+			todoFilter.DueTo = &dueTo
+		case "tag":
+			todoFilter.Tags = term.Values
+		case "project":
+			todoFilter.ProjectId = value
+		case "parent":
+			todoFilter.ParentId = value
+		case "assignee":
+			todoFilter.Assignee = value
+		case "owner":
+			todoFilter.Owner = value
+		case "title":
+			todoFilter.Title = value
+		case "sort":
+			todoFilter.Sort = value
+		case "order":
+			todoFilter.Order = value
+		default:
+			return domain.TodoQuery{}, "", errors.New(fmt.Sprintf("Unknown filter field %s", term.Field))
+		}
+	}
+
+	todoQuery, err := newTodoQuery(userId, todoFilter)
+	if err != nil {
+		return domain.TodoQuery{}, "", err
+	}
+
+	todoQuery.Priorities = priorities
+	todoQuery.DueBefore = dueBefore
+
+	return todoQuery, todoFilter.Owner, nil
+}
+
+// parseDueRange returns the first and last due date of a due filter value relative to today.
+func parseDueRange(value string, today time.Time) (time.Time, time.Time, error) {
+	switch value {
+	case "today":
+		return today, today, nil
+	case "tomorrow":
+		tomorrow := today.AddDate(0, 0, 1)
+		return tomorrow, tomorrow, nil
+	case "this-week":
+		sunday := today.AddDate(0, 0, (7-int(today.Weekday()))%7)
+		return today, sunday, nil
+	case "next-week":
+		monday := today.AddDate(0, 0, (7-int(today.Weekday()))%7+1)
+		return monday, monday.AddDate(0, 0, 6), nil
+	}
+
+	if strings.HasPrefix(value, "next-") && strings.HasSuffix(value, "-days") {
+		days, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(value, "next-"), "-days"))
+		if err != nil || days < 1 || days > maxUpcomingDays {
+			return time.Time{}, time.Time{}, errors.New(fmt.Sprintf("Days of due:%s must be between 1 and %d", value, maxUpcomingDays))
+		}
+		return today, today.AddDate(0, 0, days-1), nil
+	}
+
+	from, to, isRange := strings.Cut(value, "..")
+	if !isRange {
+		to = from
+	}
+
+	dueFrom, fromErr := time.Parse(domain.DueDateLayout, from)
+	dueTo, toErr := time.Parse(domain.DueDateLayout, to)
+	if fromErr != nil || toErr != nil {
+		return time.Time{}, time.Time{}, errors.New(fmt.Sprintf("Unsupported due value %s", value))
+	}
+
+	return dueFrom, dueTo, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+type IShareService interface {
+	GetTodoShares(userId int, todoId int) ([]response.ShareResponse, error)
+	ShareTodo(userId int, todoId int, shareCreate request.ShareCreate) (response.ShareResponse, error)
+	UnshareTodo(userId int, todoId int, sharedUserId int) error
+	GetProjectShares(userId int, projectId int) ([]response.ShareResponse, error)
+	ShareProject(userId int, projectId int, shareCreate request.ShareCreate) (response.ShareResponse, error)
+	UnshareProject(userId int, projectId int, sharedUserId int) error
+}
+
+type ShareService struct {
+	shareRepository   persistence.IShareRepository
+	todoRepository    persistence.ITodoRepository
+	projectRepository persistence.IProjectRepository
+	userRepository    persistence.IUserRepository
+	todoAuthorizer    ITodoAuthorizer
+}
+
+func NewShareService(shareRepository persistence.IShareRepository, todoRepository persistence.ITodoRepository, projectRepository persistence.IProjectRepository, userRepository persistence.IUserRepository) IShareService {
+	return &ShareService{
+		shareRepository:   shareRepository,
+		todoRepository:    todoRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		todoAuthorizer:    NewTodoAuthorizer(todoRepository, shareRepository),
+	}
+}
+
+func (shareService ShareService) GetTodoShares(userId int, todoId int) ([]response.ShareResponse, error) {
+	_, err := shareService.getTodo(userId, todoId, domain.TodoAccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := shareService.shareRepository.GetSharesByTodoId(todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NewShareResponses(shares), nil
+}
+
+// ShareTodo gives the user with the given email access to a todo and its subtasks. Sharing it again
+// with the same user changes their permission.
+func (shareService ShareService) ShareTodo(userId int, todoId int, shareCreate request.ShareCreate) (response.ShareResponse, error) {
+	todo, err := shareService.getTodo(userId, todoId, domain.TodoAccessOwner)
+	if err != nil {
+		return response.ShareResponse{}, err
+	}
+
+	return shareService.saveShare(todo.UserId, domain.Share{TodoId: &todo.Id}, shareCreate)
+}
+
+// UnshareTodo takes the access to a todo away from a user. Users can also leave a todo shared with them.
+func (shareService ShareService) UnshareTodo(userId int, todoId int, sharedUserId int) error {
+	access := domain.TodoAccessOwner
+	if sharedUserId == userId {
+		access = domain.TodoAccessView
+	}
+
+	_, err := shareService.getTodo(userId, todoId, access)
+	if err != nil {
+		return err
+	}
+
+	return shareService.shareRepository.DeleteTodoShare(todoId, sharedUserId)
+}
+
+func (shareService ShareService) GetProjectShares(userId int, projectId int) ([]response.ShareResponse, error) {
+	_, err := shareService.getProject(userId, projectId, domain.TodoAccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := shareService.shareRepository.GetSharesByProjectId(projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NewShareResponses(shares), nil
+}
+
+// ShareProject gives the user with the given email access to every todo of a project.
+func (shareService ShareService) ShareProject(userId int, projectId int, shareCreate request.ShareCreate) (response.ShareResponse, error) {
+	project, err := shareService.getProject(userId, projectId, domain.TodoAccessOwner)
+	if err != nil {
+		return response.ShareResponse{}, err
+	}
+
+	return shareService.saveShare(project.UserId, domain.Share{ProjectId: &project.Id}, shareCreate)
+}
+
+func (shareService ShareService) UnshareProject(userId int, projectId int, sharedUserId int) error {
+	access := domain.TodoAccessOwner
+	if sharedUserId == userId {
+		access = domain.TodoAccessView
+	}
+
+	_, err := shareService.getProject(userId, projectId, access)
+	if err != nil {
+		return err
+	}
+
+	return shareService.shareRepository.DeleteProjectShare(projectId, sharedUserId)
+}
+
+func (shareService ShareService) saveShare(ownerId int, share domain.Share, shareCreate request.ShareCreate) (response.ShareResponse, error) {
+	if shareCreate.Permission != domain.PermissionViewer && shareCreate.Permission != domain.PermissionEditor {
+		return response.ShareResponse{}, errors.New("Permission must be either viewer or editor")
+	}
+
+	user, err := shareService.userRepository.GetUserByEmail(strings.TrimSpace(shareCreate.Email))
+	if err != nil {
+		return response.ShareResponse{}, err
+	}
+
+	if user.Id == ownerId {
+		return response.ShareResponse{}, errors.New("You cannot share with yourself")
+	}
+
+	share.UserId = user.Id
+	share.Email = user.Email
+	share.Permission = shareCreate.Permission
+	share.CreatedAt = time.Now()
+	savedShare, err := shareService.shareRepository.SaveShare(share)
+	if err != nil {
+		return response.ShareResponse{}, err
+	}
+
+	return response.NewShareResponse(savedShare), nil
+}
+
+func (shareService ShareService) getTodo(userId int, todoId int, access domain.TodoAccess) (domain.Todo, error) {
+	todo, err := shareService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	err = shareService.todoAuthorizer.AuthorizeTodo(userId, todo, access)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	return todo, nil
+}
+
+func (shareService ShareService) getProject(userId int, projectId int, access domain.TodoAccess) (domain.Project, error) {
+	project, err := shareService.projectRepository.GetProjectById(projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	err = shareService.todoAuthorizer.AuthorizeProject(userId, project, access)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	return project, nil
+}
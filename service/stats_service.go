@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+const (
+	defaultStatsDays = 30
+	maxStatsDays     = 366
+)
+
+type IStatsService interface {
+	GetStats(userId int, statsFilter request.StatsFilter) (response.StatsResponse, error)
+}
+
+type StatsService struct {
+	todoRepository persistence.ITodoRepository
+	now            func() time.Time
+}
+
+func NewStatsService(todoRepository persistence.ITodoRepository, now func() time.Time) IStatsService {
+	return &StatsService{
+		todoRepository: todoRepository,
+		now:            now,
+	}
+}
+
+// GetStats summarises the todos the user owns, with the completions per day or week between from and to,
+// which default to the last 30 days. Days are taken in the timezone of the filter. Weekly periods start
+// on Monday, so from is moved back to the Monday of its week.
+func (statsService StatsService) GetStats(userId int, statsFilter request.StatsFilter) (response.StatsResponse, error) {
+	if statsFilter.Timezone == "" {
+		statsFilter.Timezone = "UTC"
+	}
+
+	location, err := time.LoadLocation(statsFilter.Timezone)
+	if err != nil {
+		return response.StatsResponse{}, errors.New(fmt.Sprintf("Unknown timezone %s", statsFilter.Timezone))
+	}
+
+	if statsFilter.Period == "" {
+		statsFilter.Period = domain.StatsPeriodDay
+	}
+	if statsFilter.Period != domain.StatsPeriodDay && statsFilter.Period != domain.StatsPeriodWeek {
+		return response.StatsResponse{}, errors.New(fmt.Sprintf("Unsupported period %s", statsFilter.Period))
+	}
+
+	now := statsService.now()
+	lastDay := statsDate(now.In(location))
+	if statsFilter.To != nil {
+		lastDay = statsDate(*statsFilter.To)
+	}
+
+	firstDay := lastDay.AddDate(0, 0, 1-defaultStatsDays)
+	if statsFilter.From != nil {
+		firstDay = statsDate(*statsFilter.From)
+	}
+
+	if firstDay.After(lastDay) {
+		return response.StatsResponse{}, errors.New("From must not be after to")
+	}
+
+	if firstDay.AddDate(0, 0, maxStatsDays).Before(lastDay.AddDate(0, 0, 1)) {
+		return response.StatsResponse{}, errors.New(fmt.Sprintf("Stats can cover at most %d days", maxStatsDays))
+	}
+
+	periodDays := 1
+	if statsFilter.Period == domain.StatsPeriodWeek {
+		periodDays = 7
+		firstDay = firstDay.AddDate(0, 0, -(int(firstDay.Weekday())+6)%7)
+	}
+
+	todoStats, err := statsService.todoRepository.GetTodoStats(userId, now, statsFilter.Timezone)
+	if err != nil {
+		return response.StatsResponse{}, err
+	}
+
+	completionCounts, err := statsService.todoRepository.GetCompletionCounts(userId, firstDay, lastDay, statsFilter.Period, statsFilter.Timezone)
+	if err != nil {
+		return response.StatsResponse{}, err
+	}
+
+	countsByPeriodStart := map[time.Time]int{}
+	for _, completionCount := range completionCounts {
+		countsByPeriodStart[statsDate(completionCount.PeriodStart)] = completionCount.Count
+	}
+
+	completions := []response.CompletionCountResponse{}
+	for periodStart := firstDay; !periodStart.After(lastDay); periodStart = periodStart.AddDate(0, 0, periodDays) {
+		completions = append(completions, response.CompletionCountResponse{
+			PeriodStart: periodStart.Format(domain.DueDateLayout),
+			Count:       countsByPeriodStart[periodStart],
+		})
+	}
+
+	var averageMinutesToComplete *int
+	if todoStats.AverageTimeToComplete != nil {
+		averageMinutes := minutes(*todoStats.AverageTimeToComplete)
+		averageMinutesToComplete = &averageMinutes
+	}
+
+	return response.StatsResponse{
+		OpenCount:                todoStats.OpenCount,
+		CompletedCount:           todoStats.CompletedCount,
+		OverdueCount:             todoStats.OverdueCount,
+		AverageMinutesToComplete: averageMinutesToComplete,
+		CurrentStreakDays:        todoStats.CurrentStreakDays,
+		Period:                   statsFilter.Period,
+		From:                     firstDay.Format(domain.DueDateLayout),
+		To:                       lastDay.Format(domain.DueDateLayout),
+		Completions:              completions,
+	}, nil
+}
+
+// statsDate drops the time of day of a date, keeping it in UTC like the dates the repository returns.
+func statsDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+type ITagService interface {
+	GetAllTags(userId int) ([]response.TagResponse, error)
+	GetTagById(userId int, tagId int) (response.TagResponse, error)
+	AddTag(tagCreate request.TagCreate) (response.TagResponse, error)
+	UpdateTag(userId int, tagId int, tagUpdate request.TagUpdate) (response.TagResponse, error)
+	DeleteTag(userId int, tagId int) error
+}
+
+type TagService struct {
+	tagRepository persistence.ITagRepository
+}
+
+func NewTagService(tagRepository persistence.ITagRepository) ITagService {
+	return &TagService{tagRepository: tagRepository}
+}
+
+func (tagService TagService) GetAllTags(userId int) ([]response.TagResponse, error) {
+	tags, err := tagService.tagRepository.GetAllTagsByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NewTagResponses(tags), nil
+}
+
+func (tagService TagService) GetTagById(userId int, tagId int) (response.TagResponse, error) {
+	tag, err := tagService.getOwnedTag(userId, tagId)
+	if err != nil {
+		return response.TagResponse{}, err
+	}
+
+	return response.NewTagResponse(tag), nil
+}
+
+func (tagService TagService) AddTag(tagCreate request.TagCreate) (response.TagResponse, error) {
+	validationError := validateTag(tagCreate)
+	if validationError != nil {
+		return response.TagResponse{}, validationError
+	}
+
+	addedTag, err := tagService.tagRepository.AddTag(domain.Tag{
+		UserId:    tagCreate.UserId,
+		Name:      strings.TrimSpace(tagCreate.Name),
+		Color:     tagCreate.Color,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return response.TagResponse{}, errors.Wrap(err, "Failed to add new tag")
+	}
+
+	return response.NewTagResponse(addedTag), nil
+}
+
+func (tagService TagService) UpdateTag(userId int, tagId int, tagUpdate request.TagUpdate) (response.TagResponse, error) {
+	validationError := validateTag(tagUpdate)
+	if validationError != nil {
+		return response.TagResponse{}, validationError
+	}
+
+	tag, err := tagService.getOwnedTag(userId, tagId)
+	if err != nil {
+		return response.TagResponse{}, err
+	}
+
+	tag.Name = strings.TrimSpace(tagUpdate.Name)
+	tag.Color = tagUpdate.Color
+
+	updatedTag, err := tagService.tagRepository.UpdateTag(tagId, tag)
+	if err != nil {
+		return response.TagResponse{}, err
+	}
+
+	return response.NewTagResponse(updatedTag), nil
+}
+
+func (tagService TagService) DeleteTag(userId int, tagId int) error {
+	_, err := tagService.getOwnedTag(userId, tagId)
+	if err != nil {
+		return err
+	}
+
+	return tagService.tagRepository.DeleteTag(tagId)
+}
+
+func (tagService TagService) getOwnedTag(userId int, tagId int) (domain.Tag, error) {
+	tag, err := tagService.tagRepository.GetTagById(tagId)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	if tag.UserId != userId {
+		return domain.Tag{}, errors.New("This tag is not belongs to you")
+	}
+
+	return tag, nil
+}
+
+func validateTag(tag interface{}) error {
+	var name string
+	switch t := tag.(type) {
+	case request.TagCreate:
+		name = t.Name
+	case request.TagUpdate:
+		name = t.Name
+	default:
+		return errors.New("Unsupported type")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("Tag name cannot be empty")
+	} else if len(name) > 64 {
+		return errors.New("Tag name must be at most 64 characters long")
+	}
+
+	return nil
+}
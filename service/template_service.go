@@ -0,0 +1,513 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"strings"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+const (
+	maxTemplateNameLength    = 100
+	maxTemplateDueOffsetDays = 3650
+)
+
+type ITemplateService interface {
+	GetTemplates(userId int) ([]response.TemplateResponse, error)
+	GetTemplateById(userId int, templateId int) (response.TemplateResponse, error)
+	AddTemplate(templateCreate request.TemplateCreate) (response.TemplateResponse, error)
+	AddTemplateFromTodos(templateFromTodos request.TemplateFromTodos) (response.TemplateResponse, error)
+	UpdateTemplate(userId int, templateId int, templateUpdate request.TemplateUpdate) (response.TemplateResponse, error)
+	DeleteTemplate(userId int, templateId int) error
+	InstantiateTemplate(userId int, templateId int, templateInstantiate request.TemplateInstantiate) ([]response.TodoResponse, error)
+}
+
+type TemplateService struct {
+	templateRepository persistence.ITemplateRepository
+	tagRepository      persistence.ITagRepository
+	todoService        TodoService
+}
+
+func NewTemplateService(templateRepository persistence.ITemplateRepository, todoRepository persistence.ITodoRepository, projectRepository persistence.IProjectRepository, workflowRepository persistence.IWorkflowRepository, tagRepository persistence.ITagRepository, shareRepository persistence.IShareRepository) ITemplateService {
+	return &TemplateService{
+		templateRepository: templateRepository,
+		tagRepository:      tagRepository,
+		todoService: TodoService{
+			todoRepository:     todoRepository,
+			projectRepository:  projectRepository,
+			workflowRepository: workflowRepository,
+			todoAuthorizer:     NewTodoAuthorizer(todoRepository, shareRepository),
+		},
+	}
+}
+
+func (templateService TemplateService) GetTemplates(userId int) ([]response.TemplateResponse, error) {
+	templates, err := templateService.templateRepository.GetTemplatesByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NewTemplateResponses(templates), nil
+}
+
+func (templateService TemplateService) GetTemplateById(userId int, templateId int) (response.TemplateResponse, error) {
+	template, err := templateService.getOwnedTemplate(userId, templateId)
+	if err != nil {
+		return response.TemplateResponse{}, err
+	}
+
+	return response.NewTemplateResponse(template), nil
+}
+
+func (templateService TemplateService) AddTemplate(templateCreate request.TemplateCreate) (response.TemplateResponse, error) {
+	items, err := templateService.validateTemplate(templateCreate.UserId, templateCreate.Name, templateCreate.Items)
+	if err != nil {
+		return response.TemplateResponse{}, err
+	}
+
+	addedTemplate, err := templateService.templateRepository.AddTemplate(domain.Template{
+		UserId:      templateCreate.UserId,
+		Name:        strings.TrimSpace(templateCreate.Name),
+		Description: templateCreate.Description,
+		Items:       items,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	})
+	if err != nil {
+		return response.TemplateResponse{}, errors.Wrap(err, "Failed to add new template")
+	}
+
+	return response.NewTemplateResponse(addedTemplate), nil
+}
+
+// AddTemplateFromTodos saves todos the user can view, together with their subtasks, as a template.
+// A selected todo that is already a subtask of another selected todo is only included once.
+func (templateService TemplateService) AddTemplateFromTodos(templateFromTodos request.TemplateFromTodos) (response.TemplateResponse, error) {
+	err := validateTemplateName(templateFromTodos.Name)
+	if err != nil {
+		return response.TemplateResponse{}, err
+	}
+
+	todoIds := uniqueTodoIds(templateFromTodos.TodoIds)
+	if len(todoIds) == 0 {
+		return response.TemplateResponse{}, errors.New("At least one todo id is required")
+	}
+
+	todoService := templateService.todoService
+	var todos []domain.Todo
+	subtasksByParentId := map[int][]domain.Todo{}
+	for _, todoId := range todoIds {
+		todo, err := todoService.todoRepository.GetTodoById(todoId)
+		if err != nil {
+			return response.TemplateResponse{}, err
+		}
+
+		err = todoService.todoAuthorizer.AuthorizeTodo(templateFromTodos.UserId, todo, domain.TodoAccessView)
+		if err != nil {
+			return response.TemplateResponse{}, err
+		}
+
+		todoSubtasksByParentId, err := todoService.getSubtasksByParentId(todoId)
+		if err != nil {
+			return response.TemplateResponse{}, err
+		}
+
+		todos = append(todos, todo)
+		for parentId, subtasks := range todoSubtasksByParentId {
+			subtasksByParentId[parentId] = subtasks
+		}
+	}
+
+	subtaskIds := map[int]bool{}
+	var earliestDueDate *time.Time
+	for _, todo := range todos {
+		earliestDueDate = earlierDueDate(earliestDueDate, todo.DueDate)
+	}
+	for _, subtasks := range subtasksByParentId {
+		for _, subtask := range subtasks {
+			subtaskIds[subtask.Id] = true
+			earliestDueDate = earlierDueDate(earliestDueDate, subtask.DueDate)
+		}
+	}
+
+	var items []domain.TemplateItem
+	for _, todo := range todos {
+		if !subtaskIds[todo.Id] {
+			items = append(items, newTemplateItem(templateFromTodos.UserId, todo, subtasksByParentId, earliestDueDate))
+		}
+	}
+
+	if domain.CountTemplateItems(items) > domain.MaxTemplateItemCount {
+		return response.TemplateResponse{}, errors.New(fmt.Sprintf("A template cannot have more than %d todos", domain.MaxTemplateItemCount))
+	}
+
+	addedTemplate, err := templateService.templateRepository.AddTemplate(domain.Template{
+		UserId:      templateFromTodos.UserId,
+		Name:        strings.TrimSpace(templateFromTodos.Name),
+		Description: templateFromTodos.Description,
+		Items:       items,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	})
+	if err != nil {
+		return response.TemplateResponse{}, errors.Wrap(err, "Failed to add new template")
+	}
+
+	return response.NewTemplateResponse(addedTemplate), nil
+}
+
+func (templateService TemplateService) UpdateTemplate(userId int, templateId int, templateUpdate request.TemplateUpdate) (response.TemplateResponse, error) {
+	items, err := templateService.validateTemplate(userId, templateUpdate.Name, templateUpdate.Items)
+	if err != nil {
+		return response.TemplateResponse{}, err
+	}
+
+	template, err := templateService.getOwnedTemplate(userId, templateId)
+	if err != nil {
+		return response.TemplateResponse{}, err
+	}
+
+	template.Name = strings.TrimSpace(templateUpdate.Name)
+	template.Description = templateUpdate.Description
+	template.Items = items
+	template.UpdatedAt = time.Now()
+
+	updatedTemplate, err := templateService.templateRepository.UpdateTemplate(template)
+	if err != nil {
+		return response.TemplateResponse{}, err
+	}
+
+	return response.NewTemplateResponse(updatedTemplate), nil
+}
+
+func (templateService TemplateService) DeleteTemplate(userId int, templateId int) error {
+	_, err := templateService.getOwnedTemplate(userId, templateId)
+	if err != nil {
+		return err
+	}
+
+	return templateService.templateRepository.DeleteTemplate(templateId)
+}
+
+// InstantiateTemplate creates the todos of a template in a single transaction, so that either all of
+// them are created or none. Due dates are the start date moved by the offset of each item, and tags the
+// owner of the new todos no longer has are left out.
+func (templateService TemplateService) InstantiateTemplate(userId int, templateId int, templateInstantiate request.TemplateInstantiate) ([]response.TodoResponse, error) {
+	template, err := templateService.getOwnedTemplate(userId, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := time.Now().Format(domain.DueDateLayout)
+	if templateInstantiate.StartDate != nil {
+		startDate = *templateInstantiate.StartDate
+	}
+
+	parsedStartDate, err := time.Parse(domain.DueDateLayout, startDate)
+	if err != nil {
+		return nil, errors.New("Start date must be in YYYY-MM-DD format")
+	}
+
+	ownerId := userId
+	if templateInstantiate.ProjectId != nil {
+		project, err := templateService.todoService.checkProjectAccess(userId, *templateInstantiate.ProjectId, domain.TodoAccessEdit)
+		if err != nil {
+			return nil, err
+		}
+		ownerId = project.UserId
+	}
+
+	workflow, err := getWorkflow(templateService.todoService.workflowRepository, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerTags, err := templateService.tagRepository.GetAllTagsByUserId(ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerTagIds := map[int]bool{}
+	for _, tag := range ownerTags {
+		ownerTagIds[tag.Id] = true
+	}
+
+	instance := templateInstance{
+		userId:      userId,
+		ownerId:     ownerId,
+		projectId:   templateInstantiate.ProjectId,
+		startDate:   parsedStartDate,
+		status:      workflow.CompletionStatus(false),
+		ownerTagIds: ownerTagIds,
+	}
+
+	var todoResponses []response.TodoResponse
+	err = templateService.todoService.todoRepository.WithTransaction(func(txTodoRepository persistence.ITodoRepository) error {
+		txTodoService := templateService.todoService
+		txTodoService.todoRepository = txTodoRepository
+
+		todoResponses = nil
+		for _, item := range template.Items {
+			todoResponse, err := instance.addTodo(txTodoService, item, nil)
+			if err != nil {
+				return err
+			}
+			todoResponses = append(todoResponses, todoResponse)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return todoResponses, nil
+}
+
+func (templateService TemplateService) getOwnedTemplate(userId int, templateId int) (domain.Template, error) {
+	template, err := templateService.templateRepository.GetTemplateById(templateId)
+	if err != nil {
+		return domain.Template{}, err
+	}
+
+	if template.UserId != userId {
+		return domain.Template{}, errors.New("This template is not belongs to you")
+	}
+
+	return template, nil
+}
+
+// validateTemplate checks the name and items of a template and returns the items to save. Tags must
+// belong to the user who saves the template.
+func (templateService TemplateService) validateTemplate(userId int, name string, items []request.TemplateItem) ([]domain.TemplateItem, error) {
+	err := validateTemplateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("A template needs at least one todo")
+	}
+
+	tags, err := templateService.tagRepository.GetAllTagsByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIds := map[int]bool{}
+	for _, tag := range tags {
+		tagIds[tag.Id] = true
+	}
+
+	templateItems, err := newTemplateItems(items, tagIds, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.CountTemplateItems(templateItems) > domain.MaxTemplateItemCount {
+		return nil, errors.New(fmt.Sprintf("A template cannot have more than %d todos", domain.MaxTemplateItemCount))
+	}
+
+	return templateItems, nil
+}
+
+// templateInstance holds what every todo created from one instantiation of a template shares.
+type templateInstance struct {
+	userId      int
+	ownerId     int
+	projectId   *int
+	startDate   time.Time
+	status      domain.WorkflowStatus
+	ownerTagIds map[int]bool
+}
+
+// addTodo creates the todo of an item below parentId, followed by its subtasks, and returns it as a tree.
+func (instance templateInstance) addTodo(todoService TodoService, item domain.TemplateItem, parentId *int) (response.TodoResponse, error) {
+	priority, err := parsePriority(item.Priority)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	var dueDate *time.Time
+	if item.DueOffsetDays != nil {
+		offsetDueDate := instance.startDate.AddDate(0, 0, *item.DueOffsetDays)
+		dueDate = &offsetDueDate
+	}
+
+	todo := domain.Todo{
+		UserId:          instance.ownerId,
+		ProjectId:       instance.projectId,
+		ParentId:        parentId,
+		Title:           item.Title,
+		Description:     item.Description,
+		IsCompleted:     instance.status.IsDone,
+		Status:          instance.status.Key,
+		Priority:        priority,
+		DueDate:         dueDate,
+		DueTime:         item.DueTime,
+		DueTimezone:     item.DueTimezone,
+		EstimateMinutes: item.EstimateMinutes,
+		OccurrenceIndex: 1,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	todo.SyncCompletion(instance.userId, todo.CreatedAt)
+
+	addedTodo, err := todoService.todoRepository.AddTodo(todo)
+	if err != nil {
+		return response.TodoResponse{}, errors.Wrap(err, "Failed to add new todo")
+	}
+
+	var tagIds []int
+	for _, tagId := range item.TagIds {
+		if instance.ownerTagIds[tagId] {
+			tagIds = append(tagIds, tagId)
+		}
+	}
+
+	if len(tagIds) > 0 {
+		err = todoService.todoRepository.AddTodoTags(addedTodo.Id, addedTodo.UserId, tagIds)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		addedTodo, err = todoService.todoRepository.GetTodoById(addedTodo.Id)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
+	err = todoService.recordRevision(instance.userId, domain.TodoActionCreated, domain.TodoSnapshot{}, addedTodo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	todoResponse := response.NewTodoResponse(addedTodo)
+	for _, subtask := range item.Subtasks {
+		subtaskResponse, err := instance.addTodo(todoService, subtask, &addedTodo.Id)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+		todoResponse.Subtasks = append(todoResponse.Subtasks, subtaskResponse)
+	}
+
+	return todoResponse, nil
+}
+
+func validateTemplateName(name string) error {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > maxTemplateNameLength {
+		return errors.New(fmt.Sprintf("Template name must be between 1 and %d characters long", maxTemplateNameLength))
+	}
+
+	return nil
+}
+
+// newTemplateItems validates items the way todos are validated when they are added, with tags limited
+// to tagIds, and converts them to template items.
+func newTemplateItems(items []request.TemplateItem, tagIds map[int]bool, depth int) ([]domain.TemplateItem, error) {
+	if depth > domain.MaxSubtaskDepth {
+		return nil, errors.New(fmt.Sprintf("Subtasks cannot be nested deeper than %d levels", domain.MaxSubtaskDepth))
+	}
+
+	templateItems := []domain.TemplateItem{}
+	for _, item := range items {
+		err := validateTodo(request.TodoCreate{Title: item.Title, Description: item.Description, EstimateMinutes: item.EstimateMinutes})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = parsePriority(item.Priority)
+		if err != nil {
+			return nil, err
+		}
+
+		var dueDate *string
+		if item.DueOffsetDays != nil {
+			if *item.DueOffsetDays < -maxTemplateDueOffsetDays || *item.DueOffsetDays > maxTemplateDueOffsetDays {
+				return nil, errors.New(fmt.Sprintf("Due offset must be between -%d and %d days", maxTemplateDueOffsetDays, maxTemplateDueOffsetDays))
+			}
+
+			startDate := time.Now().Format(domain.DueDateLayout)
+			dueDate = &startDate
+		}
+
+		_, err = parseDueDate(dueDate, item.DueTime, item.DueTimezone)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tagId := range item.TagIds {
+			if !tagIds[tagId] {
+				return nil, errors.New(fmt.Sprintf("Tag with id %d not found", tagId))
+			}
+		}
+
+		itemTagIds := []int{}
+		itemTagIds = append(itemTagIds, uniqueTodoIds(item.TagIds)...)
+
+		subtasks, err := newTemplateItems(item.Subtasks, tagIds, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		templateItems = append(templateItems, domain.TemplateItem{
+			Title:           item.Title,
+			Description:     item.Description,
+			Priority:        item.Priority,
+			DueOffsetDays:   item.DueOffsetDays,
+			DueTime:         item.DueTime,
+			DueTimezone:     item.DueTimezone,
+			EstimateMinutes: item.EstimateMinutes,
+			TagIds:          itemTagIds,
+			Subtasks:        subtasks,
+		})
+	}
+
+	return templateItems, nil
+}
+
+// newTemplateItem converts a todo and its subtasks to a template item. Due dates become offsets from
+// startDate, and tags are only kept on todos of the user since tags of other users cannot be reused.
+func newTemplateItem(userId int, todo domain.Todo, subtasksByParentId map[int][]domain.Todo, startDate *time.Time) domain.TemplateItem {
+	item := domain.TemplateItem{
+		Title:           todo.Title,
+		Description:     todo.Description,
+		Priority:        domain.PriorityNames[todo.Priority],
+		DueTime:         todo.DueTime,
+		DueTimezone:     todo.DueTimezone,
+		EstimateMinutes: todo.EstimateMinutes,
+		TagIds:          []int{},
+		Subtasks:        []domain.TemplateItem{},
+	}
+
+	if todo.DueDate != nil && startDate != nil {
+		dueOffsetDays := int(math.Round(todo.DueDate.Sub(*startDate).Hours() / 24))
+		item.DueOffsetDays = &dueOffsetDays
+	}
+
+	if todo.UserId == userId {
+		for _, tag := range todo.Tags {
+			item.TagIds = append(item.TagIds, tag.Id)
+		}
+	}
+
+	for _, subtask := range subtasksByParentId[todo.Id] {
+		item.Subtasks = append(item.Subtasks, newTemplateItem(userId, subtask, subtasksByParentId, startDate))
+	}
+
+	return item
+}
+
+func earlierDueDate(earliest *time.Time, dueDate *time.Time) *time.Time {
+	if dueDate == nil || (earliest != nil && !dueDate.Before(*earliest)) {
+		return earliest
+	}
+
+	return dueDate
+}
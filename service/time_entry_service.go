@@ -0,0 +1,352 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+const (
+	maxTimeEntryNoteLength = 1000
+	maxTimeEntryDuration   = 24 * time.Hour
+	defaultTimeReportDays  = 7
+	maxTimeReportDays      = 366
+)
+
+type ITimeEntryService interface {
+	GetTimeEntries(userId int, todoId int) ([]response.TimeEntryResponse, error)
+	GetRunningTimer(userId int) (*response.TimeEntryResponse, error)
+	StartTimer(userId int, todoId int) (response.TimeEntryResponse, error)
+	StopTimer(userId int, todoId int) (response.TimeEntryResponse, error)
+	AddTimeEntry(userId int, todoId int, timeEntryCreate request.TimeEntryCreate) (response.TimeEntryResponse, error)
+	DeleteTimeEntry(userId int, todoId int, timeEntryId int) error
+	GetTodoTimeReport(userId int, todoId int) (response.TodoTimeReportResponse, error)
+	GetDailyTimeReport(userId int, reportFilter request.TimeReportFilter) ([]response.DailyTimeReportResponse, error)
+}
+
+type TimeEntryService struct {
+	timeEntryRepository persistence.ITimeEntryRepository
+	todoRepository      persistence.ITodoRepository
+	todoAuthorizer      ITodoAuthorizer
+}
+
+func NewTimeEntryService(timeEntryRepository persistence.ITimeEntryRepository, todoRepository persistence.ITodoRepository, shareRepository persistence.IShareRepository) ITimeEntryService {
+	return &TimeEntryService{
+		timeEntryRepository: timeEntryRepository,
+		todoRepository:      todoRepository,
+		todoAuthorizer:      NewTodoAuthorizer(todoRepository, shareRepository),
+	}
+}
+
+func (timeEntryService TimeEntryService) GetTimeEntries(userId int, todoId int) ([]response.TimeEntryResponse, error) {
+	_, err := timeEntryService.checkTodoAccess(userId, todoId, domain.TodoAccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	timeEntries, err := timeEntryService.timeEntryRepository.GetTimeEntriesByTodoId(todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.NewTimeEntryResponses(timeEntries, time.Now()), nil
+}
+
+// GetRunningTimer returns the timer the user is running, or nil when none is running.
+func (timeEntryService TimeEntryService) GetRunningTimer(userId int) (*response.TimeEntryResponse, error) {
+	runningTimeEntry, err := timeEntryService.timeEntryRepository.GetRunningTimeEntry(userId)
+	if err != nil || runningTimeEntry == nil {
+		return nil, err
+	}
+
+	timeEntryResponse := response.NewTimeEntryResponse(*runningTimeEntry, time.Now())
+	return &timeEntryResponse, nil
+}
+
+// StartTimer starts tracking time on a todo the user can edit. A user runs at most one timer at a time,
+// so a running timer has to be stopped first.
+func (timeEntryService TimeEntryService) StartTimer(userId int, todoId int) (response.TimeEntryResponse, error) {
+	_, err := timeEntryService.checkTodoAccess(userId, todoId, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TimeEntryResponse{}, err
+	}
+
+	runningTimeEntry, err := timeEntryService.timeEntryRepository.GetRunningTimeEntry(userId)
+	if err != nil {
+		return response.TimeEntryResponse{}, err
+	}
+
+	if runningTimeEntry != nil {
+		return response.TimeEntryResponse{}, errors.New(fmt.Sprintf("A timer is already running on todo %d, stop it first", runningTimeEntry.TodoId))
+	}
+
+	now := time.Now()
+	addedTimeEntry, err := timeEntryService.timeEntryRepository.AddTimeEntry(domain.TimeEntry{
+		TodoId:    todoId,
+		UserId:    userId,
+		StartedAt: now,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return response.TimeEntryResponse{}, errors.Wrap(err, "Failed to start timer")
+	}
+
+	return response.NewTimeEntryResponse(addedTimeEntry, now), nil
+}
+
+func (timeEntryService TimeEntryService) StopTimer(userId int, todoId int) (response.TimeEntryResponse, error) {
+	_, err := timeEntryService.checkTodoAccess(userId, todoId, domain.TodoAccessView)
+	if err != nil {
+		return response.TimeEntryResponse{}, err
+	}
+
+	runningTimeEntry, err := timeEntryService.timeEntryRepository.GetRunningTimeEntry(userId)
+	if err != nil {
+		return response.TimeEntryResponse{}, err
+	}
+
+	if runningTimeEntry == nil || runningTimeEntry.TodoId != todoId {
+		return response.TimeEntryResponse{}, errors.New("No timer is running on this todo")
+	}
+
+	now := time.Now()
+	stoppedTimeEntry, err := timeEntryService.timeEntryRepository.StopTimeEntry(runningTimeEntry.Id, now)
+	if err != nil {
+		return response.TimeEntryResponse{}, err
+	}
+
+	return response.NewTimeEntryResponse(stoppedTimeEntry, now), nil
+}
+
+// AddTimeEntry records time the user spent on a todo without running a timer.
+func (timeEntryService TimeEntryService) AddTimeEntry(userId int, todoId int, timeEntryCreate request.TimeEntryCreate) (response.TimeEntryResponse, error) {
+	now := time.Now()
+	validationError := validateTimeEntry(timeEntryCreate, now)
+	if validationError != nil {
+		return response.TimeEntryResponse{}, validationError
+	}
+
+	_, err := timeEntryService.checkTodoAccess(userId, todoId, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TimeEntryResponse{}, err
+	}
+
+	endedAt := timeEntryCreate.EndedAt
+	addedTimeEntry, err := timeEntryService.timeEntryRepository.AddTimeEntry(domain.TimeEntry{
+		TodoId:    todoId,
+		UserId:    userId,
+		StartedAt: timeEntryCreate.StartedAt,
+		EndedAt:   &endedAt,
+		Note:      strings.TrimSpace(timeEntryCreate.Note),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return response.TimeEntryResponse{}, errors.Wrap(err, "Failed to add time entry")
+	}
+
+	return response.NewTimeEntryResponse(addedTimeEntry, now), nil
+}
+
+// DeleteTimeEntry deletes a time entry of the todo. Only the user who tracked the time can delete it.
+func (timeEntryService TimeEntryService) DeleteTimeEntry(userId int, todoId int, timeEntryId int) error {
+	_, err := timeEntryService.checkTodoAccess(userId, todoId, domain.TodoAccessView)
+	if err != nil {
+		return err
+	}
+
+	timeEntry, err := timeEntryService.timeEntryRepository.GetTimeEntryById(timeEntryId)
+	if err != nil {
+		return err
+	}
+
+	if timeEntry.TodoId != todoId {
+		return errors.New(fmt.Sprintf("Time entry with id %d not found", timeEntryId))
+	}
+
+	if timeEntry.UserId != userId {
+		return errors.New("This time entry is not belongs to you")
+	}
+
+	return timeEntryService.timeEntryRepository.DeleteTimeEntry(timeEntryId)
+}
+
+// GetTodoTimeReport sums the time everyone tracked on a todo, including running timers, and compares
+// it with the estimate of the todo.
+func (timeEntryService TimeEntryService) GetTodoTimeReport(userId int, todoId int) (response.TodoTimeReportResponse, error) {
+	todo, err := timeEntryService.checkTodoAccess(userId, todoId, domain.TodoAccessView)
+	if err != nil {
+		return response.TodoTimeReportResponse{}, err
+	}
+
+	timeEntries, err := timeEntryService.timeEntryRepository.GetTimeEntriesByTodoId(todoId)
+	if err != nil {
+		return response.TodoTimeReportResponse{}, err
+	}
+
+	now := time.Now()
+	var tracked time.Duration
+	var trackerIds []int
+	trackedByUserId := map[int]time.Duration{}
+	for _, timeEntry := range timeEntries {
+		if _, found := trackedByUserId[timeEntry.UserId]; !found {
+			trackerIds = append(trackerIds, timeEntry.UserId)
+		}
+		trackedByUserId[timeEntry.UserId] += timeEntry.Duration(now)
+		tracked += timeEntry.Duration(now)
+	}
+
+	userTimes := make([]response.UserTimeResponse, 0, len(trackerIds))
+	for _, trackerId := range trackerIds {
+		userTimes = append(userTimes, response.UserTimeResponse{UserId: trackerId, TrackedMinutes: minutes(trackedByUserId[trackerId])})
+	}
+
+	var remainingMinutes *int
+	if todo.EstimateMinutes != nil {
+		remaining := *todo.EstimateMinutes - minutes(tracked)
+		remainingMinutes = &remaining
+	}
+
+	return response.TodoTimeReportResponse{
+		TodoId:           todoId,
+		EstimateMinutes:  todo.EstimateMinutes,
+		TrackedMinutes:   minutes(tracked),
+		RemainingMinutes: remainingMinutes,
+		Users:            userTimes,
+	}, nil
+}
+
+// GetDailyTimeReport returns the time the user tracked on each day of the period, per todo and next to
+// the estimate of each todo. Days are calendar days in the requested timezone, and entries that span
+// midnight are split between the days they cover. The period defaults to the last seven days.
+func (timeEntryService TimeEntryService) GetDailyTimeReport(userId int, reportFilter request.TimeReportFilter) ([]response.DailyTimeReportResponse, error) {
+	if reportFilter.Timezone == "" {
+		reportFilter.Timezone = "UTC"
+	}
+
+	location, err := time.LoadLocation(reportFilter.Timezone)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Unknown timezone %s", reportFilter.Timezone))
+	}
+
+	now := time.Now().In(location)
+	lastDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	if reportFilter.To != nil {
+		lastDay = time.Date(reportFilter.To.Year(), reportFilter.To.Month(), reportFilter.To.Day(), 0, 0, 0, 0, location)
+	}
+
+	firstDay := lastDay.AddDate(0, 0, 1-defaultTimeReportDays)
+	if reportFilter.From != nil {
+		firstDay = time.Date(reportFilter.From.Year(), reportFilter.From.Month(), reportFilter.From.Day(), 0, 0, 0, 0, location)
+	}
+
+	if firstDay.After(lastDay) {
+		return nil, errors.New("From must not be after to")
+	}
+
+	if firstDay.AddDate(0, 0, maxTimeReportDays).Before(lastDay.AddDate(0, 0, 1)) {
+		return nil, errors.New(fmt.Sprintf("Time report can cover at most %d days", maxTimeReportDays))
+	}
+
+	timeEntries, err := timeEntryService.timeEntryRepository.GetTimeEntriesByUserId(userId, firstDay, lastDay.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	todosById := map[int]domain.Todo{}
+	for _, timeEntry := range timeEntries {
+		if _, found := todosById[timeEntry.TodoId]; found {
+			continue
+		}
+
+		todo, err := timeEntryService.todoRepository.GetTodoById(timeEntry.TodoId)
+		if err != nil {
+			todo, err = timeEntryService.todoRepository.GetTrashedTodoById(timeEntry.TodoId)
+			if err != nil {
+				return nil, err
+			}
+		}
+		todosById[todo.Id] = todo
+	}
+
+	var dailyReports []response.DailyTimeReportResponse
+	for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		if dayEnd.After(now) {
+			dayEnd = now
+		}
+
+		var dayTracked time.Duration
+		var trackedTodoIds []int
+		trackedByTodoId := map[int]time.Duration{}
+		for _, timeEntry := range timeEntries {
+			tracked := timeEntry.DurationBetween(day, dayEnd)
+			if tracked == 0 {
+				continue
+			}
+
+			if _, found := trackedByTodoId[timeEntry.TodoId]; !found {
+				trackedTodoIds = append(trackedTodoIds, timeEntry.TodoId)
+			}
+			trackedByTodoId[timeEntry.TodoId] += tracked
+			dayTracked += tracked
+		}
+
+		todoTimes := make([]response.DailyTodoTimeResponse, 0, len(trackedTodoIds))
+		for _, todoId := range trackedTodoIds {
+			todo := todosById[todoId]
+			todoTimes = append(todoTimes, response.DailyTodoTimeResponse{
+				TodoId:          todoId,
+				Title:           todo.Title,
+				TrackedMinutes:  minutes(trackedByTodoId[todoId]),
+				EstimateMinutes: todo.EstimateMinutes,
+			})
+		}
+
+		dailyReports = append(dailyReports, response.DailyTimeReportResponse{
+			Date:           day.Format(domain.DueDateLayout),
+			TrackedMinutes: minutes(dayTracked),
+			Todos:          todoTimes,
+		})
+	}
+
+	return dailyReports, nil
+}
+
+func (timeEntryService TimeEntryService) checkTodoAccess(userId int, todoId int, access domain.TodoAccess) (domain.Todo, error) {
+	todo, err := timeEntryService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	err = timeEntryService.todoAuthorizer.AuthorizeTodo(userId, todo, access)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	return todo, nil
+}
+
+func validateTimeEntry(timeEntryCreate request.TimeEntryCreate, now time.Time) error {
+	if timeEntryCreate.StartedAt.IsZero() || timeEntryCreate.EndedAt.IsZero() {
+		return errors.New("Time entry must have a start and an end")
+	} else if !timeEntryCreate.EndedAt.After(timeEntryCreate.StartedAt) {
+		return errors.New("Time entry must end after it starts")
+	} else if timeEntryCreate.EndedAt.After(now) {
+		return errors.New("Time entry cannot end in the future")
+	} else if timeEntryCreate.EndedAt.Sub(timeEntryCreate.StartedAt) > maxTimeEntryDuration {
+		return errors.New(fmt.Sprintf("Time entry can be at most %d hours long", int(maxTimeEntryDuration/time.Hour)))
+	} else if len(strings.TrimSpace(timeEntryCreate.Note)) > maxTimeEntryNoteLength {
+		return errors.New(fmt.Sprintf("Note must be at most %d characters long", maxTimeEntryNoteLength))
+	}
+
+	return nil
+}
+
+func minutes(duration time.Duration) int {
+	return int(duration / time.Minute)
+}
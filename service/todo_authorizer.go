@@ -0,0 +1,123 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+// ITodoAuthorizer decides what a user may do with a todo. Owners may do anything, other users only
+// what the shares of the todo, one of its parents or its project allow.
+type ITodoAuthorizer interface {
+	AuthorizeTodo(userId int, todo domain.Todo, access domain.TodoAccess) error
+	AuthorizeProject(userId int, project domain.Project, access domain.TodoAccess) error
+	GetSharedIds(userId int) ([]int, []int, error)
+}
+
+type TodoAuthorizer struct {
+	todoRepository  persistence.ITodoRepository
+	shareRepository persistence.IShareRepository
+}
+
+func NewTodoAuthorizer(todoRepository persistence.ITodoRepository, shareRepository persistence.IShareRepository) ITodoAuthorizer {
+	return &TodoAuthorizer{
+		todoRepository:  todoRepository,
+		shareRepository: shareRepository,
+	}
+}
+
+func (todoAuthorizer TodoAuthorizer) AuthorizeTodo(userId int, todo domain.Todo, access domain.TodoAccess) error {
+	todoAccess, err := todoAuthorizer.getTodoAccess(userId, todo)
+	if err != nil {
+		return err
+	}
+
+	if todoAccess >= access {
+		return nil
+	} else if todoAccess == domain.TodoAccessNone {
+		return errors.New("This todo is not belongs to you")
+	} else if access == domain.TodoAccessOwner {
+		return errors.New("Only the owner of this todo can do this")
+	}
+
+	return errors.New("This todo is only shared with you for viewing")
+}
+
+func (todoAuthorizer TodoAuthorizer) AuthorizeProject(userId int, project domain.Project, access domain.TodoAccess) error {
+	projectAccess := domain.TodoAccessOwner
+	if project.UserId != userId {
+		shares, err := todoAuthorizer.shareRepository.GetSharesByUserId(userId)
+		if err != nil {
+			return err
+		}
+
+		projectAccess = domain.TodoAccessNone
+		for _, share := range shares {
+			if share.ProjectId != nil && *share.ProjectId == project.Id {
+				projectAccess = max(projectAccess, share.Access())
+			}
+		}
+	}
+
+	if projectAccess >= access {
+		return nil
+	} else if projectAccess == domain.TodoAccessNone {
+		return errors.New("This project is not belongs to you")
+	} else if access == domain.TodoAccessOwner {
+		return errors.New("Only the owner of this project can do this")
+	}
+
+	return errors.New("This project is only shared with you for viewing")
+}
+
+// GetSharedIds returns the ids of the todos and of the projects other users have shared with the user.
+func (todoAuthorizer TodoAuthorizer) GetSharedIds(userId int) ([]int, []int, error) {
+	shares, err := todoAuthorizer.shareRepository.GetSharesByUserId(userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todoIds, projectIds := []int{}, []int{}
+	for _, share := range shares {
+		if share.TodoId != nil {
+			todoIds = append(todoIds, *share.TodoId)
+		} else if share.ProjectId != nil {
+			projectIds = append(projectIds, *share.ProjectId)
+		}
+	}
+
+	return todoIds, projectIds, nil
+}
+
+// getTodoAccess returns the highest access any share grants the user. Sharing a todo shares its
+// subtasks too, so the shares of its parents count as well.
+func (todoAuthorizer TodoAuthorizer) getTodoAccess(userId int, todo domain.Todo) (domain.TodoAccess, error) {
+	if todo.UserId == userId {
+		return domain.TodoAccessOwner, nil
+	}
+
+	shares, err := todoAuthorizer.shareRepository.GetSharesByUserId(userId)
+	if err != nil || len(shares) == 0 {
+		return domain.TodoAccessNone, err
+	}
+
+	access := domain.TodoAccessNone
+	for depth := 0; ; depth++ {
+		for _, share := range shares {
+			sharesTodo := share.TodoId != nil && *share.TodoId == todo.Id
+			sharesProject := share.ProjectId != nil && todo.ProjectId != nil && *share.ProjectId == *todo.ProjectId
+			if sharesTodo || sharesProject {
+				access = max(access, share.Access())
+			}
+		}
+
+		if todo.ParentId == nil || depth >= domain.MaxSubtaskDepth {
+			return access, nil
+		}
+
+		todo, err = todoAuthorizer.todoRepository.GetTodoById(*todo.ParentId)
+		if err != nil {
+			return domain.TodoAccessNone, err
+		}
+	}
+}
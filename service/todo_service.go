@@ -1,8 +1,12 @@
 package service
 
 import (
+	"fmt"
 	"github.com/pkg/errors"
+	"strconv"
+	"strings"
 	"time"
+	"todo-app--go-gin/common/util/recurrence"
 	"todo-app--go-gin/domain"
 	"todo-app--go-gin/domain/request"
 	"todo-app--go-gin/domain/response"
@@ -11,41 +15,269 @@ import (
 
 type ITodoService interface {
 	GetAllTodos(userId int) ([]response.TodoResponse, error)
+	GetTodosByFilter(userId int, todoFilter request.TodoFilter) (response.TodoPageResponse, error)
+	GetAssignedTodos(userId int, todoFilter request.TodoFilter) (response.TodoPageResponse, error)
+	GetTodosByDueView(userId int, dueView string, dueViewFilter request.DueViewFilter) (response.TodoPageResponse, error)
+	SearchTodos(userId int, searchFilter request.TodoSearchFilter) (response.TodoSearchPageResponse, error)
 	GetTodoById(userId int, todoId int) (response.TodoResponse, error)
 	AddTodo(todoCreate request.TodoCreate) (response.TodoResponse, error)
 	UpdateTodo(todoId int, todoUpdate request.TodoUpdate) (response.TodoResponse, error)
-	ToggleTodo(userId int, todoId int) (response.TodoResponse, error)
+	PatchTodo(userId int, todoId int, todoPatch request.TodoPatch) (response.TodoResponse, error)
+	ToggleTodo(userId int, todoId int, todoToggle request.TodoToggle) (response.TodoResponse, error)
+	ChangeTodoStatus(userId int, todoId int, todoStatusChange request.TodoStatusChange) (response.TodoResponse, error)
+	AssignTodo(userId int, todoId int, todoAssign request.TodoAssign) (response.TodoResponse, error)
+	GetTodoHistory(userId int, todoId int, historyFilter request.TodoHistoryFilter) (response.TodoHistoryPageResponse, error)
+	RevertTodo(userId int, todoId int, todoRevert request.TodoRevert) (response.TodoResponse, error)
+	GetTodoDependencies(userId int, todoId int) ([]response.TodoResponse, error)
+	AddTodoDependency(userId int, todoId int, todoDependency request.TodoDependency) (response.TodoResponse, error)
+	RemoveTodoDependency(userId int, todoId int, dependsOnId int) error
+	GetBoard(userId int, todoFilter request.TodoFilter) (response.TodoBoardResponse, error)
+	MoveTodoToProject(userId int, todoId int, todoMove request.TodoMove) (response.TodoResponse, error)
+	GetSubtasks(userId int, todoId int) ([]response.TodoResponse, error)
+	ReparentTodo(userId int, todoId int, todoReparent request.TodoReparent) (response.TodoResponse, error)
+	MoveTodo(userId int, todoId int, todoPosition request.TodoPosition) (response.TodoResponse, error)
+	GetOccurrences(userId int, todoId int, count int) ([]response.OccurrenceResponse, error)
 	DeleteTodo(userId int, todoId int) error
+	DeleteTodoWithVersion(userId int, todoId int, expectedVersion *int) error
+	GetTrash(userId int) ([]response.TodoResponse, error)
+	RestoreTodo(userId int, todoId int) (response.TodoResponse, error)
+	PermanentlyDeleteTodo(userId int, todoId int) error
+	PurgeTrash(retentionPeriod time.Duration) (int64, error)
+	BulkUpdateTodos(userId int, todoBulk request.TodoBulk) (response.TodoBulkResponse, error)
 }
 
+const (
+	defaultTodoPageLimit = 20
+	maxTodoPageLimit     = 100
+	defaultUpcomingDays  = 7
+	maxUpcomingDays      = 365
+	maxOccurrenceCount   = 100
+	maxBulkTodoCount     = 100
+	maxHistoryPageLimit  = 100
+	maxEstimateMinutes   = 60000
+)
+
+// ErrTodoVersionMismatch is returned when a conditional write finds that the todo was modified
+// after the client last read it.
+var ErrTodoVersionMismatch = errors.New("Todo has been modified since it was last read")
+
+// ValidationError reports a request that the service rejected because of its input rather than because
+// a dependency failed.
+type ValidationError struct {
+	message string
+}
+
+func newValidationError(message string) error {
+	return ValidationError{message: message}
+}
+
+func (validationError ValidationError) Error() string {
+	return validationError.message
+}
+
+// errBulkRolledBack aborts the transaction of an all-or-nothing bulk operation in which an item failed.
+var errBulkRolledBack = errors.New("Bulk operation rolled back")
+
 type TodoService struct {
-	todoRepository persistence.ITodoRepository
+	todoRepository     persistence.ITodoRepository
+	projectRepository  persistence.IProjectRepository
+	workflowRepository persistence.IWorkflowRepository
+	todoAuthorizer     ITodoAuthorizer
 }
 
-func NewTodoService(todoRepository persistence.ITodoRepository) ITodoService {
-	return &TodoService{todoRepository: todoRepository}
+func NewTodoService(todoRepository persistence.ITodoRepository, projectRepository persistence.IProjectRepository, workflowRepository persistence.IWorkflowRepository, shareRepository persistence.IShareRepository) ITodoService {
+	return &TodoService{
+		todoRepository:     todoRepository,
+		projectRepository:  projectRepository,
+		workflowRepository: workflowRepository,
+		todoAuthorizer:     NewTodoAuthorizer(todoRepository, shareRepository),
+	}
 }
 
+// GetAllTodos returns the todos of the user followed by the todos other users have shared with them.
 func (todoService TodoService) GetAllTodos(userId int) ([]response.TodoResponse, error) {
 	todos, err := todoService.todoRepository.GetAllTodosByUserId(userId)
 	if err != nil {
 		return nil, err
 	}
 
+	sharedTodoIds, sharedProjectIds, err := todoService.todoAuthorizer.GetSharedIds(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sharedTodoIds) > 0 || len(sharedProjectIds) > 0 {
+		sharedTodos, err := todoService.todoRepository.GetSharedTodos(userId, sharedTodoIds, sharedProjectIds)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, sharedTodos...)
+	}
+
 	return convertTodosToResponses(todos), nil
 }
 
+func (todoService TodoService) GetTodosByFilter(userId int, todoFilter request.TodoFilter) (response.TodoPageResponse, error) {
+	todoQuery, validationError := newTodoQuery(userId, todoFilter)
+	if validationError != nil {
+		return response.TodoPageResponse{}, validationError
+	}
+
+	err := todoService.includeSharedTodos(&todoQuery, todoFilter.Owner)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	todos, total, err := todoService.todoRepository.GetTodosByQuery(todoQuery)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	return response.NewTodoPageResponse(convertTodosToResponses(todos), total, todoQuery.Limit, todoQuery.Offset), nil
+}
+
+// GetAssignedTodos returns the todos assigned to the user, whether they own them or they were shared with them.
+func (todoService TodoService) GetAssignedTodos(userId int, todoFilter request.TodoFilter) (response.TodoPageResponse, error) {
+	todoFilter.Assignee = domain.TodoAssigneeMe
+	return todoService.GetTodosByFilter(userId, todoFilter)
+}
+
+// GetBoard returns a column for every status of the workflow, each holding a page of the todos that
+// match the filter and are in that status. Shared todos follow the workflow of their owner and are
+// therefore left out.
+func (todoService TodoService) GetBoard(userId int, todoFilter request.TodoFilter) (response.TodoBoardResponse, error) {
+	todoQuery, validationError := newTodoQuery(userId, todoFilter)
+	if validationError != nil {
+		return response.TodoBoardResponse{}, validationError
+	}
+
+	workflow, err := getWorkflow(todoService.workflowRepository, userId)
+	if err != nil {
+		return response.TodoBoardResponse{}, err
+	}
+
+	board := response.TodoBoardResponse{Columns: make([]response.TodoBoardColumn, 0, len(workflow.Statuses))}
+	for _, status := range workflow.Statuses {
+		todoQuery.Status = status.Key
+		todos, total, err := todoService.todoRepository.GetTodosByQuery(todoQuery)
+		if err != nil {
+			return response.TodoBoardResponse{}, err
+		}
+
+		board.Columns = append(board.Columns, response.TodoBoardColumn{
+			WorkflowStatusResponse: response.NewWorkflowStatusResponse(status),
+			Todos:                  convertTodosToResponses(todos),
+			Total:                  total,
+		})
+	}
+
+	return board, nil
+}
+
+// SearchTodos ranks todos by relevance to the search query unless another sort is requested.
+func (todoService TodoService) SearchTodos(userId int, searchFilter request.TodoSearchFilter) (response.TodoSearchPageResponse, error) {
+	if strings.TrimSpace(searchFilter.Query) == "" {
+		return response.TodoSearchPageResponse{}, newValidationError("Search query cannot be empty")
+	}
+
+	sortByRelevance := searchFilter.Sort == "" || searchFilter.Sort == domain.TodoSortByRelevance
+	if sortByRelevance {
+		searchFilter.Sort = ""
+	}
+
+	todoQuery, validationError := newTodoQuery(userId, searchFilter.TodoFilter)
+	if validationError != nil {
+		return response.TodoSearchPageResponse{}, validationError
+	}
+
+	err := todoService.includeSharedTodos(&todoQuery, searchFilter.Owner)
+	if err != nil {
+		return response.TodoSearchPageResponse{}, err
+	}
+
+	todoQuery.Search = searchFilter.Query
+	if sortByRelevance {
+		todoQuery.SortBy = domain.TodoSortByRelevance
+		if searchFilter.Order == "" {
+			todoQuery.SortDirection = domain.SortDirectionDesc
+		}
+	}
+
+	searchResults, total, err := todoService.todoRepository.SearchTodos(todoQuery)
+	if err != nil {
+		return response.TodoSearchPageResponse{}, err
+	}
+
+	return response.NewTodoSearchPageResponse(searchResults, total, todoQuery.Limit, todoQuery.Offset), nil
+}
+
+func (todoService TodoService) GetTodosByDueView(userId int, dueView string, dueViewFilter request.DueViewFilter) (response.TodoPageResponse, error) {
+	if dueViewFilter.Sort == "" {
+		dueViewFilter.Sort = domain.TodoSortByDueDate
+	}
+
+	todoQuery, validationError := newTodoQuery(userId, dueViewFilter.TodoFilter)
+	if validationError != nil {
+		return response.TodoPageResponse{}, validationError
+	}
+
+	location, err := time.LoadLocation(todoQuery.Timezone)
+	if err != nil {
+		return response.TodoPageResponse{}, newValidationError(fmt.Sprintf("Unknown timezone %s", todoQuery.Timezone))
+	}
+
+	err = todoService.includeSharedTodos(&todoQuery, dueViewFilter.Owner)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	isCompleted := false
+	todoQuery.IsCompleted = &isCompleted
+
+	switch dueView {
+	case domain.DueViewOverdue:
+		todoQuery.DueBefore = &now
+	case domain.DueViewToday:
+		todoQuery.DueFrom = &today
+		todoQuery.DueTo = &today
+	case domain.DueViewUpcoming:
+		days := dueViewFilter.Days
+		if days == 0 {
+			days = defaultUpcomingDays
+		}
+		if days < 0 || days > maxUpcomingDays {
+			return response.TodoPageResponse{}, newValidationError(fmt.Sprintf("Days must be between 1 and %d", maxUpcomingDays))
+		}
+		tomorrow := today.AddDate(0, 0, 1)
+		lastDay := today.AddDate(0, 0, days)
+		todoQuery.DueFrom = &tomorrow
+		todoQuery.DueTo = &lastDay
+	default:
+		return response.TodoPageResponse{}, newValidationError(fmt.Sprintf("Unsupported due view %s", dueView))
+	}
+
+	todos, total, err := todoService.todoRepository.GetTodosByQuery(todoQuery)
+	if err != nil {
+		return response.TodoPageResponse{}, err
+	}
+
+	return response.NewTodoPageResponse(convertTodosToResponses(todos), total, todoQuery.Limit, todoQuery.Offset), nil
+}
+
 func (todoService TodoService) GetTodoById(userId int, todoId int) (response.TodoResponse, error) {
 	todo, err := todoService.todoRepository.GetTodoById(todoId)
 	if err != nil {
 		return response.TodoResponse{}, err
 	}
 
-	if todo.UserId != userId {
-		return response.TodoResponse{}, errors.New("This todo is not belongs to you")
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessView)
+	if err != nil {
+		return response.TodoResponse{}, err
 	}
 
-	return response.NewTodoResponse(todo), nil
+	return todoService.buildTodoTree(todo)
 }
 
 func (todoService TodoService) AddTodo(todoCreate request.TodoCreate) (response.TodoResponse, error) {
@@ -54,101 +286,1917 @@ func (todoService TodoService) AddTodo(todoCreate request.TodoCreate) (response.
 		return response.TodoResponse{}, validationError
 	}
 
-	addedTodo, err := todoService.todoRepository.AddTodo(domain.Todo{
-		UserId:      todoCreate.UserId,
-		Title:       todoCreate.Title,
-		Description: todoCreate.Description,
-		IsCompleted: false,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	dueDate, err := parseDueDate(todoCreate.DueDate, todoCreate.DueTime, todoCreate.DueTimezone)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	recurrenceRule, err := parseRecurrenceRule(todoCreate.RecurrenceRule, dueDate)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	priority, err := parsePriority(todoCreate.Priority)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	// Todos added below a shared todo or to a shared project belong to the owner of that todo or project.
+	ownerId := todoCreate.UserId
+	if todoCreate.ParentId != nil {
+		parent, err := todoService.todoRepository.GetTodoById(*todoCreate.ParentId)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = todoService.todoAuthorizer.AuthorizeTodo(todoCreate.UserId, parent, domain.TodoAccessEdit)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		parentDepth, err := todoService.getTodoDepth(parent)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		if parentDepth+1 > domain.MaxSubtaskDepth {
+			return response.TodoResponse{}, errors.New(fmt.Sprintf("Subtasks cannot be nested deeper than %d levels", domain.MaxSubtaskDepth))
+		}
+
+		todoCreate.ProjectId = parent.ProjectId
+		ownerId = parent.UserId
+	} else if todoCreate.ProjectId != nil {
+		project, err := todoService.checkProjectAccess(todoCreate.UserId, *todoCreate.ProjectId, domain.TodoAccessEdit)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+		ownerId = project.UserId
+	}
+
+	workflow, err := getWorkflow(todoService.workflowRepository, ownerId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	status := workflow.CompletionStatus(false)
+	if todoCreate.Status != "" {
+		var found bool
+		status, found = workflow.Status(todoCreate.Status)
+		if !found {
+			return response.TodoResponse{}, errors.New(fmt.Sprintf("Unknown status %s", todoCreate.Status))
+		}
+	}
+
+	todo := domain.Todo{
+		UserId:          ownerId,
+		ProjectId:       todoCreate.ProjectId,
+		ParentId:        todoCreate.ParentId,
+		Title:           todoCreate.Title,
+		Description:     todoCreate.Description,
+		IsCompleted:     status.IsDone,
+		Status:          status.Key,
+		Priority:        priority,
+		DueDate:         dueDate,
+		DueTime:         todoCreate.DueTime,
+		DueTimezone:     todoCreate.DueTimezone,
+		RecurrenceRule:  recurrenceRule,
+		EstimateMinutes: todoCreate.EstimateMinutes,
+		OccurrenceIndex: 1,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	todo.SyncCompletion(todoCreate.UserId, todo.CreatedAt)
+
+	var addedTodo domain.Todo
+	err = todoService.withTransaction(func(txTodoService TodoService) error {
+		var err error
+		addedTodo, err = txTodoService.todoRepository.AddTodo(todo)
+		if err != nil {
+			return errors.Wrap(err, "Failed to add new todo")
+		}
+
+		if len(todoCreate.TagIds) > 0 {
+			err = txTodoService.todoRepository.AddTodoTags(addedTodo.Id, addedTodo.UserId, todoCreate.TagIds)
+			if err != nil {
+				return err
+			}
+
+			addedTodo, err = txTodoService.todoRepository.GetTodoById(addedTodo.Id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return txTodoService.recordRevision(todoCreate.UserId, domain.TodoActionCreated, domain.TodoSnapshot{}, addedTodo)
 	})
 	if err != nil {
-		return response.TodoResponse{}, errors.Wrap(err, "Failed to add new todo")
+		return response.TodoResponse{}, err
 	}
 
 	return response.NewTodoResponse(addedTodo), nil
 }
 
 func (todoService TodoService) UpdateTodo(todoId int, todoUpdate request.TodoUpdate) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withExpectedVersion(todoId, todoUpdate.ExpectedVersion, func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.updateTodo(todoUpdate.UserId, todoId, todoUpdate)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) updateTodo(userId int, todoId int, todoUpdate request.TodoUpdate) (response.TodoResponse, error) {
 	validationError := validateTodo(todoUpdate)
 	if validationError != nil {
 		return response.TodoResponse{}, validationError
 	}
 
+	dueDate, err := parseDueDate(todoUpdate.DueDate, todoUpdate.DueTime, todoUpdate.DueTimezone)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	recurrenceRule, err := parseRecurrenceRule(todoUpdate.RecurrenceRule, dueDate)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
 	todo, err := todoService.todoRepository.GetTodoById(todoId)
 	if err != nil {
 		return response.TodoResponse{}, err
 	}
 
-	if todo.UserId != todoUpdate.UserId {
-		return response.TodoResponse{}, errors.New("This todo is not belongs to you")
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TodoResponse{}, err
 	}
 
+	wasCompleted, previous := todo.IsCompleted, domain.NewTodoSnapshot(todo)
 	todo.UpdatedAt = time.Now()
 	todo.Title = todoUpdate.Title
 	todo.Description = todoUpdate.Description
 	todo.IsCompleted = todoUpdate.IsCompleted
+	todo.DueDate = dueDate
+	todo.DueTime = todoUpdate.DueTime
+	todo.DueTimezone = todoUpdate.DueTimezone
+	todo.RecurrenceRule = recurrenceRule
+	todo.EstimateMinutes = todoUpdate.EstimateMinutes
+	if todoUpdate.Priority != nil {
+		todo.Priority, err = parsePriority(*todoUpdate.Priority)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
+	workflow, err := getWorkflow(todoService.workflowRepository, todo.UserId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if todoUpdate.Status != nil {
+		err = setTodoStatus(workflow, &todo, *todoUpdate.Status)
+	} else {
+		err = syncTodoStatus(workflow, &todo)
+	}
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+	todo.SyncCompletion(userId, todo.UpdatedAt)
+
+	updatedTodo, err := todoService.todoRepository.UpdateTodo(todoId, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+	todo.Version = updatedTodo.Version
+
+	if len(todoUpdate.AttachTagIds) > 0 || len(todoUpdate.DetachTagIds) > 0 {
+		err = todoService.todoRepository.RemoveTodoTags(todoId, todoUpdate.DetachTagIds)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = todoService.todoRepository.AddTodoTags(todoId, todo.UserId, todoUpdate.AttachTagIds)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		todo, err = todoService.todoRepository.GetTodoById(todoId)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
 
-	_, err = todoService.todoRepository.UpdateTodo(todoId, todo)
+	err = todoService.recordRevision(userId, domain.TodoActionUpdated, previous, todo)
 	if err != nil {
 		return response.TodoResponse{}, err
 	}
 
+	if todo.IsCompleted && !wasCompleted {
+		err = todoService.completeParentsWhenSubtasksDone(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = todoService.scheduleNextOccurrence(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
 	return response.NewTodoResponse(todo), nil
 }
 
-func (todoService TodoService) ToggleTodo(userId int, todoId int) (response.TodoResponse, error) {
+// PatchTodo applies a JSON Merge Patch to a todo. Only the supplied fields are validated and only
+// the fields whose value actually changes are written.
+func (todoService TodoService) PatchTodo(userId int, todoId int, todoPatch request.TodoPatch) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withExpectedVersion(todoId, todoPatch.ExpectedVersion, func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.patchTodo(userId, todoId, todoPatch)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) patchTodo(userId int, todoId int, todoPatch request.TodoPatch) (response.TodoResponse, error) {
+	validationError := validateTodo(todoPatch)
+	if validationError != nil {
+		return response.TodoResponse{}, validationError
+	}
+
 	todo, err := todoService.todoRepository.GetTodoById(todoId)
 	if err != nil {
 		return response.TodoResponse{}, err
 	}
 
-	if todo.UserId != userId {
-		return response.TodoResponse{}, errors.New("This todo is not belongs to you")
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	var changedFields []string
+	wasCompleted, previousStatus, previous := todo.IsCompleted, todo.Status, domain.NewTodoSnapshot(todo)
+	if todoPatch.Title.Set && todoPatch.Title.Value != todo.Title {
+		todo.Title = todoPatch.Title.Value
+		changedFields = append(changedFields, domain.TodoFieldTitle)
+	}
+	if todoPatch.Description.Set && todoPatch.Description.Value != todo.Description {
+		todo.Description = todoPatch.Description.Value
+		changedFields = append(changedFields, domain.TodoFieldDescription)
 	}
+	if todoPatch.IsCompleted.Set || todoPatch.Status.Set {
+		workflow, err := getWorkflow(todoService.workflowRepository, todo.UserId)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
 
-	todo.IsCompleted = !todo.IsCompleted
+		if todoPatch.Status.Set {
+			err = setTodoStatus(workflow, &todo, todoPatch.Status.Value)
+		} else {
+			todo.IsCompleted = todoPatch.IsCompleted.Value
+			err = syncTodoStatus(workflow, &todo)
+		}
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		if todo.IsCompleted != wasCompleted {
+			todo.SyncCompletion(userId, time.Now())
+			changedFields = append(changedFields, domain.TodoFieldIsCompleted, domain.TodoFieldCompletedAt, domain.TodoFieldCompletedBy)
+		}
+		if todo.Status != previousStatus {
+			changedFields = append(changedFields, domain.TodoFieldStatus)
+		}
+	}
+	if todoPatch.Priority.Set {
+		priority, err := parsePriority(todoPatch.Priority.Value)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+		if priority != todo.Priority {
+			todo.Priority = priority
+			changedFields = append(changedFields, domain.TodoFieldPriority)
+		}
+	}
+
+	if todoPatch.EstimateMinutes.Set {
+		estimateMinutes := todoPatch.EstimateMinutes.Pointer()
+		if !equalInts(estimateMinutes, todo.EstimateMinutes) {
+			todo.EstimateMinutes = estimateMinutes
+			changedFields = append(changedFields, domain.TodoFieldEstimate)
+		}
+	}
+
+	if todoPatch.DueDate.Set || todoPatch.DueTime.Set || todoPatch.DueTimezone.Set || todoPatch.RecurrenceRule.Set {
+		var currentDueDate *string
+		if todo.DueDate != nil {
+			formattedDueDate := todo.DueDate.Format(domain.DueDateLayout)
+			currentDueDate = &formattedDueDate
+		}
+
+		dueTime := patchNullableString(todoPatch.DueTime, todo.DueTime)
+		dueTimezone := patchNullableString(todoPatch.DueTimezone, todo.DueTimezone)
+		dueDate, err := parseDueDate(patchNullableString(todoPatch.DueDate, currentDueDate), dueTime, dueTimezone)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		recurrenceRule, err := parseRecurrenceRule(patchNullableString(todoPatch.RecurrenceRule, todo.RecurrenceRule), dueDate)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		if !equalDates(dueDate, todo.DueDate) {
+			todo.DueDate = dueDate
+			changedFields = append(changedFields, domain.TodoFieldDueDate)
+		}
+		if !equalStrings(dueTime, todo.DueTime) {
+			todo.DueTime = dueTime
+			changedFields = append(changedFields, domain.TodoFieldDueTime)
+		}
+		if !equalStrings(dueTimezone, todo.DueTimezone) {
+			todo.DueTimezone = dueTimezone
+			changedFields = append(changedFields, domain.TodoFieldDueTimezone)
+		}
+		if !equalStrings(recurrenceRule, todo.RecurrenceRule) {
+			todo.RecurrenceRule = recurrenceRule
+			changedFields = append(changedFields, domain.TodoFieldRecurrenceRule)
+		}
+	}
+
+	if len(changedFields) == 0 {
+		return response.NewTodoResponse(todo), nil
+	}
 
-	_, err = todoService.todoRepository.UpdateTodo(todoId, todo)
+	todo.UpdatedAt = time.Now()
+	todo.Version, err = todoService.todoRepository.UpdateTodoFields(todoId, todo, changedFields)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.recordRevision(userId, domain.TodoActionUpdated, previous, todo)
 	if err != nil {
 		return response.TodoResponse{}, err
 	}
 
+	if todo.IsCompleted && !wasCompleted {
+		err = todoService.completeParentsWhenSubtasksDone(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = todoService.scheduleNextOccurrence(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
 	return response.NewTodoResponse(todo), nil
 }
 
-func (todoService TodoService) DeleteTodo(userId int, todoId int) error {
-	todo, err := todoService.GetTodoById(userId, todoId)
-	if err != nil {
+func (todoService TodoService) ToggleTodo(userId int, todoId int, todoToggle request.TodoToggle) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withExpectedVersion(todoId, todoToggle.ExpectedVersion, func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.toggleTodo(userId, todoId, todoToggle)
 		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) toggleTodo(userId int, todoId int, todoToggle request.TodoToggle) (response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
 	}
 
-	if todo.UserId != userId {
-		return errors.New("This todo is not belongs to you")
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TodoResponse{}, err
 	}
 
-	return todoService.todoRepository.DeleteTodo(todoId)
-}
+	workflow, err := getWorkflow(todoService.workflowRepository, todo.UserId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
 
-func validateTodo(todo interface{}) error {
-	switch t := todo.(type) {
-	case request.TodoCreate:
-		if len(t.Title) <= 3 {
-			return errors.New("Todo title must be at least 3 characters long")
-		} else if len(t.Description) <= 5 {
-			return errors.New("Todo description must be at least 5 characters long")
-		}
-	case request.TodoUpdate:
-		if len(t.Title) <= 3 {
-			return errors.New("Todo title must be at least 3 characters long")
-		} else if len(t.Description) <= 5 {
-			return errors.New("Todo description must be at least 5 characters long")
-		}
-	default:
-		return errors.New("Unsupported type")
+	previous := domain.NewTodoSnapshot(todo)
+	todo.IsCompleted = !todo.IsCompleted
+	err = syncTodoStatus(workflow, &todo)
+	if err != nil {
+		return response.TodoResponse{}, err
 	}
 
-	return nil
+	return todoService.saveTodoStatus(userId, domain.TodoActionToggled, previous, todo, todoToggle.CompleteSubtasks)
+}
+
+// ChangeTodoStatus moves a todo to another status of the workflow, completing or reopening it when
+// the status is done or not. Only the transitions the workflow allows are accepted.
+func (todoService TodoService) ChangeTodoStatus(userId int, todoId int, todoStatusChange request.TodoStatusChange) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withExpectedVersion(todoId, todoStatusChange.ExpectedVersion, func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.changeTodoStatus(userId, todoId, todoStatusChange)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) changeTodoStatus(userId int, todoId int, todoStatusChange request.TodoStatusChange) (response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	workflow, err := getWorkflow(todoService.workflowRepository, todo.UserId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	previous := domain.NewTodoSnapshot(todo)
+	err = setTodoStatus(workflow, &todo, todoStatusChange.Status)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return todoService.saveTodoStatus(userId, domain.TodoActionUpdated, previous, todo, false)
+}
+
+// AssignTodo assigns a todo to a user who can edit it, or unassigns it when no assignee is given.
+// The todo records who made the change and when.
+func (todoService TodoService) AssignTodo(userId int, todoId int, todoAssign request.TodoAssign) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withExpectedVersion(todoId, todoAssign.ExpectedVersion, func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.assignTodo(userId, todoId, todoAssign)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) assignTodo(userId int, todoId int, todoAssign request.TodoAssign) (response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if todoAssign.AssigneeId != nil {
+		err = todoService.todoAuthorizer.AuthorizeTodo(*todoAssign.AssigneeId, todo, domain.TodoAccessEdit)
+		if err != nil {
+			return response.TodoResponse{}, errors.New(fmt.Sprintf("User %d cannot edit this todo and cannot be assigned to it", *todoAssign.AssigneeId))
+		}
+	}
+
+	previous := domain.NewTodoSnapshot(todo)
+	now := time.Now()
+	todo.AssigneeId = todoAssign.AssigneeId
+	todo.AssignedBy = &userId
+	todo.AssignedAt = &now
+	todo.UpdatedAt = now
+	todo.Version, err = todoService.todoRepository.AssignTodo(todoId, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.recordRevision(userId, domain.TodoActionAssigned, previous, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return todoService.buildTodoTree(todo)
+}
+
+// GetTodoHistory returns a page of the revisions of a todo, newest first.
+func (todoService TodoService) GetTodoHistory(userId int, todoId int, historyFilter request.TodoHistoryFilter) (response.TodoHistoryPageResponse, error) {
+	if historyFilter.Limit == 0 {
+		historyFilter.Limit = defaultTodoPageLimit
+	}
+	if historyFilter.Limit < 0 || historyFilter.Limit > maxHistoryPageLimit {
+		return response.TodoHistoryPageResponse{}, errors.New(fmt.Sprintf("Limit must be between 1 and %d", maxHistoryPageLimit))
+	}
+	if historyFilter.Offset < 0 {
+		return response.TodoHistoryPageResponse{}, errors.New("Offset cannot be negative")
+	}
+
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoHistoryPageResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessView)
+	if err != nil {
+		return response.TodoHistoryPageResponse{}, err
+	}
+
+	revisions, total, err := todoService.todoRepository.GetTodoRevisions(todoId, historyFilter.Limit, historyFilter.Offset)
+	if err != nil {
+		return response.TodoHistoryPageResponse{}, err
+	}
+
+	return response.NewTodoHistoryPageResponse(revisions, total, historyFilter.Limit, historyFilter.Offset), nil
+}
+
+// RevertTodo sets the tracked fields of a todo back to their values at an earlier revision. The revert
+// is recorded as a revision of its own, so it can be undone the same way.
+func (todoService TodoService) RevertTodo(userId int, todoId int, todoRevert request.TodoRevert) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withExpectedVersion(todoId, todoRevert.ExpectedVersion, func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.revertTodo(userId, todoId, todoRevert)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) revertTodo(userId int, todoId int, todoRevert request.TodoRevert) (response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	revision, err := todoService.todoRepository.GetTodoRevisionById(todoRevert.RevisionId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if revision.TodoId != todoId {
+		return response.TodoResponse{}, errors.New(fmt.Sprintf("Revision with id %d not found", todoRevert.RevisionId))
+	}
+
+	snapshot := revision.Snapshot
+	dueDate, err := parseDueDate(snapshot.DueDate, snapshot.DueTime, snapshot.DueTimezone)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	priority, err := parsePriority(snapshot.Priority)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	wasCompleted, previous := todo.IsCompleted, domain.NewTodoSnapshot(todo)
+	todo.UpdatedAt = time.Now()
+	todo.Title = snapshot.Title
+	todo.Description = snapshot.Description
+	todo.Priority = priority
+	todo.DueDate = dueDate
+	todo.DueTime = snapshot.DueTime
+	todo.DueTimezone = snapshot.DueTimezone
+	todo.RecurrenceRule = snapshot.RecurrenceRule
+	todo.EstimateMinutes = snapshot.EstimateMinutes
+
+	if snapshot.Status != todo.Status {
+		workflow, err := getWorkflow(todoService.workflowRepository, todo.UserId)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = setTodoStatus(workflow, &todo, snapshot.Status)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+		todo.SyncCompletion(userId, todo.UpdatedAt)
+	}
+
+	updatedTodo, err := todoService.todoRepository.UpdateTodo(todoId, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+	todo.Version = updatedTodo.Version
+
+	err = todoService.recordRevision(userId, domain.TodoActionReverted, previous, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if todo.IsCompleted && !wasCompleted {
+		err = todoService.completeParentsWhenSubtasksDone(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = todoService.scheduleNextOccurrence(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
+	return todoService.buildTodoTree(todo)
+}
+
+// saveTodoStatus writes the status of a todo and records it in its history. When the todo is completed
+// it also completes its subtasks if asked to, completes the parents whose subtasks are now all done and
+// schedules the next occurrence.
+func (todoService TodoService) saveTodoStatus(userId int, action string, previous domain.TodoSnapshot, todo domain.Todo, completeSubtasks bool) (response.TodoResponse, error) {
+	todo.UpdatedAt = time.Now()
+	todo.SyncCompletion(userId, todo.UpdatedAt)
+
+	updatedTodo, err := todoService.todoRepository.UpdateTodo(todo.Id, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+	todo.Version = updatedTodo.Version
+
+	err = todoService.recordRevision(userId, action, previous, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if todo.IsCompleted {
+		if completeSubtasks {
+			subtasksByParentId, err := todoService.getSubtasksByParentId(todo.Id)
+			if err != nil {
+				return response.TodoResponse{}, err
+			}
+
+			var allSubtasks []domain.Todo
+			for _, subtasks := range subtasksByParentId {
+				allSubtasks = append(allSubtasks, subtasks...)
+			}
+
+			err = todoService.completeTodos(userId, todo.UserId, allSubtasks)
+			if err != nil {
+				return response.TodoResponse{}, err
+			}
+		}
+
+		err = todoService.completeParentsWhenSubtasksDone(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = todoService.scheduleNextOccurrence(userId, todo)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
+	return todoService.buildTodoTree(todo)
+}
+
+func (todoService TodoService) GetTodoDependencies(userId int, todoId int) ([]response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := todoService.todoRepository.GetTodoDependencies(todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTodosToResponses(dependencies), nil
+}
+
+// AddTodoDependency makes a todo wait for another todo of the same owner. Dependencies that would close
+// a cycle are rejected.
+func (todoService TodoService) AddTodoDependency(userId int, todoId int, todoDependency request.TodoDependency) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withTransaction(func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.addTodoDependency(userId, todoId, todoDependency)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) addTodoDependency(userId int, todoId int, todoDependency request.TodoDependency) (response.TodoResponse, error) {
+	if todoDependency.DependsOnId == todoId {
+		return response.TodoResponse{}, errors.New("A todo cannot depend on itself")
+	}
+
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	dependsOnTodo, err := todoService.todoRepository.GetTodoById(todoDependency.DependsOnId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, dependsOnTodo, domain.TodoAccessView)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if dependsOnTodo.UserId != todo.UserId {
+		return response.TodoResponse{}, errors.New("A todo can only depend on todos of the same owner")
+	}
+
+	err = todoService.todoRepository.LockTodoDependencies(todo.UserId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	hasCycle, err := todoService.todoRepository.HasDependencyPath(dependsOnTodo.Id, todo.Id)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if hasCycle {
+		return response.TodoResponse{}, errors.New(fmt.Sprintf("Todo %d already depends on todo %d, the dependency would create a cycle", dependsOnTodo.Id, todo.Id))
+	}
+
+	err = todoService.todoRepository.AddTodoDependency(todo.Id, dependsOnTodo.Id)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return todoService.GetTodoById(userId, todoId)
+}
+
+func (todoService TodoService) RemoveTodoDependency(userId int, todoId int, dependsOnId int) error {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessEdit)
+	if err != nil {
+		return err
+	}
+
+	return todoService.todoRepository.RemoveTodoDependency(todoId, dependsOnId)
+}
+
+func (todoService TodoService) MoveTodoToProject(userId int, todoId int, todoMove request.TodoMove) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withExpectedVersion(todoId, todoMove.ExpectedVersion, func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.moveTodoToProject(userId, todoId, todoMove)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) moveTodoToProject(userId int, todoId int, todoMove request.TodoMove) (response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessOwner)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if todoMove.ProjectId != nil {
+		_, err = todoService.checkProjectAccess(userId, *todoMove.ProjectId, domain.TodoAccessOwner)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
+	previous := domain.NewTodoSnapshot(todo)
+	err = todoService.todoRepository.MoveTodoToProject(todoId, todoMove.ProjectId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	todo.ProjectId = todoMove.ProjectId
+	err = todoService.recordRevision(userId, domain.TodoActionMoved, previous, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return todoService.GetTodoById(userId, todoId)
+}
+
+func (todoService TodoService) GetSubtasks(userId int, todoId int) ([]response.TodoResponse, error) {
+	todo, err := todoService.GetTodoById(userId, todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.Subtasks == nil {
+		return []response.TodoResponse{}, nil
+	}
+
+	return todo.Subtasks, nil
+}
+
+func (todoService TodoService) ReparentTodo(userId int, todoId int, todoReparent request.TodoReparent) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withTransaction(func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.reparentTodo(userId, todoId, todoReparent)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) reparentTodo(userId int, todoId int, todoReparent request.TodoReparent) (response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessOwner)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if todoReparent.ParentId != nil {
+		parent, err := todoService.todoRepository.GetTodoById(*todoReparent.ParentId)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		err = todoService.todoAuthorizer.AuthorizeTodo(userId, parent, domain.TodoAccessOwner)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		ancestor := parent
+		for depth := 0; ; depth++ {
+			if ancestor.Id == todo.Id {
+				return response.TodoResponse{}, errors.New("A todo cannot be moved under itself or one of its subtasks")
+			}
+			if ancestor.ParentId == nil || depth > domain.MaxSubtaskDepth {
+				break
+			}
+			ancestor, err = todoService.todoRepository.GetTodoById(*ancestor.ParentId)
+			if err != nil {
+				return response.TodoResponse{}, err
+			}
+		}
+
+		parentDepth, err := todoService.getTodoDepth(parent)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		subtasksByParentId, err := todoService.getSubtasksByParentId(todo.Id)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+
+		if parentDepth+1+subtreeHeight(todo.Id, subtasksByParentId) > domain.MaxSubtaskDepth {
+			return response.TodoResponse{}, errors.New(fmt.Sprintf("Subtasks cannot be nested deeper than %d levels", domain.MaxSubtaskDepth))
+		}
+	}
+
+	previous := domain.NewTodoSnapshot(todo)
+	err = todoService.todoRepository.SetTodoParent(todoId, todoReparent.ParentId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	todo.ParentId = todoReparent.ParentId
+	err = todoService.recordRevision(userId, domain.TodoActionMoved, previous, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return todoService.GetTodoById(userId, todoId)
+}
+
+func (todoService TodoService) MoveTodo(userId int, todoId int, todoPosition request.TodoPosition) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withTransaction(func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.moveTodo(userId, todoId, todoPosition)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) moveTodo(userId int, todoId int, todoPosition request.TodoPosition) (response.TodoResponse, error) {
+	if (todoPosition.BeforeId == nil) == (todoPosition.AfterId == nil) {
+		return response.TodoResponse{}, errors.New("Exactly one of beforeId and afterId is required")
+	}
+
+	anchorTodoId, before := todoPosition.AfterId, false
+	if todoPosition.BeforeId != nil {
+		anchorTodoId, before = todoPosition.BeforeId, true
+	}
+
+	if *anchorTodoId == todoId {
+		return response.TodoResponse{}, errors.New("A todo cannot be moved next to itself")
+	}
+
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	anchorTodo, err := todoService.todoRepository.GetTodoById(*anchorTodoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	// Positions order the todos of their owner, so only the owner can move them.
+	for _, movedTodo := range []domain.Todo{todo, anchorTodo} {
+		err = todoService.todoAuthorizer.AuthorizeTodo(userId, movedTodo, domain.TodoAccessOwner)
+		if err != nil {
+			return response.TodoResponse{}, err
+		}
+	}
+
+	position, err := todoService.positionNextTo(userId, todoId, anchorTodo, before)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	previous := domain.NewTodoSnapshot(todo)
+	err = todoService.todoRepository.SetTodoPosition(todoId, position)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	todo.Position = position
+	err = todoService.recordRevision(userId, domain.TodoActionMoved, previous, todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return todoService.GetTodoById(userId, todoId)
+}
+
+func (todoService TodoService) GetOccurrences(userId int, todoId int, count int) ([]response.OccurrenceResponse, error) {
+	if count < 1 || count > maxOccurrenceCount {
+		return nil, errors.New(fmt.Sprintf("Count must be between 1 and %d", maxOccurrenceCount))
+	}
+
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessView)
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.RecurrenceRule == nil || todo.DueDate == nil {
+		return nil, errors.New("This todo does not recur")
+	}
+
+	rule, err := recurrence.Parse(*todo.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrenceIndex := max(todo.OccurrenceIndex, 1)
+	if rule.Count > 0 {
+		rule.Count = rule.Count - occurrenceIndex + 1
+	}
+
+	occurrences := []response.OccurrenceResponse{}
+	for i, dueDate := range rule.Occurrences(*todo.DueDate, count+1) {
+		if i == 0 {
+			continue
+		}
+
+		occurrences = append(occurrences, response.OccurrenceResponse{
+			OccurrenceIndex: occurrenceIndex + i,
+			DueDate:         dueDate.Format(domain.DueDateLayout),
+			DueTime:         todo.DueTime,
+			DueTimezone:     todo.DueTimezone,
+		})
+	}
+
+	return occurrences, nil
+}
+
+func (todoService TodoService) DeleteTodo(userId int, todoId int) error {
+	return todoService.DeleteTodoWithVersion(userId, todoId, nil)
+}
+
+func (todoService TodoService) DeleteTodoWithVersion(userId int, todoId int, expectedVersion *int) error {
+	return todoService.withExpectedVersion(todoId, expectedVersion, func(txTodoService TodoService) error {
+		return txTodoService.deleteTodo(userId, todoId)
+	})
+}
+
+func (todoService TodoService) deleteTodo(userId int, todoId int) error {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	err = todoService.todoRepository.DeleteTodo(todoId)
+	if err != nil {
+		return err
+	}
+
+	return todoService.recordRevision(userId, domain.TodoActionDeleted, domain.NewTodoSnapshot(todo), todo)
+}
+
+func (todoService TodoService) GetTrash(userId int) ([]response.TodoResponse, error) {
+	todos, err := todoService.todoRepository.GetTrashedTodosByUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTodosToResponses(todos), nil
+}
+
+func (todoService TodoService) RestoreTodo(userId int, todoId int) (response.TodoResponse, error) {
+	var todoResponse response.TodoResponse
+	err := todoService.withTransaction(func(txTodoService TodoService) error {
+		var err error
+		todoResponse, err = txTodoService.restoreTodo(userId, todoId)
+		return err
+	})
+
+	return todoResponse, err
+}
+
+func (todoService TodoService) restoreTodo(userId int, todoId int) (response.TodoResponse, error) {
+	todo, err := todoService.todoRepository.GetTrashedTodoById(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessOwner)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	if todo.ParentId != nil {
+		if _, err := todoService.todoRepository.GetTodoById(*todo.ParentId); err != nil {
+			return response.TodoResponse{}, errors.New("The parent of this todo is in the trash, restore it first")
+		}
+	}
+
+	err = todoService.todoRepository.RestoreTodo(todoId)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	err = todoService.recordRevision(userId, domain.TodoActionRestored, domain.NewTodoSnapshot(todo), todo)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return todoService.GetTodoById(userId, todoId)
+}
+
+func (todoService TodoService) PermanentlyDeleteTodo(userId int, todoId int) error {
+	todo, err := todoService.todoRepository.GetTrashedTodoById(todoId)
+	if err != nil {
+		return err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, domain.TodoAccessOwner)
+	if err != nil {
+		return err
+	}
+
+	return todoService.todoRepository.PermanentlyDeleteTodo(todoId)
+}
+
+// PurgeTrash permanently deletes every todo that has been in the trash for longer than the retention period.
+func (todoService TodoService) PurgeTrash(retentionPeriod time.Duration) (int64, error) {
+	return todoService.todoRepository.PurgeTrashedTodos(time.Now().Add(-retentionPeriod))
+}
+
+// BulkUpdateTodos applies one action to every todo in a single transaction. Each todo runs in its
+// own savepoint, so a failed todo never leaves partial writes behind; in all-or-nothing mode any
+// failure rolls back the whole transaction instead.
+func (todoService TodoService) BulkUpdateTodos(userId int, todoBulk request.TodoBulk) (response.TodoBulkResponse, error) {
+	err := validateTodoBulk(todoBulk)
+	if err != nil {
+		return response.TodoBulkResponse{}, err
+	}
+
+	bulkResponse := response.TodoBulkResponse{Results: []response.TodoBulkItemResult{}}
+	err = todoService.todoRepository.WithTransaction(func(txTodoRepository persistence.ITodoRepository) error {
+		txTodoService := todoService
+		txTodoService.todoRepository = txTodoRepository
+
+		for _, todoId := range uniqueTodoIds(todoBulk.Ids) {
+			itemErr := txTodoRepository.WithTransaction(func(itemTodoRepository persistence.ITodoRepository) error {
+				itemTodoService := txTodoService
+				itemTodoService.todoRepository = itemTodoRepository
+				return itemTodoService.applyBulkAction(userId, todoId, todoBulk)
+			})
+
+			itemResult := response.TodoBulkItemResult{Id: todoId, Success: itemErr == nil}
+			if itemErr != nil {
+				itemResult.Error = itemErr.Error()
+				bulkResponse.Failed++
+			} else {
+				bulkResponse.Succeeded++
+			}
+			bulkResponse.Results = append(bulkResponse.Results, itemResult)
+		}
+
+		if todoBulk.AllOrNothing && bulkResponse.Failed > 0 {
+			return errBulkRolledBack
+		}
+
+		return nil
+	})
+
+	if err == errBulkRolledBack {
+		for i := range bulkResponse.Results {
+			if bulkResponse.Results[i].Success {
+				bulkResponse.Results[i].Success = false
+				bulkResponse.Results[i].Error = "Rolled back because another todo failed"
+			}
+		}
+		bulkResponse.Failed += bulkResponse.Succeeded
+		bulkResponse.Succeeded = 0
+		bulkResponse.RolledBack = true
+		return bulkResponse, nil
+	}
+	if err != nil {
+		return response.TodoBulkResponse{}, err
+	}
+
+	return bulkResponse, nil
+}
+
+func (todoService TodoService) applyBulkAction(userId int, todoId int, todoBulk request.TodoBulk) error {
+	todo, err := todoService.todoRepository.GetTodoById(todoId)
+	if err != nil {
+		return err
+	}
+
+	access := domain.TodoAccessEdit
+	if todoBulk.Action == domain.BulkActionDelete || todoBulk.Action == domain.BulkActionMove {
+		access = domain.TodoAccessOwner
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeTodo(userId, todo, access)
+	if err != nil {
+		return err
+	}
+
+	switch todoBulk.Action {
+	case domain.BulkActionComplete, domain.BulkActionUncomplete:
+		err = todoService.setTodoCompletion(userId, todo, todoBulk.Action == domain.BulkActionComplete)
+	case domain.BulkActionDelete:
+		err = todoService.deleteTodo(userId, todoId)
+	case domain.BulkActionMove:
+		_, err = todoService.moveTodoToProject(userId, todoId, request.TodoMove{ProjectId: todoBulk.ProjectId})
+	case domain.BulkActionAddTag:
+		err = todoService.addTodoTag(userId, todo, *todoBulk.TagId)
+	}
+
+	return err
+}
+
+// setTodoCompletion completes or reopens a todo, leaving it as it is when it is already in that state.
+func (todoService TodoService) setTodoCompletion(userId int, todo domain.Todo, isCompleted bool) error {
+	if todo.IsCompleted == isCompleted {
+		return nil
+	}
+
+	workflow, err := getWorkflow(todoService.workflowRepository, todo.UserId)
+	if err != nil {
+		return err
+	}
+
+	previous := domain.NewTodoSnapshot(todo)
+	todo.IsCompleted = isCompleted
+	err = syncTodoStatus(workflow, &todo)
+	if err != nil {
+		return err
+	}
+
+	_, err = todoService.saveTodoStatus(userId, domain.TodoActionToggled, previous, todo, false)
+	return err
+}
+
+func (todoService TodoService) addTodoTag(userId int, todo domain.Todo, tagId int) error {
+	err := todoService.todoRepository.AddTodoTags(todo.Id, todo.UserId, []int{tagId})
+	if err != nil {
+		return err
+	}
+
+	taggedTodo, err := todoService.todoRepository.GetTodoById(todo.Id)
+	if err != nil {
+		return err
+	}
+
+	return todoService.recordRevision(userId, domain.TodoActionUpdated, domain.NewTodoSnapshot(todo), taggedTodo)
+}
+
+// positionNextTo returns a position directly before or after the anchor todo, halfway to its neighbour.
+// Only when repeated moves have used up the gap between the two are the user's positions renumbered.
+func (todoService TodoService) positionNextTo(userId int, todoId int, anchorTodo domain.Todo, before bool) (float64, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		neighbourPosition, err := todoService.todoRepository.GetNeighbourPosition(userId, todoId, anchorTodo.Id, before)
+		if err != nil {
+			return 0, err
+		}
+
+		if neighbourPosition == nil {
+			if before {
+				return anchorTodo.Position - domain.TodoPositionGap, nil
+			}
+			return anchorTodo.Position + domain.TodoPositionGap, nil
+		}
+
+		position := (anchorTodo.Position + *neighbourPosition) / 2
+		if position != anchorTodo.Position && position != *neighbourPosition {
+			return position, nil
+		}
+
+		err = todoService.todoRepository.RebalanceTodoPositions(userId)
+		if err != nil {
+			return 0, err
+		}
+
+		anchorTodo, err = todoService.todoRepository.GetTodoById(anchorTodo.Id)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, errors.New("No free position left next to the todo")
+}
+
+// withTransaction runs write with a copy of the service whose todo repository is bound to a single
+// transaction, so that a todo, its tags and its revision are written together or not at all.
+func (todoService TodoService) withTransaction(write func(txTodoService TodoService) error) error {
+	return todoService.todoRepository.WithTransaction(func(txTodoRepository persistence.ITodoRepository) error {
+		txTodoService := todoService
+		txTodoService.todoRepository = txTodoRepository
+		return write(txTodoService)
+	})
+}
+
+// withExpectedVersion runs write in a transaction. When a version is expected, the transaction holds
+// a lock on the todo and fails with ErrTodoVersionMismatch when the todo is no longer at that version.
+func (todoService TodoService) withExpectedVersion(todoId int, expectedVersion *int, write func(txTodoService TodoService) error) error {
+	return todoService.withTransaction(func(txTodoService TodoService) error {
+		if expectedVersion != nil {
+			version, err := txTodoService.todoRepository.LockTodoVersion(todoId)
+			if err != nil {
+				return err
+			}
+
+			if version != *expectedVersion {
+				return ErrTodoVersionMismatch
+			}
+		}
+
+		return write(txTodoService)
+	})
+}
+
+// recordRevision appends a revision made by the user to the history of a todo, with the tracked fields
+// that changed since previous. Revisions that change nothing are only kept for deletes and restores.
+func (todoService TodoService) recordRevision(userId int, action string, previous domain.TodoSnapshot, todo domain.Todo) error {
+	snapshot := domain.NewTodoSnapshot(todo)
+	changes := snapshot.Changes(previous)
+	if len(changes) == 0 && action != domain.TodoActionDeleted && action != domain.TodoActionRestored {
+		return nil
+	}
+
+	return todoService.todoRepository.AddTodoRevision(domain.TodoRevision{
+		TodoId:    todo.Id,
+		UserId:    userId,
+		Action:    action,
+		Changes:   changes,
+		Snapshot:  snapshot,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (todoService TodoService) buildTodoTree(todo domain.Todo) (response.TodoResponse, error) {
+	subtasksByParentId, err := todoService.getSubtasksByParentId(todo.Id)
+	if err != nil {
+		return response.TodoResponse{}, err
+	}
+
+	return newTodoTreeResponse(todo, subtasksByParentId), nil
+}
+
+// getSubtasksByParentId loads the subtasks below a todo level by level, never descending
+// further than MaxSubtaskDepth so that corrupted parent links cannot loop forever.
+func (todoService TodoService) getSubtasksByParentId(todoId int) (map[int][]domain.Todo, error) {
+	subtasksByParentId := map[int][]domain.Todo{}
+	parentIds := []int{todoId}
+	for depth := 0; depth < domain.MaxSubtaskDepth && len(parentIds) > 0; depth++ {
+		subtasks, err := todoService.todoRepository.GetSubtasksByParentIds(parentIds)
+		if err != nil {
+			return nil, err
+		}
+
+		parentIds = nil
+		for _, subtask := range subtasks {
+			subtasksByParentId[*subtask.ParentId] = append(subtasksByParentId[*subtask.ParentId], subtask)
+			parentIds = append(parentIds, subtask.Id)
+		}
+	}
+
+	return subtasksByParentId, nil
+}
+
+func (todoService TodoService) getTodoDepth(todo domain.Todo) (int, error) {
+	depth := 0
+	for todo.ParentId != nil {
+		depth++
+		if depth > domain.MaxSubtaskDepth {
+			return 0, errors.New(fmt.Sprintf("Subtasks cannot be nested deeper than %d levels", domain.MaxSubtaskDepth))
+		}
+
+		parent, err := todoService.todoRepository.GetTodoById(*todo.ParentId)
+		if err != nil {
+			return 0, err
+		}
+		todo = parent
+	}
+
+	return depth, nil
+}
+
+// completeTodos moves open todos of the same owner to the first done status of the owner's workflow and
+// records the completion in the history of each. Todos that are blocked by open todos, or that the
+// workflow does not allow to move to that status, are left open.
+func (todoService TodoService) completeTodos(userId int, ownerId int, todos []domain.Todo) error {
+	workflow, err := getWorkflow(todoService.workflowRepository, ownerId)
+	if err != nil {
+		return err
+	}
+
+	doneStatus := workflow.CompletionStatus(true).Key
+	var todoIds []int
+	var previousSnapshots []domain.TodoSnapshot
+	var completedTodos []domain.Todo
+	for _, todo := range todos {
+		if todo.IsCompleted {
+			continue
+		}
+
+		previous := domain.NewTodoSnapshot(todo)
+		if setTodoStatus(workflow, &todo, doneStatus) != nil {
+			continue
+		}
+
+		todoIds = append(todoIds, todo.Id)
+		previousSnapshots = append(previousSnapshots, previous)
+		completedTodos = append(completedTodos, todo)
+	}
+
+	if len(todoIds) == 0 {
+		return nil
+	}
+
+	err = todoService.todoRepository.SetTodosCompletion(todoIds, true, doneStatus, userId)
+	if err != nil {
+		return err
+	}
+
+	for i, todo := range completedTodos {
+		err = todoService.recordRevision(userId, domain.TodoActionToggled, previousSnapshots[i], todo)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (todoService TodoService) completeParentsWhenSubtasksDone(userId int, todo domain.Todo) error {
+	for depth := 0; todo.ParentId != nil && depth < domain.MaxSubtaskDepth; depth++ {
+		siblings, err := todoService.todoRepository.GetSubtasksByParentIds([]int{*todo.ParentId})
+		if err != nil {
+			return err
+		}
+
+		for _, sibling := range siblings {
+			if !sibling.IsCompleted {
+				return nil
+			}
+		}
+
+		parent, err := todoService.todoRepository.GetTodoById(*todo.ParentId)
+		if err != nil {
+			return err
+		}
+
+		if parent.IsCompleted {
+			return nil
+		}
+
+		err = todoService.completeTodos(userId, parent.UserId, []domain.Todo{parent})
+		if err != nil {
+			return err
+		}
+		todo = parent
+	}
+
+	return nil
+}
+
+// scheduleNextOccurrence creates the todo for the occurrence after a completed recurring todo.
+// Completing the same occurrence twice, for example after reopening it, does not create a duplicate.
+func (todoService TodoService) scheduleNextOccurrence(userId int, todo domain.Todo) error {
+	if todo.RecurrenceRule == nil || todo.DueDate == nil {
+		return nil
+	}
+
+	rule, err := recurrence.Parse(*todo.RecurrenceRule)
+	if err != nil {
+		return err
+	}
+
+	occurrenceIndex := max(todo.OccurrenceIndex, 1)
+	nextDueDate, hasNext := rule.Next(*todo.DueDate, occurrenceIndex)
+	if !hasNext {
+		return nil
+	}
+
+	seriesId := todo.Id
+	if todo.SeriesId != nil {
+		seriesId = *todo.SeriesId
+	}
+
+	exists, err := todoService.todoRepository.HasSeriesOccurrence(seriesId, occurrenceIndex+1)
+	if err != nil || exists {
+		return err
+	}
+
+	workflow, err := getWorkflow(todoService.workflowRepository, todo.UserId)
+	if err != nil {
+		return err
+	}
+
+	nextTodo, err := todoService.todoRepository.AddTodo(domain.Todo{
+		UserId:          todo.UserId,
+		ProjectId:       todo.ProjectId,
+		ParentId:        todo.ParentId,
+		Title:           todo.Title,
+		Description:     todo.Description,
+		IsCompleted:     false,
+		Status:          workflow.CompletionStatus(false).Key,
+		Priority:        todo.Priority,
+		AssigneeId:      todo.AssigneeId,
+		AssignedBy:      todo.AssignedBy,
+		AssignedAt:      todo.AssignedAt,
+		DueDate:         &nextDueDate,
+		DueTime:         todo.DueTime,
+		DueTimezone:     todo.DueTimezone,
+		RecurrenceRule:  todo.RecurrenceRule,
+		EstimateMinutes: todo.EstimateMinutes,
+		SeriesId:        &seriesId,
+		OccurrenceIndex: occurrenceIndex + 1,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to add next occurrence")
+	}
+
+	var tagIds []int
+	for _, tag := range todo.Tags {
+		tagIds = append(tagIds, tag.Id)
+	}
+
+	err = todoService.todoRepository.AddTodoTags(nextTodo.Id, todo.UserId, tagIds)
+	if err != nil {
+		return err
+	}
+
+	return todoService.recordRevision(userId, domain.TodoActionCreated, domain.TodoSnapshot{}, nextTodo)
+}
+
+func (todoService TodoService) checkProjectAccess(userId int, projectId int, access domain.TodoAccess) (domain.Project, error) {
+	project, err := todoService.projectRepository.GetProjectById(projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	err = todoService.todoAuthorizer.AuthorizeProject(userId, project, access)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	if project.IsArchived {
+		return domain.Project{}, errors.New("Todos cannot be added to an archived project")
+	}
+
+	return project, nil
+}
+
+// includeSharedTodos extends a query of the todos of the user to the todos shared with them, or
+// limits it to those, depending on the requested owner.
+func (todoService TodoService) includeSharedTodos(todoQuery *domain.TodoQuery, owner string) error {
+	if owner == domain.TodoOwnerMe {
+		return nil
+	}
+
+	sharedTodoIds, sharedProjectIds, err := todoService.todoAuthorizer.GetSharedIds(todoQuery.UserId)
+	if err != nil {
+		return err
+	}
+
+	todoQuery.SharedTodoIds = sharedTodoIds
+	todoQuery.SharedProjectIds = sharedProjectIds
+	todoQuery.SharedOnly = owner == domain.TodoOwnerOthers
+
+	return nil
+}
+
+func validateTodo(todo interface{}) error {
+	switch t := todo.(type) {
+	case request.TodoCreate:
+		if len(t.Title) <= 3 {
+			return errors.New("Todo title must be at least 3 characters long")
+		} else if len(t.Description) <= 5 {
+			return errors.New("Todo description must be at least 5 characters long")
+		}
+		return validateEstimate(t.EstimateMinutes)
+	case request.TodoUpdate:
+		if len(t.Title) <= 3 {
+			return errors.New("Todo title must be at least 3 characters long")
+		} else if len(t.Description) <= 5 {
+			return errors.New("Todo description must be at least 5 characters long")
+		}
+		return validateEstimate(t.EstimateMinutes)
+	case request.TodoPatch:
+		if t.Title.Null || t.Description.Null || t.IsCompleted.Null {
+			return errors.New("Todo title, description and isCompleted cannot be null")
+		} else if t.Status.Null {
+			return errors.New("Todo status cannot be null")
+		} else if t.Title.Set && len(t.Title.Value) <= 3 {
+			return errors.New("Todo title must be at least 3 characters long")
+		} else if t.Description.Set && len(t.Description.Value) <= 5 {
+			return errors.New("Todo description must be at least 5 characters long")
+		} else if t.EstimateMinutes.Set {
+			return validateEstimate(t.EstimateMinutes.Pointer())
+		}
+	default:
+		return errors.New("Unsupported type")
+	}
+
+	return nil
+}
+
+func validateEstimate(estimateMinutes *int) error {
+	if estimateMinutes != nil && (*estimateMinutes <= 0 || *estimateMinutes > maxEstimateMinutes) {
+		return errors.New(fmt.Sprintf("Estimate must be between 1 and %d minutes", maxEstimateMinutes))
+	}
+
+	return nil
+}
+
+func newTodoQuery(userId int, todoFilter request.TodoFilter) (domain.TodoQuery, error) {
+	var projectId *int
+	inboxOnly := todoFilter.ProjectId == "inbox"
+	if todoFilter.ProjectId != "" && !inboxOnly {
+		parsedProjectId, err := strconv.Atoi(todoFilter.ProjectId)
+		if err != nil {
+			return domain.TodoQuery{}, newValidationError("Project id must be a number or inbox")
+		}
+		projectId = &parsedProjectId
+	}
+
+	var parentId *int
+	topLevelOnly := todoFilter.ParentId == "none"
+	if todoFilter.ParentId != "" && !topLevelOnly {
+		parsedParentId, err := strconv.Atoi(todoFilter.ParentId)
+		if err != nil {
+			return domain.TodoQuery{}, newValidationError("Parent id must be a number or none")
+		}
+		parentId = &parsedParentId
+	}
+
+	todoQuery := domain.TodoQuery{
+		ProjectId:     projectId,
+		InboxOnly:     inboxOnly,
+		ParentId:      parentId,
+		TopLevelOnly:  topLevelOnly,
+		UserId:        userId,
+		IsCompleted:   todoFilter.IsCompleted,
+		Status:        todoFilter.Status,
+		CreatedFrom:   todoFilter.CreatedFrom,
+		CreatedTo:     todoFilter.CreatedTo,
+		UpdatedFrom:   todoFilter.UpdatedFrom,
+		UpdatedTo:     todoFilter.UpdatedTo,
+		CompletedFrom: todoFilter.CompletedFrom,
+		CompletedTo:   todoFilter.CompletedTo,
+		Title:         todoFilter.Title,
+		DueFrom:       todoFilter.DueFrom,
+		DueTo:         todoFilter.DueTo,
+		Timezone:      todoFilter.Timezone,
+		Tags:          todoFilter.Tags,
+		TagMode:       todoFilter.TagMode,
+		SortBy:        todoFilter.Sort,
+		SortDirection: todoFilter.Order,
+		Limit:         todoFilter.Limit,
+		Offset:        todoFilter.Offset,
+	}
+
+	if todoFilter.Priority != "" {
+		priority, err := parsePriority(todoFilter.Priority)
+		if err != nil {
+			return domain.TodoQuery{}, newValidationError(err.Error())
+		}
+		todoQuery.Priority = &priority
+	}
+
+	if todoQuery.SortBy == "" {
+		todoQuery.SortBy = domain.TodoSortByPosition
+	}
+	if todoQuery.SortDirection == "" {
+		todoQuery.SortDirection = domain.SortDirectionAsc
+	}
+	if todoQuery.Limit == 0 {
+		todoQuery.Limit = defaultTodoPageLimit
+	}
+	if todoQuery.Timezone == "" {
+		todoQuery.Timezone = "UTC"
+	}
+	if todoQuery.TagMode == "" {
+		todoQuery.TagMode = domain.TagMatchAny
+	}
+
+	switch todoQuery.SortBy {
+	case domain.TodoSortByCreatedAt, domain.TodoSortByUpdatedAt, domain.TodoSortByTitle, domain.TodoSortByDueDate, domain.TodoSortByPosition, domain.TodoSortByPriority, domain.TodoSortByCompletedAt:
+	default:
+		return domain.TodoQuery{}, newValidationError(fmt.Sprintf("Unsupported sort field %s", todoQuery.SortBy))
+	}
+
+	if todoQuery.SortDirection != domain.SortDirectionAsc && todoQuery.SortDirection != domain.SortDirectionDesc {
+		return domain.TodoQuery{}, newValidationError("Sort order must be either asc or desc")
+	}
+
+	if todoQuery.TagMode != domain.TagMatchAll && todoQuery.TagMode != domain.TagMatchAny {
+		return domain.TodoQuery{}, newValidationError("Tag mode must be either and or or")
+	}
+
+	if todoFilter.Assignee == domain.TodoAssigneeMe {
+		todoQuery.AssigneeId = &userId
+	} else if todoFilter.Assignee == domain.TodoAssigneeNone {
+		todoQuery.Unassigned = true
+	} else if todoFilter.Assignee != "" {
+		assigneeId, err := strconv.Atoi(todoFilter.Assignee)
+		if err != nil {
+			return domain.TodoQuery{}, newValidationError("Assignee must be a user id, me or none")
+		}
+		todoQuery.AssigneeId = &assigneeId
+	}
+
+	if todoFilter.Owner != "" && todoFilter.Owner != domain.TodoOwnerMe && todoFilter.Owner != domain.TodoOwnerOthers {
+		return domain.TodoQuery{}, newValidationError("Owner must be either me or others")
+	}
+
+	if todoQuery.Limit < 0 || todoQuery.Limit > maxTodoPageLimit {
+		return domain.TodoQuery{}, newValidationError(fmt.Sprintf("Limit must be between 1 and %d", maxTodoPageLimit))
+	}
+
+	if todoQuery.Offset < 0 {
+		return domain.TodoQuery{}, newValidationError("Offset cannot be negative")
+	}
+
+	if todoQuery.CreatedFrom != nil && todoQuery.CreatedTo != nil && todoQuery.CreatedFrom.After(*todoQuery.CreatedTo) {
+		return domain.TodoQuery{}, newValidationError("createdFrom must be before createdTo")
+	}
+
+	if todoQuery.UpdatedFrom != nil && todoQuery.UpdatedTo != nil && todoQuery.UpdatedFrom.After(*todoQuery.UpdatedTo) {
+		return domain.TodoQuery{}, newValidationError("updatedFrom must be before updatedTo")
+	}
+
+	if todoQuery.CompletedFrom != nil && todoQuery.CompletedTo != nil && todoQuery.CompletedFrom.After(*todoQuery.CompletedTo) {
+		return domain.TodoQuery{}, newValidationError("completedFrom must be before completedTo")
+	}
+
+	if todoQuery.DueFrom != nil && todoQuery.DueTo != nil && todoQuery.DueFrom.After(*todoQuery.DueTo) {
+		return domain.TodoQuery{}, newValidationError("dueFrom must be before dueTo")
+	}
+
+	return todoQuery, nil
+}
+
+func parseDueDate(dueDate *string, dueTime *string, dueTimezone *string) (*time.Time, error) {
+	if dueDate == nil {
+		if dueTime != nil || dueTimezone != nil {
+			return nil, errors.New("Due time and timezone require a due date")
+		}
+		return nil, nil
+	}
+
+	parsedDueDate, err := time.Parse(domain.DueDateLayout, *dueDate)
+	if err != nil {
+		return nil, errors.New("Due date must be in YYYY-MM-DD format")
+	}
+
+	if dueTime != nil {
+		if _, err := time.Parse(domain.DueTimeLayout, *dueTime); err != nil {
+			return nil, errors.New("Due time must be in HH:MM format")
+		}
+	} else if dueTimezone != nil {
+		return nil, errors.New("Due timezone requires a due time")
+	}
+
+	if dueTimezone != nil {
+		if _, err := time.LoadLocation(*dueTimezone); err != nil || *dueTimezone == "" {
+			return nil, errors.New(fmt.Sprintf("Unknown timezone %s", *dueTimezone))
+		}
+	}
+
+	return &parsedDueDate, nil
+}
+
+func newTodoTreeResponse(todo domain.Todo, subtasksByParentId map[int][]domain.Todo) response.TodoResponse {
+	todoResponse := response.NewTodoResponse(todo)
+	for _, subtask := range subtasksByParentId[todo.Id] {
+		todoResponse.Subtasks = append(todoResponse.Subtasks, newTodoTreeResponse(subtask, subtasksByParentId))
+	}
+
+	return todoResponse
+}
+
+func subtreeHeight(todoId int, subtasksByParentId map[int][]domain.Todo) int {
+	height := 0
+	for _, subtask := range subtasksByParentId[todoId] {
+		subtaskHeight := 1 + subtreeHeight(subtask.Id, subtasksByParentId)
+		if subtaskHeight > height {
+			height = subtaskHeight
+		}
+	}
+
+	return height
+}
+
+func validateTodoBulk(todoBulk request.TodoBulk) error {
+	if len(todoBulk.Ids) == 0 {
+		return errors.New("At least one todo id is required")
+	}
+
+	if len(todoBulk.Ids) > maxBulkTodoCount {
+		return errors.New(fmt.Sprintf("At most %d todos can be updated at once", maxBulkTodoCount))
+	}
+
+	switch todoBulk.Action {
+	case domain.BulkActionComplete, domain.BulkActionUncomplete, domain.BulkActionDelete, domain.BulkActionMove:
+	case domain.BulkActionAddTag:
+		if todoBulk.TagId == nil {
+			return errors.New("Tag id is required to add a tag")
+		}
+	default:
+		return errors.New(fmt.Sprintf("Unsupported bulk action %s", todoBulk.Action))
+	}
+
+	return nil
+}
+
+func uniqueTodoIds(todoIds []int) []int {
+	seen := map[int]bool{}
+	var unique []int
+	for _, todoId := range todoIds {
+		if !seen[todoId] {
+			seen[todoId] = true
+			unique = append(unique, todoId)
+		}
+	}
+
+	return unique
+}
+
+// setTodoStatus moves todo to the status with the given key and completes or reopens it to match.
+// A todo cannot be completed while any todo it depends on is still open.
+func setTodoStatus(workflow domain.Workflow, todo *domain.Todo, key string) error {
+	status, found := workflow.Status(key)
+	if !found {
+		return errors.New(fmt.Sprintf("Unknown status %s", key))
+	}
+
+	if !workflow.CanTransition(todo.Status, status.Key) {
+		return errors.New(fmt.Sprintf("Todo cannot move from status %s to %s", todo.Status, status.Key))
+	}
+
+	if status.IsDone && len(todo.BlockedByIds) > 0 {
+		return errors.New(fmt.Sprintf("Todo is blocked by open todos %s", formatIds(todo.BlockedByIds)))
+	}
+
+	todo.Status = status.Key
+	todo.IsCompleted = status.IsDone
+
+	return nil
+}
+
+// syncTodoStatus moves a todo whose completion was changed directly to the first status of the
+// workflow that matches it, keeping isCompleted and status consistent.
+func syncTodoStatus(workflow domain.Workflow, todo *domain.Todo) error {
+	if status, found := workflow.Status(todo.Status); found && status.IsDone == todo.IsCompleted {
+		return nil
+	}
+
+	return setTodoStatus(workflow, todo, workflow.CompletionStatus(todo.IsCompleted).Key)
+}
+
+func formatIds(ids []int) string {
+	formattedIds := make([]string, len(ids))
+	for i, id := range ids {
+		formattedIds[i] = strconv.Itoa(id)
+	}
+
+	return strings.Join(formattedIds, ", ")
+}
+
+// parsePriority treats an empty name as no priority.
+func parsePriority(name string) (int, error) {
+	if name == "" {
+		return domain.PriorityNone, nil
+	}
+
+	priority, found := domain.ParsePriority(name)
+	if !found {
+		return domain.PriorityNone, errors.New(fmt.Sprintf("Unsupported priority %s", name))
+	}
+
+	return priority, nil
+}
+
+func patchNullableString(patchable request.Patchable[string], current *string) *string {
+	if !patchable.Set {
+		return current
+	}
+
+	return patchable.Pointer()
+}
+
+func equalStrings(first *string, second *string) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+
+	return *first == *second
+}
+
+func equalInts(first *int, second *int) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+
+	return *first == *second
+}
+
+func equalDates(first *time.Time, second *time.Time) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+
+	return first.Equal(*second)
+}
+
+func parseRecurrenceRule(recurrenceRule *string, dueDate *time.Time) (*string, error) {
+	if recurrenceRule == nil {
+		return nil, nil
+	}
+
+	if dueDate == nil {
+		return nil, errors.New("Recurring todos require a due date")
+	}
+
+	rule, err := recurrence.Parse(*recurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedRule := rule.String()
+
+	return &normalizedRule, nil
 }
 
 func convertTodosToResponses(todos []domain.Todo) []response.TodoResponse {
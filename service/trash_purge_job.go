@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type TrashPurgeJob struct {
+	todoService       ITodoService
+	attachmentService IAttachmentService
+	retentionPeriod   time.Duration
+	purgeInterval     time.Duration
+}
+
+func NewTrashPurgeJob(todoService ITodoService, attachmentService IAttachmentService, retentionPeriod time.Duration, purgeInterval time.Duration) *TrashPurgeJob {
+	return &TrashPurgeJob{
+		todoService:       todoService,
+		attachmentService: attachmentService,
+		retentionPeriod:   retentionPeriod,
+		purgeInterval:     purgeInterval,
+	}
+}
+
+// Start purges expired trash and the attachments of permanently deleted todos once immediately and then on every purge interval until ctx is cancelled.
+func (trashPurgeJob *TrashPurgeJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(trashPurgeJob.purgeInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			trashPurgeJob.purge()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (trashPurgeJob *TrashPurgeJob) purge() {
+	purgedCount, err := trashPurgeJob.todoService.PurgeTrash(trashPurgeJob.retentionPeriod)
+	if err != nil {
+		log.Printf("Failed to purge trashed todos: %v", err)
+		return
+	}
+
+	if purgedCount > 0 {
+		log.Printf("Purged %d trashed todos", purgedCount)
+	}
+
+	deletedCount, err := trashPurgeJob.attachmentService.CleanupOrphanedAttachments()
+	if err != nil {
+		log.Printf("Failed to clean up orphaned attachments: %v", err)
+		return
+	}
+
+	if deletedCount > 0 {
+		log.Printf("Deleted %d orphaned attachments", deletedCount)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"regexp"
+	"strings"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+	"todo-app--go-gin/persistence"
+)
+
+var workflowStatusKeyPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+type IWorkflowService interface {
+	GetWorkflow(userId int) (response.WorkflowResponse, error)
+	UpdateWorkflow(workflowUpdate request.WorkflowUpdate) (response.WorkflowResponse, error)
+}
+
+type WorkflowService struct {
+	workflowRepository persistence.IWorkflowRepository
+	todoRepository     persistence.ITodoRepository
+}
+
+func NewWorkflowService(workflowRepository persistence.IWorkflowRepository, todoRepository persistence.ITodoRepository) IWorkflowService {
+	return &WorkflowService{
+		workflowRepository: workflowRepository,
+		todoRepository:     todoRepository,
+	}
+}
+
+func (workflowService WorkflowService) GetWorkflow(userId int) (response.WorkflowResponse, error) {
+	workflow, err := getWorkflow(workflowService.workflowRepository, userId)
+	if err != nil {
+		return response.WorkflowResponse{}, err
+	}
+
+	return response.NewWorkflowResponse(workflow), nil
+}
+
+// UpdateWorkflow replaces the workflow of the user. Statuses that todos are still in cannot be removed.
+func (workflowService WorkflowService) UpdateWorkflow(workflowUpdate request.WorkflowUpdate) (response.WorkflowResponse, error) {
+	workflow := domain.Workflow{UserId: workflowUpdate.UserId}
+	for _, status := range workflowUpdate.Statuses {
+		workflow.Statuses = append(workflow.Statuses, domain.WorkflowStatus{
+			Key:    strings.TrimSpace(status.Key),
+			Name:   strings.TrimSpace(status.Name),
+			IsDone: status.IsDone,
+		})
+	}
+	for _, transition := range workflowUpdate.Transitions {
+		workflow.Transitions = append(workflow.Transitions, domain.WorkflowTransition{
+			From: strings.TrimSpace(transition.From),
+			To:   strings.TrimSpace(transition.To),
+		})
+	}
+
+	validationError := validateWorkflow(workflow)
+	if validationError != nil {
+		return response.WorkflowResponse{}, validationError
+	}
+
+	statusesInUse, err := workflowService.todoRepository.GetTodoStatusesInUse(workflowUpdate.UserId)
+	if err != nil {
+		return response.WorkflowResponse{}, err
+	}
+
+	for _, statusInUse := range statusesInUse {
+		if _, found := workflow.Status(statusInUse); !found {
+			return response.WorkflowResponse{}, errors.New(fmt.Sprintf("Status %s is still used by todos", statusInUse))
+		}
+	}
+
+	err = workflowService.workflowRepository.SaveWorkflow(workflow)
+	if err != nil {
+		return response.WorkflowResponse{}, err
+	}
+
+	return response.NewWorkflowResponse(workflow), nil
+}
+
+// getWorkflow returns the workflow of the user, or the default workflow when the user has not configured one.
+func getWorkflow(workflowRepository persistence.IWorkflowRepository, userId int) (domain.Workflow, error) {
+	workflow, err := workflowRepository.GetWorkflowByUserId(userId)
+	if err != nil {
+		return domain.Workflow{}, err
+	}
+
+	if len(workflow.Statuses) == 0 {
+		return domain.DefaultWorkflow(userId), nil
+	}
+
+	return workflow, nil
+}
+
+func validateWorkflow(workflow domain.Workflow) error {
+	hasOpenStatus, hasDoneStatus := false, false
+	keys := make(map[string]bool)
+	for _, status := range workflow.Statuses {
+		if !workflowStatusKeyPattern.MatchString(status.Key) {
+			return errors.New("Status key must be 1 to 32 lowercase letters, digits or underscores")
+		} else if keys[status.Key] {
+			return errors.New(fmt.Sprintf("Status %s is defined more than once", status.Key))
+		} else if status.Name == "" || len(status.Name) > 64 {
+			return errors.New("Status name must be between 1 and 64 characters long")
+		}
+
+		keys[status.Key] = true
+		hasOpenStatus = hasOpenStatus || !status.IsDone
+		hasDoneStatus = hasDoneStatus || status.IsDone
+	}
+
+	if !hasOpenStatus || !hasDoneStatus {
+		return errors.New("Workflow must have at least one open and one done status")
+	}
+
+	transitions := make(map[domain.WorkflowTransition]bool)
+	for _, transition := range workflow.Transitions {
+		if !keys[transition.From] || !keys[transition.To] {
+			return errors.New(fmt.Sprintf("Transition from %s to %s uses an unknown status", transition.From, transition.To))
+		} else if transition.From == transition.To || transitions[transition] {
+			return errors.New(fmt.Sprintf("Transition from %s to %s is invalid or defined more than once", transition.From, transition.To))
+		}
+
+		transitions[transition] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"todo-app--go-gin/controller"
+	"todo-app--go-gin/domain"
+	fakes "todo-app--go-gin/test/service"
+)
+
+func newETagTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	parentId := 1
+	todoService := fakes.NewTestServices(fakes.TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2", Description: "Ship the release", Version: 1},
+			{Id: 2, UserId: 1, Title: "Write changelog", Description: "List the changes", ParentId: &parentId, Version: 1},
+		},
+	}).TodoService
+	todoController := controller.NewTodoController(todoService)
+
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("userId", 1)
+	})
+	router.GET("/todos/:id", todoController.GetTodoById)
+	router.PUT("/todos/:id", todoController.UpdateTodo)
+	return router
+}
+
+func serveTodoRequest(router *gin.Engine, method string, path string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	httpRequest := httptest.NewRequest(method, path, strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		httpRequest.Header.Set(name, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httpRequest)
+	return recorder
+}
+
+func Test_ShouldRejectUpdateWithStaleETag(t *testing.T) {
+	router := newETagTestRouter()
+	update := `{"title": "Release version 2.1", "description": "Ship the patch release"}`
+
+	t.Run("ShouldRejectUpdateWithStaleETag", func(t *testing.T) {
+		etag := serveTodoRequest(router, http.MethodGet, "/todos/1", "", nil).Header().Get("ETag")
+
+		recorder := serveTodoRequest(router, http.MethodPut, "/todos/1", update, map[string]string{"If-Match": etag})
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotEqual(t, etag, recorder.Header().Get("ETag"))
+
+		recorder = serveTodoRequest(router, http.MethodPut, "/todos/1", update, map[string]string{"If-Match": etag})
+		assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+	})
+
+	t.Run("ShouldRejectUpdateWithMalformedETag", func(t *testing.T) {
+		recorder := serveTodoRequest(router, http.MethodPut, "/todos/1", update, map[string]string{"If-Match": "release"})
+		assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+	})
+}
+
+func Test_ShouldChangeETagWhenSubtaskChanges(t *testing.T) {
+	router := newETagTestRouter()
+
+	t.Run("ShouldChangeETagWhenSubtaskChanges", func(t *testing.T) {
+		etag := serveTodoRequest(router, http.MethodGet, "/todos/1", "", nil).Header().Get("ETag")
+
+		recorder := serveTodoRequest(router, http.MethodGet, "/todos/1", "", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+
+		recorder = serveTodoRequest(router, http.MethodPut, "/todos/2", `{"title": "Write release notes", "description": "List the changes"}`, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		recorder = serveTodoRequest(router, http.MethodGet, "/todos/1", "", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotEqual(t, etag, recorder.Header().Get("ETag"))
+		assert.Contains(t, recorder.Body.String(), "Write release notes")
+	})
+}
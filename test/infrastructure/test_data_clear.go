@@ -14,6 +14,20 @@ func TruncateTestData(ctx context.Context, dbPool *pgxpool.Pool) {
 		log.Printf("Todos table truncated")
 	}
 
+	_, truncateResultErr = dbPool.Exec(ctx, "TRUNCATE projects RESTART IDENTITY CASCADE")
+	if truncateResultErr != nil {
+		log.Printf("Error truncating projects table: %v", truncateResultErr)
+	} else {
+		log.Printf("Projects table truncated")
+	}
+
+	_, truncateResultErr = dbPool.Exec(ctx, "TRUNCATE tags RESTART IDENTITY CASCADE")
+	if truncateResultErr != nil {
+		log.Printf("Error truncating tags table: %v", truncateResultErr)
+	} else {
+		log.Printf("Tags table truncated")
+	}
+
 	_, truncateResultErr = dbPool.Exec(ctx, "TRUNCATE users RESTART IDENTITY CASCADE")
 	if truncateResultErr != nil {
 		log.Printf("Error truncating users table: %v", truncateResultErr)
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"todo-app--go-gin/common/storage"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+func Test_ShouldAddAttachment(t *testing.T) {
+	blobStore, err := storage.NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+	attachmentTestServices := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Fix layout", Description: "Broken header"},
+			{Id: 2, UserId: 1, Title: "Update docs", Description: "Setup guide"},
+			{Id: 3, UserId: 2, Title: "Other user todo", Description: "Not yours"},
+		},
+		BlobStore:              blobStore,
+		MaxAttachmentSize:      1024,
+		AttachmentContentTypes: []string{"image/png", "text/plain"},
+	})
+	attachmentService := attachmentTestServices.AttachmentService
+
+	t.Run("ShouldAddAttachmentWithDetectedContentType", func(t *testing.T) {
+		attachment, err := attachmentService.AddAttachment(request.AttachmentCreate{UserId: 1, TodoId: 1, FileName: "../../screenshot.png", Content: bytes.NewReader(pngContent)})
+		assert.Nil(t, err)
+		assert.Equal(t, "screenshot.png", attachment.FileName)
+		assert.Equal(t, "image/png", attachment.ContentType)
+		assert.Equal(t, int64(len(pngContent)), attachment.Size)
+
+		_, content, err := attachmentService.OpenAttachment(1, 1, attachment.Id)
+		assert.Nil(t, err)
+		storedContent, _ := io.ReadAll(content)
+		content.Close()
+		assert.Equal(t, pngContent, storedContent)
+
+		attachments, _ := attachmentService.GetAttachments(1, 1)
+		assert.Equal(t, 1, len(attachments))
+	})
+
+	t.Run("ShouldNotAddAttachmentWithDisallowedContentType", func(t *testing.T) {
+		_, err := attachmentService.AddAttachment(request.AttachmentCreate{UserId: 1, TodoId: 1, FileName: "report.pdf", Content: strings.NewReader("%PDF-1.7\n")})
+		assert.Equal(t, "Attachments of type application/pdf are not allowed", err.Error())
+	})
+
+	t.Run("ShouldNotAddAttachmentLargerThanMaxSize", func(t *testing.T) {
+		_, err := attachmentService.AddAttachment(request.AttachmentCreate{UserId: 1, TodoId: 1, FileName: "notes.txt", Content: strings.NewReader(strings.Repeat("a", 2048))})
+		assert.Equal(t, "Attachment must be at most 1024 bytes", err.Error())
+	})
+
+	t.Run("ShouldNotAddAttachmentToOtherUsersTodo", func(t *testing.T) {
+		_, err := attachmentService.AddAttachment(request.AttachmentCreate{UserId: 1, TodoId: 3, FileName: "notes.txt", Content: strings.NewReader("notes")})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldDeleteAttachment(t *testing.T) {
+	blobStore, err := storage.NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+	attachmentTestServices := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Fix layout", Description: "Broken header"},
+			{Id: 2, UserId: 1, Title: "Update docs", Description: "Setup guide"},
+			{Id: 3, UserId: 2, Title: "Other user todo", Description: "Not yours"},
+		},
+		BlobStore:              blobStore,
+		MaxAttachmentSize:      1024,
+		AttachmentContentTypes: []string{"image/png", "text/plain"},
+	})
+	attachmentService, attachmentRepository := attachmentTestServices.AttachmentService, attachmentTestServices.AttachmentRepository
+	first, _ := attachmentService.AddAttachment(request.AttachmentCreate{UserId: 1, TodoId: 1, FileName: "notes.txt", Content: strings.NewReader("shared notes")})
+	second, _ := attachmentService.AddAttachment(request.AttachmentCreate{UserId: 1, TodoId: 2, FileName: "copy.txt", Content: strings.NewReader("shared notes")})
+	blobKey := attachmentRepository.attachments[0].BlobKey
+
+	t.Run("ShouldKeepBlobSharedWithAnotherAttachment", func(t *testing.T) {
+		assert.Equal(t, blobKey, attachmentRepository.attachments[1].BlobKey)
+
+		err := attachmentService.DeleteAttachment(1, 1, first.Id)
+		assert.Nil(t, err)
+
+		content, err := blobStore.Open(blobKey)
+		assert.Nil(t, err)
+		content.Close()
+	})
+
+	t.Run("ShouldNotDeleteAttachmentOfAnotherTodo", func(t *testing.T) {
+		err := attachmentService.DeleteAttachment(1, 1, second.Id)
+		assert.Equal(t, "Attachment with id 2 not found", err.Error())
+	})
+
+	t.Run("ShouldDeleteBlobWithLastAttachment", func(t *testing.T) {
+		err := attachmentService.DeleteAttachment(1, 2, second.Id)
+		assert.Nil(t, err)
+
+		_, err = blobStore.Open(blobKey)
+		assert.Equal(t, storage.ErrBlobNotFound, err)
+	})
+}
+
+func Test_ShouldCleanupOrphanedAttachments(t *testing.T) {
+	blobStore, err := storage.NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+	attachmentTestServices := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Fix layout", Description: "Broken header"},
+			{Id: 2, UserId: 1, Title: "Update docs", Description: "Setup guide"},
+			{Id: 3, UserId: 2, Title: "Other user todo", Description: "Not yours"},
+		},
+		BlobStore:              blobStore,
+		MaxAttachmentSize:      1024,
+		AttachmentContentTypes: []string{"image/png", "text/plain"},
+	})
+	attachmentService, attachmentRepository := attachmentTestServices.AttachmentService, attachmentTestServices.AttachmentRepository
+	attachmentService.AddAttachment(request.AttachmentCreate{UserId: 1, TodoId: 1, FileName: "notes.txt", Content: strings.NewReader("orphaned notes")})
+	blobKey := attachmentRepository.attachments[0].BlobKey
+
+	t.Run("ShouldCleanupAttachmentsOfDeletedTodos", func(t *testing.T) {
+		attachmentRepository.attachments[0].TodoId = nil
+
+		deletedCount, err := attachmentService.CleanupOrphanedAttachments()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, deletedCount)
+
+		_, err = blobStore.Open(blobKey)
+		assert.Equal(t, storage.ErrBlobNotFound, err)
+	})
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldGetComments(t *testing.T) {
+	commentService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write essay", Description: "History essay"},
+			{Id: 2, UserId: 2, Title: "Read book", Description: "Novel for class"},
+		},
+		Comments: []domain.Comment{
+			{Id: 1, TodoId: 1, UserId: 1, Body: "Started on the outline"},
+			{Id: 2, TodoId: 1, UserId: 1, Body: "Outline is done"},
+			{Id: 3, TodoId: 2, UserId: 2, Body: "Not on your todo"},
+		},
+	}).CommentService
+
+	t.Run("ShouldGetCommentsWithPagination", func(t *testing.T) {
+		commentPage, err := commentService.GetComments(1, 1, request.CommentFilter{Limit: 1, Offset: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, commentPage.Total)
+		assert.Equal(t, 1, len(commentPage.Comments))
+		assert.Equal(t, 2, commentPage.Comments[0].Id)
+	})
+
+	t.Run("ShouldNotGetCommentsOfOtherUsersTodo", func(t *testing.T) {
+		_, err := commentService.GetComments(1, 2, request.CommentFilter{})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldAddComment(t *testing.T) {
+	commentService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write essay", Description: "History essay"},
+			{Id: 2, UserId: 2, Title: "Read book", Description: "Novel for class"},
+		},
+		Comments: []domain.Comment{
+			{Id: 1, TodoId: 1, UserId: 1, Body: "Started on the outline"},
+			{Id: 2, TodoId: 1, UserId: 1, Body: "Outline is done"},
+			{Id: 3, TodoId: 2, UserId: 2, Body: "Not on your todo"},
+		},
+	}).CommentService
+
+	t.Run("ShouldAddComment", func(t *testing.T) {
+		comment, err := commentService.AddComment(request.CommentCreate{UserId: 1, TodoId: 1, Body: "  Sent for review  "})
+		assert.Nil(t, err)
+		assert.Equal(t, "Sent for review", comment.Body)
+		assert.Equal(t, 1, comment.AuthorId)
+		assert.Nil(t, comment.EditedAt)
+	})
+
+	t.Run("ShouldNotAddEmptyComment", func(t *testing.T) {
+		_, err := commentService.AddComment(request.CommentCreate{UserId: 1, TodoId: 1, Body: "   "})
+		assert.Equal(t, "Comment cannot be empty", err.Error())
+	})
+}
+
+func Test_ShouldEditCommentAsAuthorOnly(t *testing.T) {
+	commentService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write essay", Description: "History essay"},
+			{Id: 2, UserId: 2, Title: "Read book", Description: "Novel for class"},
+		},
+		Comments: []domain.Comment{
+			{Id: 1, TodoId: 1, UserId: 1, Body: "Started on the outline"},
+			{Id: 2, TodoId: 1, UserId: 1, Body: "Outline is done"},
+			{Id: 3, TodoId: 2, UserId: 2, Body: "Not on your todo"},
+		},
+	}).CommentService
+
+	t.Run("ShouldUpdateComment", func(t *testing.T) {
+		comment, err := commentService.UpdateComment(1, 1, 2, request.CommentUpdate{Body: "Outline is finished"})
+		assert.Nil(t, err)
+		assert.Equal(t, "Outline is finished", comment.Body)
+		assert.NotNil(t, comment.EditedAt)
+	})
+
+	t.Run("ShouldNotUpdateCommentOfAnotherTodo", func(t *testing.T) {
+		_, err := commentService.UpdateComment(1, 1, 3, request.CommentUpdate{Body: "Hijacked"})
+		assert.Equal(t, "Comment with id 3 not found", err.Error())
+	})
+
+	t.Run("ShouldDeleteComment", func(t *testing.T) {
+		err := commentService.DeleteComment(1, 1, 1)
+		assert.Nil(t, err)
+
+		commentPage, _ := commentService.GetComments(1, 1, request.CommentFilter{})
+		assert.Equal(t, 1, commentPage.Total)
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeAttachmentRepository struct {
+	attachments []domain.Attachment
+}
+
+func NewFakeAttachmentRepository(initialAttachments []domain.Attachment) persistence.IAttachmentRepository {
+	return &FakeAttachmentRepository{
+		attachments: initialAttachments,
+	}
+}
+
+func (fakeAttachmentRepository *FakeAttachmentRepository) GetAttachmentsByTodoId(todoId int) ([]domain.Attachment, error) {
+	var todoAttachments []domain.Attachment
+	for _, attachment := range fakeAttachmentRepository.attachments {
+		if attachment.TodoId != nil && *attachment.TodoId == todoId {
+			todoAttachments = append(todoAttachments, attachment)
+		}
+	}
+
+	return todoAttachments, nil
+}
+
+func (fakeAttachmentRepository *FakeAttachmentRepository) GetAttachmentById(attachmentId int) (domain.Attachment, error) {
+	for _, attachment := range fakeAttachmentRepository.attachments {
+		if attachment.Id == attachmentId {
+			return attachment, nil
+		}
+	}
+
+	return domain.Attachment{}, errors.New(fmt.Sprintf("Attachment with id %d not found", attachmentId))
+}
+
+func (fakeAttachmentRepository *FakeAttachmentRepository) AddAttachment(attachment domain.Attachment) (domain.Attachment, error) {
+	attachment.Id = len(fakeAttachmentRepository.attachments) + 1
+	fakeAttachmentRepository.attachments = append(fakeAttachmentRepository.attachments, attachment)
+
+	return attachment, nil
+}
+
+func (fakeAttachmentRepository *FakeAttachmentRepository) DeleteAttachment(attachmentId int) error {
+	for i, attachment := range fakeAttachmentRepository.attachments {
+		if attachment.Id == attachmentId {
+			fakeAttachmentRepository.attachments = append(fakeAttachmentRepository.attachments[:i], fakeAttachmentRepository.attachments[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Attachment with id %d not found", attachmentId))
+}
+
+func (fakeAttachmentRepository *FakeAttachmentRepository) CountAttachmentsByBlobKey(blobKey string) (int, error) {
+	count := 0
+	for _, attachment := range fakeAttachmentRepository.attachments {
+		if attachment.BlobKey == blobKey {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (fakeAttachmentRepository *FakeAttachmentRepository) GetOrphanedAttachments() ([]domain.Attachment, error) {
+	var orphanedAttachments []domain.Attachment
+	for _, attachment := range fakeAttachmentRepository.attachments {
+		if attachment.TodoId == nil {
+			orphanedAttachments = append(orphanedAttachments, attachment)
+		}
+	}
+
+	return orphanedAttachments, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeCommentRepository struct {
+	comments []domain.Comment
+}
+
+func NewFakeCommentRepository(initialComments []domain.Comment) persistence.ICommentRepository {
+	return &FakeCommentRepository{
+		comments: initialComments,
+	}
+}
+
+func (fakeCommentRepository *FakeCommentRepository) GetCommentsByTodoId(todoId int, limit int, offset int) ([]domain.Comment, int, error) {
+	var todoComments []domain.Comment
+	for _, comment := range fakeCommentRepository.comments {
+		if comment.TodoId == todoId {
+			todoComments = append(todoComments, comment)
+		}
+	}
+
+	total := len(todoComments)
+	start := min(offset, total)
+	end := min(start+limit, total)
+
+	return todoComments[start:end], total, nil
+}
+
+func (fakeCommentRepository *FakeCommentRepository) GetCommentById(commentId int) (domain.Comment, error) {
+	for _, comment := range fakeCommentRepository.comments {
+		if comment.Id == commentId {
+			return comment, nil
+		}
+	}
+
+	return domain.Comment{}, errors.New(fmt.Sprintf("Comment with id %d not found", commentId))
+}
+
+func (fakeCommentRepository *FakeCommentRepository) AddComment(comment domain.Comment) (domain.Comment, error) {
+	comment.Id = len(fakeCommentRepository.comments) + 1
+	fakeCommentRepository.comments = append(fakeCommentRepository.comments, comment)
+
+	return comment, nil
+}
+
+func (fakeCommentRepository *FakeCommentRepository) UpdateComment(commentId int, updatedComment domain.Comment) (domain.Comment, error) {
+	for i, comment := range fakeCommentRepository.comments {
+		if comment.Id == commentId {
+			fakeCommentRepository.comments[i] = updatedComment
+			return updatedComment, nil
+		}
+	}
+
+	return domain.Comment{}, errors.New(fmt.Sprintf("Comment with id %d not found", commentId))
+}
+
+func (fakeCommentRepository *FakeCommentRepository) DeleteComment(commentId int) error {
+	for i, comment := range fakeCommentRepository.comments {
+		if comment.Id == commentId {
+			fakeCommentRepository.comments = append(fakeCommentRepository.comments[:i], fakeCommentRepository.comments[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Comment with id %d not found", commentId))
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeProjectRepository struct {
+	projects []domain.Project
+}
+
+func NewFakeProjectRepository(initialProjects []domain.Project) persistence.IProjectRepository {
+	return &FakeProjectRepository{
+		projects: initialProjects,
+	}
+}
+
+func (fakeProjectRepository *FakeProjectRepository) GetAllProjectsByUserId(userId int, includeArchived bool) ([]domain.Project, error) {
+	var userProjects []domain.Project
+	for _, project := range fakeProjectRepository.projects {
+		if project.UserId == userId && (includeArchived || !project.IsArchived) {
+			userProjects = append(userProjects, project)
+		}
+	}
+
+	return userProjects, nil
+}
+
+func (fakeProjectRepository *FakeProjectRepository) GetProjectById(projectId int) (domain.Project, error) {
+	for _, project := range fakeProjectRepository.projects {
+		if project.Id == projectId {
+			return project, nil
+		}
+	}
+
+	return domain.Project{}, errors.New(fmt.Sprintf("Project with id %d not found", projectId))
+}
+
+func (fakeProjectRepository *FakeProjectRepository) AddProject(project domain.Project) (domain.Project, error) {
+	project.Id = len(fakeProjectRepository.projects) + 1
+	project.Position = len(fakeProjectRepository.projects) + 1
+	fakeProjectRepository.projects = append(fakeProjectRepository.projects, project)
+
+	return project, nil
+}
+
+func (fakeProjectRepository *FakeProjectRepository) UpdateProject(projectId int, updatedProject domain.Project) (domain.Project, error) {
+	for i, project := range fakeProjectRepository.projects {
+		if project.Id == projectId {
+			fakeProjectRepository.projects[i] = updatedProject
+			return updatedProject, nil
+		}
+	}
+
+	return domain.Project{}, errors.New(fmt.Sprintf("Project with id %d not found", projectId))
+}
+
+func (fakeProjectRepository *FakeProjectRepository) DeleteProject(projectId int, deleteMode string) error {
+	for i, project := range fakeProjectRepository.projects {
+		if project.Id == projectId {
+			fakeProjectRepository.projects = append(fakeProjectRepository.projects[:i], fakeProjectRepository.projects[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Project with id %d not found", projectId))
+}
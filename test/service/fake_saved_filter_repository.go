@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeSavedFilterRepository struct {
+	savedFilters []domain.SavedFilter
+}
+
+func NewFakeSavedFilterRepository(initialSavedFilters []domain.SavedFilter) persistence.ISavedFilterRepository {
+	return &FakeSavedFilterRepository{
+		savedFilters: initialSavedFilters,
+	}
+}
+
+func (fakeSavedFilterRepository *FakeSavedFilterRepository) GetSavedFiltersByUserId(userId int) ([]domain.SavedFilter, error) {
+	userSavedFilters := []domain.SavedFilter{}
+	for _, savedFilter := range fakeSavedFilterRepository.savedFilters {
+		if savedFilter.UserId == userId {
+			userSavedFilters = append(userSavedFilters, savedFilter)
+		}
+	}
+
+	return userSavedFilters, nil
+}
+
+func (fakeSavedFilterRepository *FakeSavedFilterRepository) GetSavedFilterById(savedFilterId int) (domain.SavedFilter, error) {
+	for _, savedFilter := range fakeSavedFilterRepository.savedFilters {
+		if savedFilter.Id == savedFilterId {
+			return savedFilter, nil
+		}
+	}
+
+	return domain.SavedFilter{}, errors.New(fmt.Sprintf("Saved filter with id %d not found", savedFilterId))
+}
+
+func (fakeSavedFilterRepository *FakeSavedFilterRepository) AddSavedFilter(savedFilter domain.SavedFilter) (domain.SavedFilter, error) {
+	savedFilter.Id = len(fakeSavedFilterRepository.savedFilters) + 1
+	fakeSavedFilterRepository.savedFilters = append(fakeSavedFilterRepository.savedFilters, savedFilter)
+
+	return savedFilter, nil
+}
+
+func (fakeSavedFilterRepository *FakeSavedFilterRepository) UpdateSavedFilter(savedFilter domain.SavedFilter) (domain.SavedFilter, error) {
+	for i, existingSavedFilter := range fakeSavedFilterRepository.savedFilters {
+		if existingSavedFilter.Id == savedFilter.Id {
+			fakeSavedFilterRepository.savedFilters[i] = savedFilter
+			return savedFilter, nil
+		}
+	}
+
+	return domain.SavedFilter{}, errors.New(fmt.Sprintf("Saved filter with id %d not found", savedFilter.Id))
+}
+
+func (fakeSavedFilterRepository *FakeSavedFilterRepository) DeleteSavedFilter(savedFilterId int) error {
+	for i, savedFilter := range fakeSavedFilterRepository.savedFilters {
+		if savedFilter.Id == savedFilterId {
+			fakeSavedFilterRepository.savedFilters = append(fakeSavedFilterRepository.savedFilters[:i], fakeSavedFilterRepository.savedFilters[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Saved filter with id %d not found", savedFilterId))
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeShareRepository struct {
+	shares []domain.Share
+}
+
+func NewFakeShareRepository(initialShares []domain.Share) persistence.IShareRepository {
+	return &FakeShareRepository{
+		shares: initialShares,
+	}
+}
+
+func (fakeShareRepository *FakeShareRepository) GetSharesByTodoId(todoId int) ([]domain.Share, error) {
+	var todoShares []domain.Share
+	for _, share := range fakeShareRepository.shares {
+		if share.TodoId != nil && *share.TodoId == todoId {
+			todoShares = append(todoShares, share)
+		}
+	}
+
+	return todoShares, nil
+}
+
+func (fakeShareRepository *FakeShareRepository) GetSharesByProjectId(projectId int) ([]domain.Share, error) {
+	var projectShares []domain.Share
+	for _, share := range fakeShareRepository.shares {
+		if share.ProjectId != nil && *share.ProjectId == projectId {
+			projectShares = append(projectShares, share)
+		}
+	}
+
+	return projectShares, nil
+}
+
+func (fakeShareRepository *FakeShareRepository) GetSharesByUserId(userId int) ([]domain.Share, error) {
+	var userShares []domain.Share
+	for _, share := range fakeShareRepository.shares {
+		if share.UserId == userId {
+			userShares = append(userShares, share)
+		}
+	}
+
+	return userShares, nil
+}
+
+func (fakeShareRepository *FakeShareRepository) SaveShare(share domain.Share) (domain.Share, error) {
+	for i, existingShare := range fakeShareRepository.shares {
+		if existingShare.UserId == share.UserId && equalIds(existingShare.TodoId, share.TodoId) && equalIds(existingShare.ProjectId, share.ProjectId) {
+			fakeShareRepository.shares[i].Permission = share.Permission
+			return fakeShareRepository.shares[i], nil
+		}
+	}
+
+	share.Id = len(fakeShareRepository.shares) + 1
+	fakeShareRepository.shares = append(fakeShareRepository.shares, share)
+
+	return share, nil
+}
+
+func (fakeShareRepository *FakeShareRepository) DeleteTodoShare(todoId int, userId int) error {
+	return fakeShareRepository.deleteShare(&todoId, nil, userId)
+}
+
+func (fakeShareRepository *FakeShareRepository) DeleteProjectShare(projectId int, userId int) error {
+	return fakeShareRepository.deleteShare(nil, &projectId, userId)
+}
+
+func (fakeShareRepository *FakeShareRepository) deleteShare(todoId *int, projectId *int, userId int) error {
+	for i, share := range fakeShareRepository.shares {
+		if share.UserId == userId && equalIds(share.TodoId, todoId) && equalIds(share.ProjectId, projectId) {
+			fakeShareRepository.shares = append(fakeShareRepository.shares[:i], fakeShareRepository.shares[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Not shared with user with id %d", userId))
+}
+
+func equalIds(first *int, second *int) bool {
+	return (first == nil && second == nil) || (first != nil && second != nil && *first == *second)
+}
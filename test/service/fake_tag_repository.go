@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeTagRepository struct {
+	tags []domain.Tag
+}
+
+func NewFakeTagRepository(initialTags []domain.Tag) persistence.ITagRepository {
+	return &FakeTagRepository{
+		tags: initialTags,
+	}
+}
+
+func (fakeTagRepository *FakeTagRepository) GetAllTagsByUserId(userId int) ([]domain.Tag, error) {
+	var userTags []domain.Tag
+	for _, tag := range fakeTagRepository.tags {
+		if tag.UserId == userId {
+			userTags = append(userTags, tag)
+		}
+	}
+
+	return userTags, nil
+}
+
+func (fakeTagRepository *FakeTagRepository) GetTagById(tagId int) (domain.Tag, error) {
+	for _, tag := range fakeTagRepository.tags {
+		if tag.Id == tagId {
+			return tag, nil
+		}
+	}
+
+	return domain.Tag{}, errors.New(fmt.Sprintf("Tag with id %d not found", tagId))
+}
+
+func (fakeTagRepository *FakeTagRepository) AddTag(tag domain.Tag) (domain.Tag, error) {
+	for _, existingTag := range fakeTagRepository.tags {
+		if existingTag.UserId == tag.UserId && existingTag.Name == tag.Name {
+			return domain.Tag{}, errors.New("ERROR: duplicate key value violates unique constraint \"tags_user_id_name_key\" (SQLSTATE 23505)")
+		}
+	}
+
+	tag.Id = len(fakeTagRepository.tags) + 1
+	fakeTagRepository.tags = append(fakeTagRepository.tags, tag)
+
+	return tag, nil
+}
+
+func (fakeTagRepository *FakeTagRepository) UpdateTag(tagId int, updatedTag domain.Tag) (domain.Tag, error) {
+	for i, tag := range fakeTagRepository.tags {
+		if tag.Id == tagId {
+			fakeTagRepository.tags[i].Name = updatedTag.Name
+			fakeTagRepository.tags[i].Color = updatedTag.Color
+			return fakeTagRepository.tags[i], nil
+		}
+	}
+
+	return domain.Tag{}, errors.New(fmt.Sprintf("Tag with id %d not found", tagId))
+}
+
+func (fakeTagRepository *FakeTagRepository) DeleteTag(tagId int) error {
+	for i, tag := range fakeTagRepository.tags {
+		if tag.Id == tagId {
+			fakeTagRepository.tags = append(fakeTagRepository.tags[:i], fakeTagRepository.tags[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Tag with id %d not found", tagId))
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeTemplateRepository struct {
+	templates []domain.Template
+}
+
+func NewFakeTemplateRepository(initialTemplates []domain.Template) persistence.ITemplateRepository {
+	return &FakeTemplateRepository{
+		templates: initialTemplates,
+	}
+}
+
+func (fakeTemplateRepository *FakeTemplateRepository) GetTemplatesByUserId(userId int) ([]domain.Template, error) {
+	userTemplates := []domain.Template{}
+	for _, template := range fakeTemplateRepository.templates {
+		if template.UserId == userId {
+			userTemplates = append(userTemplates, template)
+		}
+	}
+
+	return userTemplates, nil
+}
+
+func (fakeTemplateRepository *FakeTemplateRepository) GetTemplateById(templateId int) (domain.Template, error) {
+	for _, template := range fakeTemplateRepository.templates {
+		if template.Id == templateId {
+			return template, nil
+		}
+	}
+
+	return domain.Template{}, errors.New(fmt.Sprintf("Template with id %d not found", templateId))
+}
+
+func (fakeTemplateRepository *FakeTemplateRepository) AddTemplate(template domain.Template) (domain.Template, error) {
+	template.Id = len(fakeTemplateRepository.templates) + 1
+	fakeTemplateRepository.templates = append(fakeTemplateRepository.templates, template)
+
+	return template, nil
+}
+
+func (fakeTemplateRepository *FakeTemplateRepository) UpdateTemplate(template domain.Template) (domain.Template, error) {
+	for i, existingTemplate := range fakeTemplateRepository.templates {
+		if existingTemplate.Id == template.Id {
+			fakeTemplateRepository.templates[i] = template
+			return template, nil
+		}
+	}
+
+	return domain.Template{}, errors.New(fmt.Sprintf("Template with id %d not found", template.Id))
+}
+
+func (fakeTemplateRepository *FakeTemplateRepository) DeleteTemplate(templateId int) error {
+	for i, template := range fakeTemplateRepository.templates {
+		if template.Id == templateId {
+			fakeTemplateRepository.templates = append(fakeTemplateRepository.templates[:i], fakeTemplateRepository.templates[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Template with id %d not found", templateId))
+}
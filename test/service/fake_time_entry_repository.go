@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"sort"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeTimeEntryRepository struct {
+	timeEntries []domain.TimeEntry
+}
+
+func NewFakeTimeEntryRepository(initialTimeEntries []domain.TimeEntry) persistence.ITimeEntryRepository {
+	return &FakeTimeEntryRepository{
+		timeEntries: initialTimeEntries,
+	}
+}
+
+func (fakeTimeEntryRepository *FakeTimeEntryRepository) GetTimeEntriesByTodoId(todoId int) ([]domain.TimeEntry, error) {
+	todoTimeEntries := []domain.TimeEntry{}
+	for _, timeEntry := range fakeTimeEntryRepository.timeEntries {
+		if timeEntry.TodoId == todoId {
+			todoTimeEntries = append(todoTimeEntries, timeEntry)
+		}
+	}
+
+	return sortTimeEntries(todoTimeEntries), nil
+}
+
+func (fakeTimeEntryRepository *FakeTimeEntryRepository) GetTimeEntriesByUserId(userId int, from time.Time, to time.Time) ([]domain.TimeEntry, error) {
+	userTimeEntries := []domain.TimeEntry{}
+	for _, timeEntry := range fakeTimeEntryRepository.timeEntries {
+		if timeEntry.UserId == userId && timeEntry.StartedAt.Before(to) && (timeEntry.EndedAt == nil || timeEntry.EndedAt.After(from)) {
+			userTimeEntries = append(userTimeEntries, timeEntry)
+		}
+	}
+
+	return sortTimeEntries(userTimeEntries), nil
+}
+
+func (fakeTimeEntryRepository *FakeTimeEntryRepository) GetTimeEntryById(timeEntryId int) (domain.TimeEntry, error) {
+	for _, timeEntry := range fakeTimeEntryRepository.timeEntries {
+		if timeEntry.Id == timeEntryId {
+			return timeEntry, nil
+		}
+	}
+
+	return domain.TimeEntry{}, errors.New(fmt.Sprintf("Time entry with id %d not found", timeEntryId))
+}
+
+func (fakeTimeEntryRepository *FakeTimeEntryRepository) GetRunningTimeEntry(userId int) (*domain.TimeEntry, error) {
+	for _, timeEntry := range fakeTimeEntryRepository.timeEntries {
+		if timeEntry.UserId == userId && timeEntry.IsRunning() {
+			return &timeEntry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (fakeTimeEntryRepository *FakeTimeEntryRepository) AddTimeEntry(timeEntry domain.TimeEntry) (domain.TimeEntry, error) {
+	timeEntry.Id = len(fakeTimeEntryRepository.timeEntries) + 1
+	fakeTimeEntryRepository.timeEntries = append(fakeTimeEntryRepository.timeEntries, timeEntry)
+
+	return timeEntry, nil
+}
+
+func (fakeTimeEntryRepository *FakeTimeEntryRepository) StopTimeEntry(timeEntryId int, endedAt time.Time) (domain.TimeEntry, error) {
+	for i, timeEntry := range fakeTimeEntryRepository.timeEntries {
+		if timeEntry.Id == timeEntryId && timeEntry.IsRunning() {
+			fakeTimeEntryRepository.timeEntries[i].EndedAt = &endedAt
+			return fakeTimeEntryRepository.timeEntries[i], nil
+		}
+	}
+
+	return domain.TimeEntry{}, errors.New(fmt.Sprintf("Running timer with id %d not found", timeEntryId))
+}
+
+func (fakeTimeEntryRepository *FakeTimeEntryRepository) DeleteTimeEntry(timeEntryId int) error {
+	for i, timeEntry := range fakeTimeEntryRepository.timeEntries {
+		if timeEntry.Id == timeEntryId {
+			fakeTimeEntryRepository.timeEntries = append(fakeTimeEntryRepository.timeEntries[:i], fakeTimeEntryRepository.timeEntries[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Time entry with id %d not found", timeEntryId))
+}
+
+func sortTimeEntries(timeEntries []domain.TimeEntry) []domain.TimeEntry {
+	sort.SliceStable(timeEntries, func(i, j int) bool {
+		return timeEntries[i].StartedAt.Before(timeEntries[j].StartedAt)
+	})
+
+	return timeEntries
+}
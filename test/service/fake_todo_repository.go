@@ -3,13 +3,20 @@ package service
 import (
 	"fmt"
 	"github.com/pkg/errors"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 	"todo-app--go-gin/domain"
 	"todo-app--go-gin/persistence"
+	"unicode"
 )
 
 type FakeTodoRepository struct {
-	todos []domain.Todo
+	todos        []domain.Todo
+	tags         []domain.Tag
+	dependencies map[int][]int
+	revisions    []domain.TodoRevision
 }
 
 func NewFakeTodoRepository(initialTodos []domain.Todo) persistence.ITodoRepository {
@@ -19,13 +26,20 @@ func NewFakeTodoRepository(initialTodos []domain.Todo) persistence.ITodoReposito
 }
 
 func (fakeTodoRepository *FakeTodoRepository) GetAllTodos() ([]domain.Todo, error) {
-	return fakeTodoRepository.todos, nil
+	var activeTodos []domain.Todo
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.DeletedAt == nil {
+			activeTodos = append(activeTodos, todo)
+		}
+	}
+
+	return activeTodos, nil
 }
 
 func (fakeTodoRepository *FakeTodoRepository) GetTodoById(todoId int) (domain.Todo, error) {
 	for _, todo := range fakeTodoRepository.todos {
-		if todo.Id == todoId {
-			return todo, nil
+		if todo.Id == todoId && todo.DeletedAt == nil {
+			return fakeTodoRepository.withBlockedByIds(todo), nil
 		}
 	}
 
@@ -35,16 +49,116 @@ func (fakeTodoRepository *FakeTodoRepository) GetTodoById(todoId int) (domain.To
 func (fakeTodoRepository *FakeTodoRepository) GetAllTodosByUserId(userId int) ([]domain.Todo, error) {
 	var userTodos []domain.Todo
 	for _, todo := range fakeTodoRepository.todos {
-		if todo.UserId == userId {
-			userTodos = append(userTodos, todo)
+		if todo.UserId == userId && todo.DeletedAt == nil {
+			userTodos = append(userTodos, fakeTodoRepository.withBlockedByIds(todo))
 		}
 	}
 
+	sort.SliceStable(userTodos, func(i, j int) bool {
+		return compareTodos(userTodos[i], userTodos[j], domain.TodoSortByPosition)
+	})
+
 	return userTodos, nil
 }
 
+func (fakeTodoRepository *FakeTodoRepository) GetSharedTodos(userId int, todoIds []int, projectIds []int) ([]domain.Todo, error) {
+	var sharedTodos []domain.Todo
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.UserId != userId && todo.DeletedAt == nil && isSharedTodo(todo, todoIds, projectIds) {
+			sharedTodos = append(sharedTodos, fakeTodoRepository.withBlockedByIds(todo))
+		}
+	}
+
+	sort.SliceStable(sharedTodos, func(i, j int) bool {
+		return compareTodos(sharedTodos[i], sharedTodos[j], domain.TodoSortByPosition)
+	})
+
+	return sharedTodos, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTodosByQuery(todoQuery domain.TodoQuery) ([]domain.Todo, int, error) {
+	var matchedTodos []domain.Todo
+	for _, todo := range fakeTodoRepository.todos {
+		if matchesTodoQuery(todo, todoQuery) {
+			matchedTodos = append(matchedTodos, fakeTodoRepository.withBlockedByIds(todo))
+		}
+	}
+
+	sort.SliceStable(matchedTodos, func(i, j int) bool {
+		if todoQuery.SortDirection == domain.SortDirectionDesc {
+			return compareTodos(matchedTodos[j], matchedTodos[i], todoQuery.SortBy)
+		}
+		return compareTodos(matchedTodos[i], matchedTodos[j], todoQuery.SortBy)
+	})
+
+	total := len(matchedTodos)
+	start := todoQuery.Offset
+	if start > total {
+		start = total
+	}
+	end := start + todoQuery.Limit
+	if end > total {
+		end = total
+	}
+
+	return matchedTodos[start:end], total, nil
+}
+
+// SearchTodos is a naive stand-in for the full-text search: every search word must be a
+// case-insensitive prefix of a word in the title or description, and title matches rank higher.
+func (fakeTodoRepository *FakeTodoRepository) SearchTodos(todoQuery domain.TodoQuery) ([]domain.TodoSearchResult, int, error) {
+	searchWords := splitSearchWords(todoQuery.Search)
+	var searchResults []domain.TodoSearchResult
+	for _, todo := range fakeTodoRepository.todos {
+		if len(searchWords) == 0 || !matchesTodoQuery(todo, todoQuery) {
+			continue
+		}
+
+		rank := 0.0
+		for _, searchWord := range searchWords {
+			if hasWordWithPrefix(todo.Title, searchWord) {
+				rank += 1
+			}
+			if hasWordWithPrefix(todo.Description, searchWord) {
+				rank += 0.4
+			}
+		}
+
+		searchResults = append(searchResults, domain.TodoSearchResult{
+			Todo:                 todo,
+			Rank:                 rank,
+			TitleHighlight:       highlightWords(todo.Title, searchWords),
+			DescriptionHighlight: highlightWords(todo.Description, searchWords),
+		})
+	}
+
+	sort.SliceStable(searchResults, func(i, j int) bool {
+		first, second := searchResults[i], searchResults[j]
+		if todoQuery.SortDirection == domain.SortDirectionDesc {
+			first, second = second, first
+		}
+		if todoQuery.SortBy == domain.TodoSortByRelevance && first.Rank != second.Rank {
+			return first.Rank < second.Rank
+		}
+		return compareTodos(first.Todo, second.Todo, todoQuery.SortBy)
+	})
+
+	total := len(searchResults)
+	start := min(todoQuery.Offset, total)
+	end := min(start+todoQuery.Limit, total)
+
+	return searchResults[start:end], total, nil
+}
+
 func (fakeTodoRepository *FakeTodoRepository) AddTodo(todo domain.Todo) (domain.Todo, error) {
 	todo.Id = len(fakeTodoRepository.todos) + 1
+	todo.Version = 1
+	for _, existingTodo := range fakeTodoRepository.todos {
+		if existingTodo.UserId == todo.UserId {
+			todo.Position = max(todo.Position, existingTodo.Position)
+		}
+	}
+	todo.Position += domain.TodoPositionGap
 	fakeTodoRepository.todos = append(fakeTodoRepository.todos, todo)
 
 	return todo, nil
@@ -57,6 +171,7 @@ func (fakeTodoRepository *FakeTodoRepository) UpdateTodo(todoId int, updatedTodo
 			updatedTodo.UserId = todo.UserId
 			updatedTodo.CreatedAt = todo.CreatedAt
 			updatedTodo.UpdatedAt = time.Now()
+			updatedTodo.Version = todo.Version + 1
 			fakeTodoRepository.todos[i] = updatedTodo
 			return fakeTodoRepository.todos[i], nil
 		}
@@ -65,13 +180,714 @@ func (fakeTodoRepository *FakeTodoRepository) UpdateTodo(todoId int, updatedTodo
 	return domain.Todo{}, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
 }
 
+func (fakeTodoRepository *FakeTodoRepository) UpdateTodoFields(todoId int, updatedTodo domain.Todo, fields []string) (int, error) {
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId && todo.DeletedAt == nil {
+			for _, field := range fields {
+				switch field {
+				case domain.TodoFieldTitle:
+					fakeTodoRepository.todos[i].Title = updatedTodo.Title
+				case domain.TodoFieldDescription:
+					fakeTodoRepository.todos[i].Description = updatedTodo.Description
+				case domain.TodoFieldIsCompleted:
+					fakeTodoRepository.todos[i].IsCompleted = updatedTodo.IsCompleted
+				case domain.TodoFieldDueDate:
+					fakeTodoRepository.todos[i].DueDate = updatedTodo.DueDate
+				case domain.TodoFieldDueTime:
+					fakeTodoRepository.todos[i].DueTime = updatedTodo.DueTime
+				case domain.TodoFieldDueTimezone:
+					fakeTodoRepository.todos[i].DueTimezone = updatedTodo.DueTimezone
+				case domain.TodoFieldRecurrenceRule:
+					fakeTodoRepository.todos[i].RecurrenceRule = updatedTodo.RecurrenceRule
+				case domain.TodoFieldPriority:
+					fakeTodoRepository.todos[i].Priority = updatedTodo.Priority
+				case domain.TodoFieldEstimate:
+					fakeTodoRepository.todos[i].EstimateMinutes = updatedTodo.EstimateMinutes
+				case domain.TodoFieldCompletedAt:
+					fakeTodoRepository.todos[i].CompletedAt = updatedTodo.CompletedAt
+				case domain.TodoFieldCompletedBy:
+					fakeTodoRepository.todos[i].CompletedBy = updatedTodo.CompletedBy
+				}
+			}
+			fakeTodoRepository.todos[i].UpdatedAt = updatedTodo.UpdatedAt
+			fakeTodoRepository.todos[i].Version++
+			return fakeTodoRepository.todos[i].Version, nil
+		}
+	}
+
+	return 0, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) LockTodoVersion(todoId int) (int, error) {
+	todo, err := fakeTodoRepository.GetTodoById(todoId)
+	if err != nil {
+		return 0, err
+	}
+
+	return todo.Version, nil
+}
+
 func (fakeTodoRepository *FakeTodoRepository) DeleteTodo(todoId int) error {
+	if _, err := fakeTodoRepository.GetTodoById(todoId); err != nil {
+		return err
+	}
+
+	deletedAt := time.Now()
+	subtreeIds := []int{todoId}
+	for i := 0; i < len(subtreeIds); i++ {
+		for j, todo := range fakeTodoRepository.todos {
+			if todo.Id == subtreeIds[i] || (todo.ParentId != nil && *todo.ParentId == subtreeIds[i]) {
+				if todo.DeletedAt == nil {
+					fakeTodoRepository.todos[j].DeletedAt = &deletedAt
+					if todo.Id != subtreeIds[i] {
+						subtreeIds = append(subtreeIds, todo.Id)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) AddTodoTags(todoId int, userId int, tagIds []int) error {
+	var tagsToAdd []domain.Tag
+	for _, tagId := range tagIds {
+		found := false
+		for _, tag := range fakeTodoRepository.tags {
+			if tag.Id == tagId && tag.UserId == userId {
+				tagsToAdd = append(tagsToAdd, tag)
+				found = true
+			}
+		}
+		if !found {
+			return errors.New("One or more tags not found")
+		}
+	}
+
 	for i, todo := range fakeTodoRepository.todos {
 		if todo.Id == todoId {
-			fakeTodoRepository.todos = append(fakeTodoRepository.todos[:i], fakeTodoRepository.todos[i+1:]...)
+			for _, tag := range tagsToAdd {
+				if !hasTag(todo, tag.Name) {
+					fakeTodoRepository.todos[i].Tags = append(fakeTodoRepository.todos[i].Tags, tag)
+				}
+			}
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) RemoveTodoTags(todoId int, tagIds []int) error {
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId {
+			var remainingTags []domain.Tag
+			for _, tag := range todo.Tags {
+				if !containsId(tagIds, tag.Id) {
+					remainingTags = append(remainingTags, tag)
+				}
+			}
+			fakeTodoRepository.todos[i].Tags = remainingTags
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) MoveTodoToProject(todoId int, projectId *int) error {
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId && todo.DeletedAt == nil {
+			fakeTodoRepository.todos[i].ProjectId = projectId
+			fakeTodoRepository.todos[i].Version++
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) AssignTodo(todoId int, updatedTodo domain.Todo) (int, error) {
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId && todo.DeletedAt == nil {
+			fakeTodoRepository.todos[i].AssigneeId = updatedTodo.AssigneeId
+			fakeTodoRepository.todos[i].AssignedBy = updatedTodo.AssignedBy
+			fakeTodoRepository.todos[i].AssignedAt = updatedTodo.AssignedAt
+			fakeTodoRepository.todos[i].UpdatedAt = updatedTodo.UpdatedAt
+			fakeTodoRepository.todos[i].Version++
+			return fakeTodoRepository.todos[i].Version, nil
+		}
+	}
+
+	return 0, errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetSubtasksByParentIds(parentIds []int) ([]domain.Todo, error) {
+	var subtasks []domain.Todo
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.ParentId != nil && containsId(parentIds, *todo.ParentId) && todo.DeletedAt == nil {
+			subtasks = append(subtasks, fakeTodoRepository.withBlockedByIds(todo))
+		}
+	}
+
+	return subtasks, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) SetTodoParent(todoId int, parentId *int) error {
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId {
+			fakeTodoRepository.todos[i].ParentId = parentId
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) SetTodosCompletion(todoIds []int, isCompleted bool, status string, userId int) error {
+	for i, todo := range fakeTodoRepository.todos {
+		if containsId(todoIds, todo.Id) && todo.IsCompleted != isCompleted {
+			fakeTodoRepository.todos[i].IsCompleted = isCompleted
+			fakeTodoRepository.todos[i].Status = status
+			fakeTodoRepository.todos[i].CompletedAt = nil
+			fakeTodoRepository.todos[i].SyncCompletion(userId, time.Now())
+		}
+	}
+
+	return nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTodoStatusesInUse(userId int) ([]string, error) {
+	var statuses []string
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.UserId == userId && todo.DeletedAt == nil && !slices.Contains(statuses, todo.Status) {
+			statuses = append(statuses, todo.Status)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTodoDependencies(todoId int) ([]domain.Todo, error) {
+	var dependencies []domain.Todo
+	for _, dependsOnId := range fakeTodoRepository.dependencies[todoId] {
+		if dependency, err := fakeTodoRepository.GetTodoById(dependsOnId); err == nil {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	return dependencies, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) AddTodoDependency(todoId int, dependsOnId int) error {
+	if fakeTodoRepository.dependencies == nil {
+		fakeTodoRepository.dependencies = map[int][]int{}
+	}
+	if !containsId(fakeTodoRepository.dependencies[todoId], dependsOnId) {
+		fakeTodoRepository.dependencies[todoId] = append(fakeTodoRepository.dependencies[todoId], dependsOnId)
+	}
+
+	return nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) RemoveTodoDependency(todoId int, dependsOnId int) error {
+	dependsOnIds := fakeTodoRepository.dependencies[todoId]
+	for i, id := range dependsOnIds {
+		if id == dependsOnId {
+			fakeTodoRepository.dependencies[todoId] = append(dependsOnIds[:i], dependsOnIds[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Todo with id %d does not depend on todo with id %d", todoId, dependsOnId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) HasDependencyPath(fromTodoId int, toTodoId int) (bool, error) {
+	visited := map[int]bool{}
+	pending := append([]int{}, fakeTodoRepository.dependencies[fromTodoId]...)
+	for len(pending) > 0 {
+		todoId := pending[0]
+		pending = pending[1:]
+		if todoId == toTodoId {
+			return true, nil
+		}
+		if !visited[todoId] {
+			visited[todoId] = true
+			pending = append(pending, fakeTodoRepository.dependencies[todoId]...)
+		}
+	}
+
+	return false, nil
+}
+
+// withBlockedByIds fills in the open todos that todo depends on, as the repository does on every read.
+func (fakeTodoRepository *FakeTodoRepository) withBlockedByIds(todo domain.Todo) domain.Todo {
+	todo.BlockedByIds = nil
+	for _, dependsOnId := range fakeTodoRepository.dependencies[todo.Id] {
+		for _, dependency := range fakeTodoRepository.todos {
+			if dependency.Id == dependsOnId && !dependency.IsCompleted && dependency.DeletedAt == nil {
+				todo.BlockedByIds = append(todo.BlockedByIds, dependsOnId)
+			}
+		}
+	}
+
+	return todo
+}
+
+func (fakeTodoRepository *FakeTodoRepository) LockTodoDependencies(userId int) error {
+	return nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) HasSeriesOccurrence(seriesId int, occurrenceIndex int) (bool, error) {
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.SeriesId != nil && *todo.SeriesId == seriesId && todo.OccurrenceIndex == occurrenceIndex {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetNeighbourPosition(userId int, excludedTodoId int, anchorTodoId int, before bool) (*float64, error) {
+	anchorTodo, err := fakeTodoRepository.GetTodoById(anchorTodoId)
+	if err != nil {
+		return nil, err
+	}
+
+	var neighbour *domain.Todo
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.UserId != userId || todo.Id == excludedTodoId || todo.Id == anchorTodoId || todo.DeletedAt != nil {
+			continue
+		}
+		if before && compareTodos(todo, anchorTodo, domain.TodoSortByPosition) && (neighbour == nil || compareTodos(*neighbour, todo, domain.TodoSortByPosition)) {
+			neighbour = &fakeTodoRepository.todos[i]
+		}
+		if !before && compareTodos(anchorTodo, todo, domain.TodoSortByPosition) && (neighbour == nil || compareTodos(todo, *neighbour, domain.TodoSortByPosition)) {
+			neighbour = &fakeTodoRepository.todos[i]
+		}
+	}
+
+	if neighbour == nil {
+		return nil, nil
+	}
+
+	return &neighbour.Position, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) SetTodoPosition(todoId int, position float64) error {
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId && todo.DeletedAt == nil {
+			fakeTodoRepository.todos[i].Position = position
+			fakeTodoRepository.todos[i].Version++
 			return nil
 		}
 	}
 
 	return errors.New(fmt.Sprintf("Todo with id %d not found", todoId))
 }
+
+func (fakeTodoRepository *FakeTodoRepository) RebalanceTodoPositions(userId int) error {
+	userTodos, _ := fakeTodoRepository.GetAllTodosByUserId(userId)
+	for rank, userTodo := range userTodos {
+		for i, todo := range fakeTodoRepository.todos {
+			position := float64(rank+1) * domain.TodoPositionGap
+			if todo.Id == userTodo.Id && todo.Position != position {
+				fakeTodoRepository.todos[i].Position = position
+				fakeTodoRepository.todos[i].Version++
+			}
+		}
+	}
+
+	return nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTrashedTodosByUserId(userId int) ([]domain.Todo, error) {
+	var trashedTodos []domain.Todo
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.UserId == userId && todo.DeletedAt != nil {
+			trashedTodos = append(trashedTodos, todo)
+		}
+	}
+
+	return trashedTodos, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTrashedTodoById(todoId int) (domain.Todo, error) {
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId && todo.DeletedAt != nil {
+			return todo, nil
+		}
+	}
+
+	return domain.Todo{}, errors.New(fmt.Sprintf("Todo with id %d not found in trash", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) RestoreTodo(todoId int) error {
+	trashedTodo, err := fakeTodoRepository.GetTrashedTodoById(todoId)
+	if err != nil {
+		return err
+	}
+
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.DeletedAt != nil && todo.DeletedAt.Equal(*trashedTodo.DeletedAt) {
+			fakeTodoRepository.todos[i].DeletedAt = nil
+		}
+	}
+
+	return nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) PermanentlyDeleteTodo(todoId int) error {
+	for i, todo := range fakeTodoRepository.todos {
+		if todo.Id == todoId && todo.DeletedAt != nil {
+			fakeTodoRepository.todos = append(fakeTodoRepository.todos[:i], fakeTodoRepository.todos[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Todo with id %d not found in trash", todoId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) PurgeTrashedTodos(deletedBefore time.Time) (int64, error) {
+	var remainingTodos []domain.Todo
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.DeletedAt == nil || !todo.DeletedAt.Before(deletedBefore) {
+			remainingTodos = append(remainingTodos, todo)
+		}
+	}
+
+	purgedCount := int64(len(fakeTodoRepository.todos) - len(remainingTodos))
+	fakeTodoRepository.todos = remainingTodos
+
+	return purgedCount, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) AddTodoRevision(revision domain.TodoRevision) error {
+	revision.Id = len(fakeTodoRepository.revisions) + 1
+	fakeTodoRepository.revisions = append(fakeTodoRepository.revisions, revision)
+
+	return nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTodoRevisions(todoId int, limit int, offset int) ([]domain.TodoRevision, int, error) {
+	var todoRevisions []domain.TodoRevision
+	for i := len(fakeTodoRepository.revisions) - 1; i >= 0; i-- {
+		if fakeTodoRepository.revisions[i].TodoId == todoId {
+			todoRevisions = append(todoRevisions, fakeTodoRepository.revisions[i])
+		}
+	}
+
+	total := len(todoRevisions)
+	start := min(offset, total)
+	end := min(offset+limit, total)
+
+	return todoRevisions[start:end], total, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTodoRevisionById(revisionId int) (domain.TodoRevision, error) {
+	for _, revision := range fakeTodoRepository.revisions {
+		if revision.Id == revisionId {
+			return revision, nil
+		}
+	}
+
+	return domain.TodoRevision{}, errors.New(fmt.Sprintf("Revision with id %d not found", revisionId))
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetTodoStats(userId int, now time.Time, timezone string) (domain.TodoStats, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return domain.TodoStats{}, err
+	}
+
+	isCompleted := false
+	overdueQuery := domain.TodoQuery{UserId: userId, IsCompleted: &isCompleted, DueBefore: &now, Timezone: timezone}
+
+	var todoStats domain.TodoStats
+	var totalTimeToComplete time.Duration
+	timedCount := 0
+	completionDays := map[time.Time]bool{}
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.UserId != userId || todo.DeletedAt != nil {
+			continue
+		}
+
+		if !todo.IsCompleted {
+			todoStats.OpenCount++
+			if matchesTodoQuery(todo, overdueQuery) {
+				todoStats.OverdueCount++
+			}
+			continue
+		}
+
+		todoStats.CompletedCount++
+		if todo.CompletedAt == nil {
+			continue
+		}
+
+		timedCount++
+		totalTimeToComplete += todo.CompletedAt.Sub(todo.CreatedAt)
+		completionDays[localDate(*todo.CompletedAt, location)] = true
+	}
+
+	if timedCount > 0 {
+		averageTimeToComplete := totalTimeToComplete / time.Duration(timedCount)
+		todoStats.AverageTimeToComplete = &averageTimeToComplete
+	}
+
+	day := localDate(now, location)
+	if !completionDays[day] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for completionDays[day] {
+		todoStats.CurrentStreakDays++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return todoStats, nil
+}
+
+func (fakeTodoRepository *FakeTodoRepository) GetCompletionCounts(userId int, from time.Time, to time.Time, period string, timezone string) ([]domain.CompletionCount, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	countsByPeriodStart := map[time.Time]int{}
+	for _, todo := range fakeTodoRepository.todos {
+		if todo.UserId != userId || todo.DeletedAt != nil || !todo.IsCompleted || todo.CompletedAt == nil {
+			continue
+		}
+
+		day := localDate(*todo.CompletedAt, location)
+		if day.Before(from) || day.After(to) {
+			continue
+		}
+
+		if period == domain.StatsPeriodWeek {
+			day = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		}
+		countsByPeriodStart[day]++
+	}
+
+	completionCounts := []domain.CompletionCount{}
+	for periodStart, count := range countsByPeriodStart {
+		completionCounts = append(completionCounts, domain.CompletionCount{PeriodStart: periodStart, Count: count})
+	}
+	sort.Slice(completionCounts, func(i, j int) bool {
+		return completionCounts[i].PeriodStart.Before(completionCounts[j].PeriodStart)
+	})
+
+	return completionCounts, nil
+}
+
+// localDate returns the date of moment in location as midnight UTC, the way dates come back from the database.
+func localDate(moment time.Time, location *time.Location) time.Time {
+	year, month, day := moment.In(location).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// WithTransaction snapshots the todos and their history and restores them when fn fails, mimicking a rollback.
+func (fakeTodoRepository *FakeTodoRepository) WithTransaction(fn func(txTodoRepository persistence.ITodoRepository) error) error {
+	snapshot := make([]domain.Todo, len(fakeTodoRepository.todos))
+	copy(snapshot, fakeTodoRepository.todos)
+	revisionCount := len(fakeTodoRepository.revisions)
+
+	if err := fn(fakeTodoRepository); err != nil {
+		fakeTodoRepository.todos = snapshot
+		fakeTodoRepository.revisions = fakeTodoRepository.revisions[:revisionCount]
+		return err
+	}
+
+	return nil
+}
+
+func isSharedTodo(todo domain.Todo, todoIds []int, projectIds []int) bool {
+	return slices.Contains(todoIds, todo.Id) || (todo.ProjectId != nil && slices.Contains(projectIds, *todo.ProjectId))
+}
+
+func matchesTodoQuery(todo domain.Todo, todoQuery domain.TodoQuery) bool {
+	isShared := todo.UserId != todoQuery.UserId && isSharedTodo(todo, todoQuery.SharedTodoIds, todoQuery.SharedProjectIds)
+	if (todo.UserId != todoQuery.UserId && !isShared) || (todoQuery.SharedOnly && !isShared) || todo.DeletedAt != nil {
+		return false
+	}
+	if todoQuery.InboxOnly && todo.ProjectId != nil {
+		return false
+	}
+	if todoQuery.ProjectId != nil && (todo.ProjectId == nil || *todo.ProjectId != *todoQuery.ProjectId) {
+		return false
+	}
+	if todoQuery.TopLevelOnly && todo.ParentId != nil {
+		return false
+	}
+	if todoQuery.ParentId != nil && (todo.ParentId == nil || *todo.ParentId != *todoQuery.ParentId) {
+		return false
+	}
+	if todoQuery.IsCompleted != nil && todo.IsCompleted != *todoQuery.IsCompleted {
+		return false
+	}
+	if todoQuery.Priority != nil && todo.Priority != *todoQuery.Priority {
+		return false
+	}
+	if len(todoQuery.Priorities) > 0 && !slices.Contains(todoQuery.Priorities, todo.Priority) {
+		return false
+	}
+	if todoQuery.Status != "" && todo.Status != todoQuery.Status {
+		return false
+	}
+	if todoQuery.AssigneeId != nil && (todo.AssigneeId == nil || *todo.AssigneeId != *todoQuery.AssigneeId) {
+		return false
+	}
+	if todoQuery.Unassigned && todo.AssigneeId != nil {
+		return false
+	}
+	if todoQuery.CreatedFrom != nil && todo.CreatedAt.Before(*todoQuery.CreatedFrom) {
+		return false
+	}
+	if todoQuery.CreatedTo != nil && todo.CreatedAt.After(*todoQuery.CreatedTo) {
+		return false
+	}
+	if todoQuery.CompletedFrom != nil && (todo.CompletedAt == nil || todo.CompletedAt.Before(*todoQuery.CompletedFrom)) {
+		return false
+	}
+	if todoQuery.CompletedTo != nil && (todo.CompletedAt == nil || todo.CompletedAt.After(*todoQuery.CompletedTo)) {
+		return false
+	}
+	if todoQuery.UpdatedFrom != nil && todo.UpdatedAt.Before(*todoQuery.UpdatedFrom) {
+		return false
+	}
+	if todoQuery.UpdatedTo != nil && todo.UpdatedAt.After(*todoQuery.UpdatedTo) {
+		return false
+	}
+	if todoQuery.Title != "" && !strings.Contains(strings.ToLower(todo.Title), strings.ToLower(todoQuery.Title)) {
+		return false
+	}
+	if todoQuery.Search != "" {
+		for _, searchWord := range splitSearchWords(todoQuery.Search) {
+			if !hasWordWithPrefix(todo.Title, searchWord) && !hasWordWithPrefix(todo.Description, searchWord) {
+				return false
+			}
+		}
+	}
+	if todoQuery.DueFrom != nil && (todo.DueDate == nil || todo.DueDate.Before(*todoQuery.DueFrom)) {
+		return false
+	}
+	if todoQuery.DueTo != nil && (todo.DueDate == nil || todo.DueDate.After(*todoQuery.DueTo)) {
+		return false
+	}
+	if todoQuery.DueBefore != nil {
+		location, _ := time.LoadLocation(todoQuery.Timezone)
+		dueMoment, hasDueDate := todo.DueMoment(location)
+		if !hasDueDate || !dueMoment.Before(*todoQuery.DueBefore) {
+			return false
+		}
+	}
+	if len(todoQuery.Tags) > 0 {
+		matchedTagCount := 0
+		for _, tagName := range todoQuery.Tags {
+			if hasTag(todo, tagName) {
+				matchedTagCount++
+			}
+		}
+		if matchedTagCount == 0 || (todoQuery.TagMode == domain.TagMatchAll && matchedTagCount != len(todoQuery.Tags)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compareTodos(first domain.Todo, second domain.Todo, sortBy string) bool {
+	switch sortBy {
+	case domain.TodoSortByUpdatedAt:
+		if !first.UpdatedAt.Equal(second.UpdatedAt) {
+			return first.UpdatedAt.Before(second.UpdatedAt)
+		}
+	case domain.TodoSortByTitle:
+		if first.Title != second.Title {
+			return first.Title < second.Title
+		}
+	case domain.TodoSortByPosition:
+		if first.Position != second.Position {
+			return first.Position < second.Position
+		}
+	case domain.TodoSortByPriority:
+		if first.Priority != second.Priority {
+			return first.Priority < second.Priority
+		}
+	case domain.TodoSortByDueDate:
+		if first.DueDate == nil || second.DueDate == nil {
+			if first.DueDate != second.DueDate {
+				return second.DueDate == nil
+			}
+		} else if !first.DueDate.Equal(*second.DueDate) {
+			return first.DueDate.Before(*second.DueDate)
+		}
+	case domain.TodoSortByCompletedAt:
+		if first.CompletedAt == nil || second.CompletedAt == nil {
+			if first.CompletedAt != second.CompletedAt {
+				return second.CompletedAt == nil
+			}
+		} else if !first.CompletedAt.Equal(*second.CompletedAt) {
+			return first.CompletedAt.Before(*second.CompletedAt)
+		}
+	default:
+		if !first.CreatedAt.Equal(second.CreatedAt) {
+			return first.CreatedAt.Before(second.CreatedAt)
+		}
+	}
+
+	return first.Id < second.Id
+}
+
+func splitSearchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(character rune) bool {
+		return !unicode.IsLetter(character) && !unicode.IsDigit(character)
+	})
+}
+
+func hasWordWithPrefix(text string, prefix string) bool {
+	for _, word := range splitSearchWords(text) {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func highlightWords(text string, searchWords []string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		for _, searchWord := range searchWords {
+			if hasWordWithPrefix(word, searchWord) {
+				words[i] = "<mark>" + word + "</mark>"
+				break
+			}
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+func hasTag(todo domain.Todo, tagName string) bool {
+	for _, tag := range todo.Tags {
+		if tag.Name == tagName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsId(ids []int, id int) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+
+	return false
+}
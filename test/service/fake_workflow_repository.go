@@ -0,0 +1,38 @@
+package service
+
+import (
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/persistence"
+)
+
+type FakeWorkflowRepository struct {
+	workflows []domain.Workflow
+}
+
+func NewFakeWorkflowRepository(initialWorkflows []domain.Workflow) persistence.IWorkflowRepository {
+	return &FakeWorkflowRepository{
+		workflows: initialWorkflows,
+	}
+}
+
+func (fakeWorkflowRepository *FakeWorkflowRepository) GetWorkflowByUserId(userId int) (domain.Workflow, error) {
+	for _, workflow := range fakeWorkflowRepository.workflows {
+		if workflow.UserId == userId {
+			return workflow, nil
+		}
+	}
+
+	return domain.Workflow{UserId: userId}, nil
+}
+
+func (fakeWorkflowRepository *FakeWorkflowRepository) SaveWorkflow(workflow domain.Workflow) error {
+	for i, existingWorkflow := range fakeWorkflowRepository.workflows {
+		if existingWorkflow.UserId == workflow.UserId {
+			fakeWorkflowRepository.workflows[i] = workflow
+			return nil
+		}
+	}
+
+	fakeWorkflowRepository.workflows = append(fakeWorkflowRepository.workflows, workflow)
+	return nil
+}
@@ -77,10 +77,9 @@ func TestMain(m *testing.M) {
 		},
 	}
 
-	fakeTodoRepository := NewFakeTodoRepository(initialTodos)
-	fakeUserRepository := NewFakeUserRepository(initialUsers)
-	todoService = service.NewTodoService(fakeTodoRepository)
-	userService = service.NewUserService(fakeUserRepository)
+	testServices := NewTestServices(TestFixture{Todos: initialTodos, Users: initialUsers})
+	todoService = testServices.TodoService
+	userService = testServices.UserService
 	exitCode := m.Run()
 	os.Exit(exitCode)
 }
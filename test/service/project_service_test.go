@@ -0,0 +1,126 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+func Test_ShouldGetAllProjectsWithoutArchived(t *testing.T) {
+	projectService := NewTestServices(TestFixture{
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Work", Position: 1},
+			{Id: 2, UserId: 1, Name: "Old", Position: 2, IsArchived: true},
+			{Id: 3, UserId: 2, Name: "Home", Position: 1},
+		},
+	}).ProjectService
+
+	t.Run("ShouldGetAllProjectsWithoutArchived", func(t *testing.T) {
+		projects, _ := projectService.GetAllProjects(1, false)
+		assert.Equal(t, 1, len(projects))
+		projects, _ = projectService.GetAllProjects(1, true)
+		assert.Equal(t, 2, len(projects))
+	})
+}
+
+func Test_ShouldNotDeleteProjectUnsupportedMode(t *testing.T) {
+	projectService := NewTestServices(TestFixture{
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Work", Position: 1},
+			{Id: 2, UserId: 1, Name: "Old", Position: 2, IsArchived: true},
+			{Id: 3, UserId: 2, Name: "Home", Position: 1},
+		},
+	}).ProjectService
+
+	t.Run("ShouldNotDeleteProjectUnsupportedMode", func(t *testing.T) {
+		err := projectService.DeleteProject(1, 1, "archive")
+		assert.Equal(t, "Unsupported delete mode archive", err.Error())
+	})
+}
+
+func Test_ShouldNotDeleteProjectOfAnotherUser(t *testing.T) {
+	projectService := NewTestServices(TestFixture{
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Work", Position: 1},
+			{Id: 2, UserId: 1, Name: "Old", Position: 2, IsArchived: true},
+			{Id: 3, UserId: 2, Name: "Home", Position: 1},
+		},
+	}).ProjectService
+
+	t.Run("ShouldNotDeleteProjectOfAnotherUser", func(t *testing.T) {
+		err := projectService.DeleteProject(1, 3, "")
+		assert.Equal(t, "This project is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldMoveTodoToProject(t *testing.T) {
+	projectId := 1
+	projectTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report"},
+		},
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Work", Position: 1},
+			{Id: 2, UserId: 1, Name: "Old", Position: 2, IsArchived: true},
+			{Id: 3, UserId: 2, Name: "Home", Position: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldMoveTodoToProject", func(t *testing.T) {
+		todo, err := projectTodoService.MoveTodoToProject(1, 1, request.TodoMove{ProjectId: &projectId})
+		assert.Nil(t, err)
+		assert.Equal(t, &projectId, todo.ProjectId)
+		todoPage, _ := projectTodoService.GetTodosByFilter(1, request.TodoFilter{ProjectId: "1"})
+		assert.Equal(t, 1, todoPage.Total)
+		todoPage, _ = projectTodoService.GetTodosByFilter(1, request.TodoFilter{ProjectId: "inbox"})
+		assert.Equal(t, 0, todoPage.Total)
+	})
+}
+
+func Test_ShouldMoveTodoToProjectWithVersion(t *testing.T) {
+	projectId := 1
+	projectTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report", Version: 1},
+		},
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Work", Position: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldRejectMoveWithStaleVersion", func(t *testing.T) {
+		_, err := projectTodoService.MoveTodoToProject(1, 1, request.TodoMove{ProjectId: &projectId, VersionPrecondition: request.VersionPrecondition{ExpectedVersion: intPointer(2)}})
+		assert.Equal(t, service.ErrTodoVersionMismatch, err)
+	})
+
+	t.Run("ShouldReturnNewVersionAndRecordMove", func(t *testing.T) {
+		todo, err := projectTodoService.MoveTodoToProject(1, 1, request.TodoMove{ProjectId: &projectId, VersionPrecondition: request.VersionPrecondition{ExpectedVersion: intPointer(1)}})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, todo.Version)
+
+		historyPage, _ := projectTodoService.GetTodoHistory(1, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionMoved}, revisionActions(historyPage.Revisions))
+		assert.Equal(t, []domain.TodoFieldChange{{Field: domain.TodoFieldProjectId, OldValue: nil, NewValue: 1}}, historyPage.Revisions[0].Changes)
+	})
+}
+
+func Test_ShouldNotMoveTodoToArchivedProject(t *testing.T) {
+	projectId := 2
+	projectTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report"},
+		},
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Work", Position: 1},
+			{Id: 2, UserId: 1, Name: "Old", Position: 2, IsArchived: true},
+			{Id: 3, UserId: 2, Name: "Home", Position: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldNotMoveTodoToArchivedProject", func(t *testing.T) {
+		_, err := projectTodoService.MoveTodoToProject(1, 1, request.TodoMove{ProjectId: &projectId})
+		assert.Equal(t, "Todos cannot be added to an archived project", err.Error())
+	})
+}
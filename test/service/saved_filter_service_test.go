@@ -0,0 +1,225 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+// savedFilterTestNow is a Wednesday.
+var savedFilterTestNow = time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC)
+
+func Test_ShouldAddSavedFilter(t *testing.T) {
+	savedFilterService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Ship release", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 8), Tags: []domain.Tag{{Id: 1, UserId: 1, Name: "work"}}},
+			{Id: 2, UserId: 1, Title: "Fix login bug", Priority: domain.PriorityUrgent, DueDate: datePointer(2024, 3, 10)},
+			{Id: 3, UserId: 1, Title: "Write docs", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 12)},
+			{Id: 4, UserId: 1, Title: "Plan offsite", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 7), IsCompleted: true},
+			{Id: 5, UserId: 1, Title: "Pay invoice", Priority: domain.PriorityLow, DueDate: datePointer(2024, 3, 1)},
+			{Id: 6, UserId: 2, Title: "Review budget", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+			{Id: 7, UserId: 3, Title: "Private todo", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(6), UserId: 1, Permission: domain.PermissionViewer},
+		},
+		Now: savedFilterTestNow,
+	}).SavedFilterService
+
+	t.Run("ShouldAddSavedFilter", func(t *testing.T) {
+		savedFilter, err := savedFilterService.AddSavedFilter(request.SavedFilterCreate{UserId: 1, Name: " Hot this week ", Expression: "priority:high,urgent AND due:this-week done:false"})
+		assert.Nil(t, err)
+		assert.Equal(t, "Hot this week", savedFilter.Name)
+
+		savedFilters, _ := savedFilterService.GetSavedFilters(1)
+		assert.Equal(t, 1, len(savedFilters))
+	})
+
+	t.Run("ShouldNotAddInvalidSavedFilter", func(t *testing.T) {
+		testCases := []struct {
+			name          string
+			filterName    string
+			expression    string
+			expectedError string
+		}{
+			{"EmptyName", " ", "done:false", "Saved filter name must be between 1 and 100 characters long"},
+			{"DuplicateName", "hot THIS week", "done:false", "A saved filter named hot THIS week already exists"},
+			{"EmptyExpression", "Open", " ", "Filter expression cannot be empty"},
+			{"NotFieldValue", "Open", "urgent", "Filter term urgent must be in field:value format"},
+			{"Or", "Open", "priority:high OR priority:urgent", "OR is only supported between values of one field, as in priority:high,urgent"},
+			{"RepeatedField", "Open", "tag:work tag:home", "Filter field tag can only be used once"},
+			{"UnterminatedQuote", "Open", `title:"weekly report`, "Filter expression has an unterminated quote"},
+			{"UnknownField", "Open", "color:red", "Unknown filter field color"},
+			{"UnknownPriority", "Open", "priority:high,asap", "Unsupported priority asap"},
+			{"SeveralValues", "Open", "done:true,false", "Filter field done takes a single value"},
+			{"InvalidDone", "Open", "done:maybe", "Filter field done must be true or false"},
+			{"UnknownDue", "Open", "due:someday", "Unsupported due value someday"},
+			{"ReversedDueRange", "Open", "due:2024-03-10..2024-03-01", "dueFrom must be before dueTo"},
+			{"InvalidSort", "Open", "sort:color", "Unsupported sort field color"},
+			{"InvalidAssignee", "Open", "assignee:someone", "Assignee must be a user id, me or none"},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				_, err := savedFilterService.AddSavedFilter(request.SavedFilterCreate{UserId: 1, Name: testCase.filterName, Expression: testCase.expression})
+				assert.Equal(t, testCase.expectedError, err.Error())
+			})
+		}
+	})
+}
+
+func Test_ShouldGetSavedFilterTodos(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expression  string
+		expectedIds []int
+	}{
+		{"HighPriorityDueThisWeekNotDone", "priority:high,urgent due:this-week done:false", []int{1, 2, 6}},
+		{"OnlyOwnTodos", "priority:high,urgent due:this-week done:false owner:me", []int{1, 2}},
+		{"Overdue", "due:overdue done:false", []int{5}},
+		{"NextWeek", "due:next-week", []int{3}},
+		{"NextDays", "due:next-2-days", []int{4}},
+		{"DueRange", "due:2024-03-01..2024-03-07", []int{4, 5}},
+		{"TaggedAndTitle", `tag:work,home title:"ship"`, []int{1}},
+		{"SortedByDueDate", "priority:high sort:dueDate order:desc", []int{3, 6, 1, 4}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			savedFilterService := NewTestServices(TestFixture{
+				Todos: []domain.Todo{
+					{Id: 1, UserId: 1, Title: "Ship release", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 8), Tags: []domain.Tag{{Id: 1, UserId: 1, Name: "work"}}},
+					{Id: 2, UserId: 1, Title: "Fix login bug", Priority: domain.PriorityUrgent, DueDate: datePointer(2024, 3, 10)},
+					{Id: 3, UserId: 1, Title: "Write docs", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 12)},
+					{Id: 4, UserId: 1, Title: "Plan offsite", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 7), IsCompleted: true},
+					{Id: 5, UserId: 1, Title: "Pay invoice", Priority: domain.PriorityLow, DueDate: datePointer(2024, 3, 1)},
+					{Id: 6, UserId: 2, Title: "Review budget", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+					{Id: 7, UserId: 3, Title: "Private todo", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+				},
+				Shares: []domain.Share{
+					{Id: 1, TodoId: intPointer(6), UserId: 1, Permission: domain.PermissionViewer},
+				},
+				SavedFilters: []domain.SavedFilter{
+					{Id: 1, UserId: 1, Name: "Filter", Expression: testCase.expression},
+				},
+				Now: savedFilterTestNow,
+			}).SavedFilterService
+
+			todoPage, err := savedFilterService.GetSavedFilterTodos(1, 1, request.SavedFilterEvaluate{})
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedIds, todoResponseIds(todoPage.Todos))
+		})
+	}
+
+	t.Run("ShouldPaginateTodos", func(t *testing.T) {
+		savedFilterService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Ship release", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 8), Tags: []domain.Tag{{Id: 1, UserId: 1, Name: "work"}}},
+				{Id: 2, UserId: 1, Title: "Fix login bug", Priority: domain.PriorityUrgent, DueDate: datePointer(2024, 3, 10)},
+				{Id: 3, UserId: 1, Title: "Write docs", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 12)},
+				{Id: 4, UserId: 1, Title: "Plan offsite", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 7), IsCompleted: true},
+				{Id: 5, UserId: 1, Title: "Pay invoice", Priority: domain.PriorityLow, DueDate: datePointer(2024, 3, 1)},
+				{Id: 6, UserId: 2, Title: "Review budget", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+				{Id: 7, UserId: 3, Title: "Private todo", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+			},
+			Shares: []domain.Share{
+				{Id: 1, TodoId: intPointer(6), UserId: 1, Permission: domain.PermissionViewer},
+			},
+			SavedFilters: []domain.SavedFilter{
+				{Id: 1, UserId: 1, Name: "High", Expression: "priority:high,urgent done:false owner:me"},
+			},
+			Now: savedFilterTestNow,
+		}).SavedFilterService
+
+		todoPage, err := savedFilterService.GetSavedFilterTodos(1, 1, request.SavedFilterEvaluate{Limit: 2, Offset: 2})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, todoPage.Total)
+		assert.Equal(t, []int{3}, todoResponseIds(todoPage.Todos))
+	})
+
+	t.Run("ShouldResolveRelativeDatesInTimezone", func(t *testing.T) {
+		savedFilterService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Ship release", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 8), Tags: []domain.Tag{{Id: 1, UserId: 1, Name: "work"}}},
+				{Id: 2, UserId: 1, Title: "Fix login bug", Priority: domain.PriorityUrgent, DueDate: datePointer(2024, 3, 10)},
+				{Id: 3, UserId: 1, Title: "Write docs", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 12)},
+				{Id: 4, UserId: 1, Title: "Plan offsite", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 7), IsCompleted: true},
+				{Id: 5, UserId: 1, Title: "Pay invoice", Priority: domain.PriorityLow, DueDate: datePointer(2024, 3, 1)},
+				{Id: 6, UserId: 2, Title: "Review budget", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+				{Id: 7, UserId: 3, Title: "Private todo", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+			},
+			Shares: []domain.Share{
+				{Id: 1, TodoId: intPointer(6), UserId: 1, Permission: domain.PermissionViewer},
+			},
+			SavedFilters: []domain.SavedFilter{
+				{Id: 1, UserId: 1, Name: "Today", Expression: "due:today"},
+			},
+			Now: time.Date(2024, 3, 6, 23, 30, 0, 0, time.UTC),
+		}).SavedFilterService
+
+		todoPage, _ := savedFilterService.GetSavedFilterTodos(1, 1, request.SavedFilterEvaluate{})
+		assert.Empty(t, todoPage.Todos)
+
+		todoPage, err := savedFilterService.GetSavedFilterTodos(1, 1, request.SavedFilterEvaluate{Timezone: "Pacific/Auckland"})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{4}, todoResponseIds(todoPage.Todos))
+	})
+
+	t.Run("ShouldNotGetTodosOfSavedFilterOfOthers", func(t *testing.T) {
+		savedFilterService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Ship release", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 8), Tags: []domain.Tag{{Id: 1, UserId: 1, Name: "work"}}},
+				{Id: 2, UserId: 1, Title: "Fix login bug", Priority: domain.PriorityUrgent, DueDate: datePointer(2024, 3, 10)},
+				{Id: 3, UserId: 1, Title: "Write docs", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 12)},
+				{Id: 4, UserId: 1, Title: "Plan offsite", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 7), IsCompleted: true},
+				{Id: 5, UserId: 1, Title: "Pay invoice", Priority: domain.PriorityLow, DueDate: datePointer(2024, 3, 1)},
+				{Id: 6, UserId: 2, Title: "Review budget", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+				{Id: 7, UserId: 3, Title: "Private todo", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+			},
+			Shares: []domain.Share{
+				{Id: 1, TodoId: intPointer(6), UserId: 1, Permission: domain.PermissionViewer},
+			},
+			SavedFilters: []domain.SavedFilter{
+				{Id: 1, UserId: 2, Name: "Mine", Expression: "done:false"},
+			},
+			Now: savedFilterTestNow,
+		}).SavedFilterService
+
+		_, err := savedFilterService.GetSavedFilterTodos(1, 1, request.SavedFilterEvaluate{})
+		assert.Equal(t, "This saved filter is not belongs to you", err.Error())
+
+		err = savedFilterService.DeleteSavedFilter(1, 1)
+		assert.Equal(t, "This saved filter is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldUpdateSavedFilter(t *testing.T) {
+	savedFilterService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Ship release", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 8), Tags: []domain.Tag{{Id: 1, UserId: 1, Name: "work"}}},
+			{Id: 2, UserId: 1, Title: "Fix login bug", Priority: domain.PriorityUrgent, DueDate: datePointer(2024, 3, 10)},
+			{Id: 3, UserId: 1, Title: "Write docs", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 12)},
+			{Id: 4, UserId: 1, Title: "Plan offsite", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 7), IsCompleted: true},
+			{Id: 5, UserId: 1, Title: "Pay invoice", Priority: domain.PriorityLow, DueDate: datePointer(2024, 3, 1)},
+			{Id: 6, UserId: 2, Title: "Review budget", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+			{Id: 7, UserId: 3, Title: "Private todo", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 3, 9)},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(6), UserId: 1, Permission: domain.PermissionViewer},
+		},
+		SavedFilters: []domain.SavedFilter{
+			{Id: 1, UserId: 1, Name: "Open", Expression: "done:false"},
+			{Id: 2, UserId: 1, Name: "Urgent", Expression: "priority:urgent"},
+		},
+		Now: savedFilterTestNow,
+	}).SavedFilterService
+
+	savedFilter, err := savedFilterService.UpdateSavedFilter(1, 1, request.SavedFilterUpdate{Name: "Open", Expression: "done:false owner:me"})
+	assert.Nil(t, err)
+	assert.Equal(t, "done:false owner:me", savedFilter.Expression)
+
+	_, err = savedFilterService.UpdateSavedFilter(1, 1, request.SavedFilterUpdate{Name: "Urgent", Expression: "done:false"})
+	assert.Equal(t, "A saved filter named Urgent already exists", err.Error())
+}
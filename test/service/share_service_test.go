@@ -0,0 +1,136 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+)
+
+func todoResponseIds(todos []response.TodoResponse) []int {
+	var ids []int
+	for _, todo := range todos {
+		ids = append(ids, todo.Id)
+	}
+	return ids
+}
+
+func Test_ShouldShareTodo(t *testing.T) {
+	shareTestServices := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Plan offsite", Description: "Venue and agenda", Position: 1024},
+			{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Book venue", Description: "Call the hotel", Position: 2048},
+			{Id: 3, UserId: 1, ProjectId: intPointer(1), Title: "Draft budget", Description: "Travel and food", Position: 3072},
+			{Id: 4, UserId: 2, Title: "Review slides", Description: "Quarterly slides", Position: 1024},
+		},
+		Projects: []domain.Project{{Id: 1, UserId: 1, Name: "Offsite"}},
+		Users: []domain.User{
+			{Id: 1, Username: "alice", Email: "alice@example.com"},
+			{Id: 2, Username: "bob", Email: "bob@example.com"},
+			{Id: 3, Username: "carol", Email: "carol@example.com"},
+		},
+	})
+	todoService, shareService := shareTestServices.TodoService, shareTestServices.ShareService
+
+	t.Run("ShouldShareTodoForViewing", func(t *testing.T) {
+		share, err := shareService.ShareTodo(1, 1, request.ShareCreate{Email: "bob@example.com", Permission: domain.PermissionViewer})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, share.UserId)
+
+		todo, err := todoService.GetTodoById(2, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(todo.Subtasks))
+
+		_, err = todoService.GetTodoById(2, 2)
+		assert.Nil(t, err)
+
+		_, err = todoService.ToggleTodo(2, 1, request.TodoToggle{})
+		assert.Equal(t, "This todo is only shared with you for viewing", err.Error())
+	})
+
+	t.Run("ShouldChangePermissionWhenSharingAgain", func(t *testing.T) {
+		_, err := shareService.ShareTodo(1, 1, request.ShareCreate{Email: "bob@example.com", Permission: domain.PermissionEditor})
+		assert.Nil(t, err)
+
+		shares, _ := shareService.GetTodoShares(1, 1)
+		assert.Equal(t, 1, len(shares))
+		assert.Equal(t, domain.PermissionEditor, shares[0].Permission)
+
+		todo, err := todoService.ToggleTodo(2, 1, request.TodoToggle{})
+		assert.Nil(t, err)
+		assert.True(t, todo.IsCompleted)
+
+		err = todoService.DeleteTodo(2, 1)
+		assert.Equal(t, "Only the owner of this todo can do this", err.Error())
+	})
+
+	t.Run("ShouldNotShareTodoWithoutOwningIt", func(t *testing.T) {
+		_, err := shareService.ShareTodo(2, 1, request.ShareCreate{Email: "carol@example.com", Permission: domain.PermissionViewer})
+		assert.Equal(t, "Only the owner of this todo can do this", err.Error())
+
+		_, err = shareService.ShareTodo(3, 1, request.ShareCreate{Email: "carol@example.com", Permission: domain.PermissionViewer})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+
+	t.Run("ShouldNotShareTodoWithInvalidPermission", func(t *testing.T) {
+		_, err := shareService.ShareTodo(1, 1, request.ShareCreate{Email: "carol@example.com", Permission: "admin"})
+		assert.Equal(t, "Permission must be either viewer or editor", err.Error())
+
+		_, err = shareService.ShareTodo(1, 1, request.ShareCreate{Email: "alice@example.com", Permission: domain.PermissionViewer})
+		assert.Equal(t, "You cannot share with yourself", err.Error())
+	})
+
+	t.Run("ShouldUnshareTodo", func(t *testing.T) {
+		err := shareService.UnshareTodo(2, 1, 2)
+		assert.Nil(t, err)
+
+		_, err = todoService.GetTodoById(2, 1)
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldListSharedTodos(t *testing.T) {
+	shareTestServices := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Plan offsite", Description: "Venue and agenda", Position: 1024},
+			{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Book venue", Description: "Call the hotel", Position: 2048},
+			{Id: 3, UserId: 1, ProjectId: intPointer(1), Title: "Draft budget", Description: "Travel and food", Position: 3072},
+			{Id: 4, UserId: 2, Title: "Review slides", Description: "Quarterly slides", Position: 1024},
+		},
+		Projects: []domain.Project{{Id: 1, UserId: 1, Name: "Offsite"}},
+		Users: []domain.User{
+			{Id: 1, Username: "alice", Email: "alice@example.com"},
+			{Id: 2, Username: "bob", Email: "bob@example.com"},
+			{Id: 3, Username: "carol", Email: "carol@example.com"},
+		},
+	})
+	todoService, shareService := shareTestServices.TodoService, shareTestServices.ShareService
+	shareService.ShareProject(1, 1, request.ShareCreate{Email: "bob@example.com", Permission: domain.PermissionViewer})
+
+	t.Run("ShouldIncludeSharedTodosInListings", func(t *testing.T) {
+		todos, err := todoService.GetAllTodos(2)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{4, 3}, todoResponseIds(todos))
+
+		todoPage, err := todoService.GetTodosByFilter(2, request.TodoFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{4, 3}, todoResponseIds(todoPage.Todos))
+	})
+
+	t.Run("ShouldFilterTodosByOwner", func(t *testing.T) {
+		todoPage, _ := todoService.GetTodosByFilter(2, request.TodoFilter{Owner: domain.TodoOwnerMe})
+		assert.Equal(t, []int{4}, todoResponseIds(todoPage.Todos))
+
+		todoPage, _ = todoService.GetTodosByFilter(2, request.TodoFilter{Owner: domain.TodoOwnerOthers})
+		assert.Equal(t, []int{3}, todoResponseIds(todoPage.Todos))
+
+		_, err := todoService.GetTodosByFilter(2, request.TodoFilter{Owner: "everyone"})
+		assert.Equal(t, "Owner must be either me or others", err.Error())
+	})
+
+	t.Run("ShouldNotAddTodoToProjectSharedForViewing", func(t *testing.T) {
+		_, err := todoService.AddTodo(request.TodoCreate{UserId: 2, ProjectId: intPointer(1), Title: "Order catering", Description: "Lunch for everyone"})
+		assert.Equal(t, "This project is only shared with you for viewing", err.Error())
+	})
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+)
+
+// statsTestNow is a Wednesday.
+var statsTestNow = time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC)
+
+func Test_ShouldGetStats(t *testing.T) {
+	deletedAt := time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC)
+	statsTestTodos := []domain.Todo{
+		{Id: 1, UserId: 1, Title: "Pay invoice", DueDate: datePointer(2024, 3, 1)},
+		{Id: 2, UserId: 1, Title: "Plan offsite", DueDate: datePointer(2024, 3, 10)},
+		{Id: 3, UserId: 1, Title: "Ship release", IsCompleted: true, CreatedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), CompletedAt: timePointer(time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC))},
+		{Id: 4, UserId: 1, Title: "Write docs", IsCompleted: true, CreatedAt: time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC), CompletedAt: timePointer(time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC))},
+		{Id: 5, UserId: 1, Title: "Fix login bug", IsCompleted: true, CreatedAt: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), CompletedAt: timePointer(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))},
+		{Id: 6, UserId: 1, Title: "Review budget", IsCompleted: true, CreatedAt: time.Date(2024, 2, 20, 10, 0, 0, 0, time.UTC), CompletedAt: timePointer(time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC))},
+		{Id: 7, UserId: 2, Title: "Private todo", IsCompleted: true, CreatedAt: time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC), CompletedAt: timePointer(time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC))},
+		{Id: 8, UserId: 1, Title: "Trashed todo", IsCompleted: true, CreatedAt: time.Date(2024, 3, 3, 8, 0, 0, 0, time.UTC), CompletedAt: timePointer(time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC)), DeletedAt: &deletedAt},
+		{Id: 9, UserId: 1, Title: "Morning standup", DueDate: datePointer(2024, 3, 6), DueTime: stringPointer("09:00")},
+		{Id: 10, UserId: 1, Title: "Evening review", DueDate: datePointer(2024, 3, 6)},
+	}
+
+	t.Run("ShouldCountTodos", func(t *testing.T) {
+		statsService := NewTestServices(TestFixture{Todos: statsTestTodos, Now: statsTestNow}).StatsService
+
+		stats, err := statsService.GetStats(1, request.StatsFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, 4, stats.OpenCount)
+		assert.Equal(t, 4, stats.CompletedCount)
+		assert.Equal(t, 2, stats.OverdueCount)
+		assert.Equal(t, 6270, *stats.AverageMinutesToComplete)
+		assert.Equal(t, 3, stats.CurrentStreakDays)
+	})
+
+	t.Run("ShouldCountCompletionsPerDay", func(t *testing.T) {
+		statsService := NewTestServices(TestFixture{Todos: statsTestTodos, Now: statsTestNow}).StatsService
+
+		stats, err := statsService.GetStats(1, request.StatsFilter{From: datePointer(2024, 3, 1)})
+		assert.Nil(t, err)
+		assert.Equal(t, "2024-03-01", stats.From)
+		assert.Equal(t, "2024-03-06", stats.To)
+		assert.Equal(t, []int{0, 1, 0, 1, 1, 1}, completionCounts(stats.Completions))
+
+		stats, _ = statsService.GetStats(1, request.StatsFilter{})
+		assert.Equal(t, "2024-02-06", stats.From)
+		assert.Equal(t, 30, len(stats.Completions))
+	})
+
+	t.Run("ShouldCountCompletionsPerWeekFromMonday", func(t *testing.T) {
+		statsService := NewTestServices(TestFixture{Todos: statsTestTodos, Now: statsTestNow}).StatsService
+
+		stats, err := statsService.GetStats(1, request.StatsFilter{From: datePointer(2024, 2, 28), To: datePointer(2024, 3, 6), Period: domain.StatsPeriodWeek})
+		assert.Nil(t, err)
+		assert.Equal(t, "2024-02-26", stats.From)
+		assert.Equal(t, "2024-02-26", stats.Completions[0].PeriodStart)
+		assert.Equal(t, []int{1, 3}, completionCounts(stats.Completions))
+	})
+
+	t.Run("ShouldCountStreakUpToYesterday", func(t *testing.T) {
+		stats, _ := NewTestServices(TestFixture{Todos: statsTestTodos, Now: time.Date(2024, 3, 7, 10, 0, 0, 0, time.UTC)}).StatsService.GetStats(1, request.StatsFilter{})
+		assert.Equal(t, 3, stats.CurrentStreakDays)
+
+		stats, _ = NewTestServices(TestFixture{Todos: statsTestTodos, Now: time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC)}).StatsService.GetStats(1, request.StatsFilter{})
+		assert.Equal(t, 0, stats.CurrentStreakDays)
+	})
+
+	t.Run("ShouldUseDaysOfTimezone", func(t *testing.T) {
+		statsService := NewTestServices(TestFixture{Todos: statsTestTodos, Now: statsTestNow}).StatsService
+
+		stats, err := statsService.GetStats(1, request.StatsFilter{From: datePointer(2024, 3, 1), Timezone: "Pacific/Auckland"})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{0, 1, 0, 0, 2, 1}, completionCounts(stats.Completions))
+		assert.Equal(t, 2, stats.CurrentStreakDays)
+		assert.Equal(t, 2, stats.OverdueCount)
+	})
+
+	t.Run("ShouldUseDaysOfTimezoneBehindUtc", func(t *testing.T) {
+		statsService := NewTestServices(TestFixture{Todos: statsTestTodos, Now: statsTestNow}).StatsService
+
+		// It is midnight in Honolulu, so the standup at 09:00 local time is not yet overdue and no
+		// todo has been completed today.
+		stats, err := statsService.GetStats(1, request.StatsFilter{From: datePointer(2024, 3, 1), Timezone: "Pacific/Honolulu"})
+		assert.Nil(t, err)
+		assert.Equal(t, "2024-03-06", stats.To)
+		assert.Equal(t, []int{0, 1, 0, 2, 1, 0}, completionCounts(stats.Completions))
+		assert.Equal(t, 2, stats.CurrentStreakDays)
+		assert.Equal(t, 1, stats.OverdueCount)
+	})
+
+	t.Run("ShouldNotGetStatsOfInvalidFilter", func(t *testing.T) {
+		statsService := NewTestServices(TestFixture{Todos: statsTestTodos, Now: statsTestNow}).StatsService
+
+		testCases := []struct {
+			name          string
+			statsFilter   request.StatsFilter
+			expectedError string
+		}{
+			{"UnknownTimezone", request.StatsFilter{Timezone: "Mars/Olympus"}, "Unknown timezone Mars/Olympus"},
+			{"UnknownPeriod", request.StatsFilter{Period: "month"}, "Unsupported period month"},
+			{"FromAfterTo", request.StatsFilter{From: datePointer(2024, 3, 7), To: datePointer(2024, 3, 6)}, "From must not be after to"},
+			{"TooLong", request.StatsFilter{From: datePointer(2023, 1, 1)}, "Stats can cover at most 366 days"},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				_, err := statsService.GetStats(1, testCase.statsFilter)
+				assert.Equal(t, testCase.expectedError, err.Error())
+			})
+		}
+	})
+}
+
+func completionCounts(completions []response.CompletionCountResponse) []int {
+	counts := []int{}
+	for _, completion := range completions {
+		counts = append(counts, completion.Count)
+	}
+
+	return counts
+}
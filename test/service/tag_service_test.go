@@ -0,0 +1,142 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+var initialTags = []domain.Tag{
+	{Id: 1, UserId: 1, Name: "work"},
+	{Id: 2, UserId: 1, Name: "urgent"},
+	{Id: 3, UserId: 2, Name: "home"},
+}
+
+func Test_ShouldAddTag(t *testing.T) {
+	tagService := NewTestServices(TestFixture{Tags: initialTags}).TagService
+
+	t.Run("ShouldAddTag", func(t *testing.T) {
+		tag, err := tagService.AddTag(request.TagCreate{UserId: 1, Name: " personal "})
+		assert.Nil(t, err)
+		assert.Equal(t, "personal", tag.Name)
+		actualTags, _ := tagService.GetAllTags(1)
+		assert.Equal(t, 3, len(actualTags))
+	})
+}
+
+func Test_ShouldNotAddTagEmptyName(t *testing.T) {
+	tagService := NewTestServices(TestFixture{Tags: initialTags}).TagService
+
+	t.Run("ShouldNotAddTagEmptyName", func(t *testing.T) {
+		_, err := tagService.AddTag(request.TagCreate{UserId: 1, Name: "  "})
+		assert.Equal(t, "Tag name cannot be empty", err.Error())
+	})
+}
+
+func Test_ShouldNotUpdateTagOfAnotherUser(t *testing.T) {
+	tagService := NewTestServices(TestFixture{Tags: initialTags}).TagService
+
+	t.Run("ShouldNotUpdateTagOfAnotherUser", func(t *testing.T) {
+		_, err := tagService.UpdateTag(1, 3, request.TagUpdate{Name: "garden"})
+		assert.Equal(t, "This tag is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldGetTodosByAnyTag(t *testing.T) {
+	tagTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare slides", Tags: []domain.Tag{initialTags[0], initialTags[1]}},
+			{Id: 2, UserId: 1, Title: "Review pull request", Tags: []domain.Tag{initialTags[0]}},
+			{Id: 3, UserId: 1, Title: "Water the plants"},
+		},
+		Tags: initialTags,
+	}).TodoService
+
+	t.Run("ShouldGetTodosByAnyTag", func(t *testing.T) {
+		todoPage, _ := tagTodoService.GetTodosByFilter(1, request.TodoFilter{Tags: []string{"work", "urgent"}})
+		assert.Equal(t, 2, todoPage.Total)
+	})
+}
+
+func Test_ShouldGetTodosByAllTags(t *testing.T) {
+	tagTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare slides", Tags: []domain.Tag{initialTags[0], initialTags[1]}},
+			{Id: 2, UserId: 1, Title: "Review pull request", Tags: []domain.Tag{initialTags[0]}},
+			{Id: 3, UserId: 1, Title: "Water the plants"},
+		},
+		Tags: initialTags,
+	}).TodoService
+
+	t.Run("ShouldGetTodosByAllTags", func(t *testing.T) {
+		todoPage, _ := tagTodoService.GetTodosByFilter(1, request.TodoFilter{Tags: []string{"work", "urgent"}, TagMode: "and"})
+		assert.Equal(t, 1, todoPage.Total)
+		assert.Equal(t, 1, todoPage.Todos[0].Id)
+	})
+}
+
+func Test_ShouldAttachAndDetachTagsOnUpdate(t *testing.T) {
+	tagTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare slides", Tags: []domain.Tag{initialTags[0], initialTags[1]}},
+			{Id: 2, UserId: 1, Title: "Review pull request", Tags: []domain.Tag{initialTags[0]}},
+			{Id: 3, UserId: 1, Title: "Water the plants"},
+		},
+		Tags: initialTags,
+	}).TodoService
+
+	t.Run("ShouldAttachAndDetachTagsOnUpdate", func(t *testing.T) {
+		todo, err := tagTodoService.UpdateTodo(2, request.TodoUpdate{
+			UserId:       1,
+			Title:        "Review pull request",
+			Description:  "Review the open pull request",
+			AttachTagIds: []int{2},
+			DetachTagIds: []int{1},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(todo.Tags))
+		assert.Equal(t, "urgent", todo.Tags[0].Name)
+	})
+}
+
+func Test_ShouldNotAttachTagOfAnotherUser(t *testing.T) {
+	tagTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare slides", Tags: []domain.Tag{initialTags[0], initialTags[1]}},
+			{Id: 2, UserId: 1, Title: "Review pull request", Tags: []domain.Tag{initialTags[0]}},
+			{Id: 3, UserId: 1, Title: "Water the plants"},
+		},
+		Tags: initialTags,
+	}).TodoService
+
+	t.Run("ShouldNotAttachTagOfAnotherUser", func(t *testing.T) {
+		_, err := tagTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Water the garden", Description: "Water the garden plants", TagIds: []int{3}})
+		assert.Equal(t, "One or more tags not found", err.Error())
+		actualTodos, _ := tagTodoService.GetAllTodos(1)
+		assert.Equal(t, 3, len(actualTodos))
+	})
+}
+
+func Test_ShouldNotUpdateTodoWithTagOfAnotherUser(t *testing.T) {
+	tagTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare slides", Tags: []domain.Tag{initialTags[0], initialTags[1]}},
+			{Id: 2, UserId: 1, Title: "Review pull request", Tags: []domain.Tag{initialTags[0]}},
+			{Id: 3, UserId: 1, Title: "Water the plants"},
+		},
+		Tags: initialTags,
+	}).TodoService
+
+	t.Run("ShouldNotUpdateTodoWithTagOfAnotherUser", func(t *testing.T) {
+		_, err := tagTodoService.UpdateTodo(2, request.TodoUpdate{
+			UserId:       1,
+			Title:        "Review release branch",
+			Description:  "Review the release branch",
+			AttachTagIds: []int{3},
+		})
+		assert.Equal(t, "One or more tags not found", err.Error())
+		todo, _ := tagTodoService.GetTodoById(1, 2)
+		assert.Equal(t, "Review pull request", todo.Title)
+	})
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+var templateTestTags = []domain.Tag{
+	{Id: 1, UserId: 1, Name: "release"},
+	{Id: 2, UserId: 2, Name: "team"},
+}
+
+func newReleaseTemplate() domain.Template {
+	return domain.Template{Id: 1, UserId: 1, Name: "Release", Items: []domain.TemplateItem{
+		{Title: "Prepare release", Description: "Release checklist", Priority: "high", DueOffsetDays: intPointer(2), TagIds: []int{1}, Subtasks: []domain.TemplateItem{
+			{Title: "Write changelog", Description: "Summarize changes", DueOffsetDays: intPointer(0), EstimateMinutes: intPointer(30)},
+		}},
+		{Title: "Announce release", Description: "Post on the blog"},
+	}}
+}
+
+func Test_ShouldAddTemplate(t *testing.T) {
+	templateService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+			{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+			{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+			{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+		},
+		Tags: templateTestTags,
+		Projects: []domain.Project{
+			{Id: 1, UserId: 2, Name: "Team project"},
+		},
+		Shares: []domain.Share{
+			{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+		},
+	}).TemplateService
+
+	t.Run("ShouldAddTemplateWithSubtasks", func(t *testing.T) {
+		template, err := templateService.AddTemplate(request.TemplateCreate{UserId: 1, Name: " Weekly review ", Items: []request.TemplateItem{
+			{Title: "Review inbox", Description: "Empty the inbox", DueOffsetDays: intPointer(1), TagIds: []int{1}, Subtasks: []request.TemplateItem{
+				{Title: "Archive mail", Description: "Archive old mail"},
+			}},
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "Weekly review", template.Name)
+		assert.Equal(t, 2, template.ItemCount)
+
+		templates, _ := templateService.GetTemplates(1)
+		assert.Equal(t, 1, len(templates))
+	})
+
+	t.Run("ShouldNotAddInvalidTemplate", func(t *testing.T) {
+		validItem := request.TemplateItem{Title: "Review inbox", Description: "Empty the inbox"}
+		testCases := []struct {
+			name          string
+			templateName  string
+			items         []request.TemplateItem
+			expectedError string
+		}{
+			{"EmptyName", " ", []request.TemplateItem{validItem}, "Template name must be between 1 and 100 characters long"},
+			{"NoItems", "Review", nil, "A template needs at least one todo"},
+			{"ShortTitle", "Review", []request.TemplateItem{{Title: "Do", Description: "Empty the inbox"}}, "Todo title must be at least 3 characters long"},
+			{"UnknownPriority", "Review", []request.TemplateItem{{Title: "Review inbox", Description: "Empty the inbox", Priority: "asap"}}, "Unsupported priority asap"},
+			{"DueTimeWithoutOffset", "Review", []request.TemplateItem{{Title: "Review inbox", Description: "Empty the inbox", DueTime: stringPointer("09:00")}}, "Due time and timezone require a due date"},
+			{"TagOfOtherUser", "Review", []request.TemplateItem{{Title: "Review inbox", Description: "Empty the inbox", TagIds: []int{2}}}, "Tag with id 2 not found"},
+			{"TooDeep", "Review", []request.TemplateItem{{Title: "Level zero", Description: "Top level todo", Subtasks: []request.TemplateItem{
+				{Title: "Level one", Description: "First subtask", Subtasks: []request.TemplateItem{
+					{Title: "Level two", Description: "Second subtask", Subtasks: []request.TemplateItem{
+						{Title: "Level three", Description: "Third subtask", Subtasks: []request.TemplateItem{validItem}},
+					}},
+				}},
+			}}}, "Subtasks cannot be nested deeper than 3 levels"},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				_, err := templateService.AddTemplate(request.TemplateCreate{UserId: 1, Name: testCase.templateName, Items: testCase.items})
+				assert.Equal(t, testCase.expectedError, err.Error())
+			})
+		}
+	})
+}
+
+func Test_ShouldManageOwnTemplatesOnly(t *testing.T) {
+	templateService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+			{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+			{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+			{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+		},
+		Tags: templateTestTags,
+		Projects: []domain.Project{
+			{Id: 1, UserId: 2, Name: "Team project"},
+		},
+		Shares: []domain.Share{
+			{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+		},
+		Templates: []domain.Template{newReleaseTemplate()},
+	}).TemplateService
+
+	_, err := templateService.GetTemplateById(2, 1)
+	assert.Equal(t, "This template is not belongs to you", err.Error())
+
+	_, err = templateService.InstantiateTemplate(2, 1, request.TemplateInstantiate{})
+	assert.Equal(t, "This template is not belongs to you", err.Error())
+
+	err = templateService.DeleteTemplate(2, 1)
+	assert.Equal(t, "This template is not belongs to you", err.Error())
+
+	updatedTemplate, err := templateService.UpdateTemplate(1, 1, request.TemplateUpdate{Name: "Minor release", Items: []request.TemplateItem{
+		{Title: "Announce release", Description: "Post on the blog"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, "Minor release", updatedTemplate.Name)
+	assert.Equal(t, 1, updatedTemplate.ItemCount)
+
+	err = templateService.DeleteTemplate(1, 1)
+	assert.Nil(t, err)
+	templates, _ := templateService.GetTemplates(1)
+	assert.Empty(t, templates)
+}
+
+func Test_ShouldInstantiateTemplate(t *testing.T) {
+	t.Run("ShouldCreateTodosWithDueDatesFromStartDate", func(t *testing.T) {
+		templateTestServices := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+				{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+				{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+				{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+			},
+			Tags: templateTestTags,
+			Projects: []domain.Project{
+				{Id: 1, UserId: 2, Name: "Team project"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+			},
+			Templates: []domain.Template{newReleaseTemplate()},
+		})
+		templateService, todoService := templateTestServices.TemplateService, templateTestServices.TodoService
+
+		todos, err := templateService.InstantiateTemplate(1, 1, request.TemplateInstantiate{StartDate: stringPointer("2024-06-03")})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(todos))
+
+		parent := todos[0]
+		assert.Equal(t, 1, parent.UserId)
+		assert.Equal(t, "high", parent.Priority)
+		assert.Equal(t, "2024-06-05", *parent.DueDate)
+		assert.Equal(t, "release", parent.Tags[0].Name)
+		assert.Equal(t, 1, len(parent.Subtasks))
+		assert.Equal(t, parent.Id, *parent.Subtasks[0].ParentId)
+		assert.Equal(t, "2024-06-03", *parent.Subtasks[0].DueDate)
+		assert.Equal(t, 30, *parent.Subtasks[0].EstimateMinutes)
+		assert.Nil(t, todos[1].DueDate)
+
+		history, _ := todoService.GetTodoHistory(1, parent.Subtasks[0].Id, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionCreated}, revisionActions(history.Revisions))
+	})
+
+	t.Run("ShouldCreateTodosInSharedProjectWithoutTagsOfUser", func(t *testing.T) {
+		templateService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+				{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+				{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+				{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+			},
+			Tags: templateTestTags,
+			Projects: []domain.Project{
+				{Id: 1, UserId: 2, Name: "Team project"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+			},
+			Templates: []domain.Template{newReleaseTemplate()},
+		}).TemplateService
+
+		todos, err := templateService.InstantiateTemplate(1, 1, request.TemplateInstantiate{ProjectId: intPointer(1)})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, todos[0].UserId)
+		assert.Equal(t, 1, *todos[0].ProjectId)
+		assert.Empty(t, todos[0].Tags)
+		assert.Equal(t, 1, *todos[0].Subtasks[0].ProjectId)
+	})
+
+	t.Run("ShouldCreateNoTodosWhenAnyTodoFails", func(t *testing.T) {
+		brokenTemplate := newReleaseTemplate()
+		brokenTemplate.Items[1].Priority = "asap"
+		templateTestServices := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+				{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+				{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+				{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+			},
+			Tags: templateTestTags,
+			Projects: []domain.Project{
+				{Id: 1, UserId: 2, Name: "Team project"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+			},
+			Templates: []domain.Template{brokenTemplate},
+		})
+		templateService, todoService := templateTestServices.TemplateService, templateTestServices.TodoService
+
+		_, err := templateService.InstantiateTemplate(1, 1, request.TemplateInstantiate{})
+		assert.Equal(t, "Unsupported priority asap", err.Error())
+
+		todos, _ := todoService.GetAllTodos(1)
+		assert.Equal(t, []int{1, 2, 3}, todoResponseIds(todos))
+	})
+
+	t.Run("ShouldNotInstantiateWithInvalidStartDate", func(t *testing.T) {
+		templateService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+				{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+				{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+				{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+			},
+			Tags: templateTestTags,
+			Projects: []domain.Project{
+				{Id: 1, UserId: 2, Name: "Team project"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+			},
+			Templates: []domain.Template{newReleaseTemplate()},
+		}).TemplateService
+
+		_, err := templateService.InstantiateTemplate(1, 1, request.TemplateInstantiate{StartDate: stringPointer("03/06/2024")})
+		assert.Equal(t, "Start date must be in YYYY-MM-DD format", err.Error())
+	})
+}
+
+func Test_ShouldAddTemplateFromTodos(t *testing.T) {
+	t.Run("ShouldUseOffsetsFromEarliestDueDate", func(t *testing.T) {
+		templateService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+				{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+				{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+				{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+			},
+			Tags: templateTestTags,
+			Projects: []domain.Project{
+				{Id: 1, UserId: 2, Name: "Team project"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+			},
+		}).TemplateService
+
+		template, err := templateService.AddTemplateFromTodos(request.TemplateFromTodos{UserId: 1, Name: "Release", TodoIds: []int{2, 1, 3}})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, template.ItemCount)
+		assert.Equal(t, 2, len(template.Items))
+
+		prepareRelease := template.Items[0]
+		assert.Equal(t, "Prepare release", prepareRelease.Title)
+		assert.Equal(t, "high", prepareRelease.Priority)
+		assert.Equal(t, 2, *prepareRelease.DueOffsetDays)
+		assert.Equal(t, []int{1}, prepareRelease.TagIds)
+		assert.Equal(t, 0, *prepareRelease.Subtasks[0].DueOffsetDays)
+		assert.Equal(t, 30, *prepareRelease.Subtasks[0].EstimateMinutes)
+		assert.Equal(t, 4, *template.Items[1].DueOffsetDays)
+	})
+
+	t.Run("ShouldNotAddTemplateFromTodosOfOthers", func(t *testing.T) {
+		templateService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Prepare release", Description: "Release checklist", Priority: domain.PriorityHigh, DueDate: datePointer(2024, 5, 10), Tags: []domain.Tag{templateTestTags[0]}},
+				{Id: 2, UserId: 1, ParentId: intPointer(1), Title: "Write changelog", Description: "Summarize changes", DueDate: datePointer(2024, 5, 8), EstimateMinutes: intPointer(30)},
+				{Id: 3, UserId: 1, Title: "Announce release", Description: "Post on the blog", DueDate: datePointer(2024, 5, 12)},
+				{Id: 4, UserId: 3, Title: "Private todo", Description: "Not shared"},
+			},
+			Tags: templateTestTags,
+			Projects: []domain.Project{
+				{Id: 1, UserId: 2, Name: "Team project"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, ProjectId: intPointer(1), UserId: 1, Permission: domain.PermissionEditor},
+			},
+		}).TemplateService
+
+		_, err := templateService.AddTemplateFromTodos(request.TemplateFromTodos{UserId: 1, Name: "Release", TodoIds: []int{1, 4}})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+
+		templates, _ := templateService.GetTemplates(1)
+		assert.Empty(t, templates)
+	})
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"time"
+	"todo-app--go-gin/common/storage"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/service"
+)
+
+// TestFixture is the seed data of the fake repositories behind TestServices.
+// A zero Now makes the clock-dependent services use time.Now.
+type TestFixture struct {
+	Todos                  []domain.Todo
+	Tags                   []domain.Tag
+	Dependencies           map[int][]int
+	Projects               []domain.Project
+	Workflows              []domain.Workflow
+	Shares                 []domain.Share
+	Users                  []domain.User
+	Comments               []domain.Comment
+	TimeEntries            []domain.TimeEntry
+	Templates              []domain.Template
+	SavedFilters           []domain.SavedFilter
+	BlobStore              storage.BlobStore
+	MaxAttachmentSize      int64
+	AttachmentContentTypes []string
+	Now                    time.Time
+}
+
+// TestServices wires the services under test to one set of fake repositories,
+// so a change made through one service is visible through the others.
+type TestServices struct {
+	TodoRepository       *FakeTodoRepository
+	AttachmentRepository *FakeAttachmentRepository
+	TodoService          service.ITodoService
+	UserService          service.IUserService
+	TagService           service.ITagService
+	ProjectService       service.IProjectService
+	WorkflowService      service.IWorkflowService
+	CommentService       service.ICommentService
+	AttachmentService    service.IAttachmentService
+	ShareService         service.IShareService
+	TimeEntryService     service.ITimeEntryService
+	TemplateService      service.ITemplateService
+	QuickAddService      service.IQuickAddService
+	SavedFilterService   service.ISavedFilterService
+	StatsService         service.IStatsService
+}
+
+func NewTestServices(fixture TestFixture) TestServices {
+	now := func() time.Time {
+		if fixture.Now.IsZero() {
+			return time.Now()
+		}
+		return fixture.Now
+	}
+
+	todoRepository := &FakeTodoRepository{
+		todos:        append([]domain.Todo{}, fixture.Todos...),
+		tags:         append([]domain.Tag{}, fixture.Tags...),
+		dependencies: fixture.Dependencies,
+	}
+	projectRepository := NewFakeProjectRepository(append([]domain.Project{}, fixture.Projects...))
+	workflowRepository := NewFakeWorkflowRepository(append([]domain.Workflow{}, fixture.Workflows...))
+	shareRepository := NewFakeShareRepository(append([]domain.Share{}, fixture.Shares...))
+	userRepository := NewFakeUserRepository(append([]domain.User{}, fixture.Users...))
+	tagRepository := NewFakeTagRepository(append([]domain.Tag{}, fixture.Tags...))
+	attachmentRepository := &FakeAttachmentRepository{}
+	todoService := service.NewTodoService(todoRepository, projectRepository, workflowRepository, shareRepository)
+	tagService := service.NewTagService(tagRepository)
+
+	return TestServices{
+		TodoRepository:       todoRepository,
+		AttachmentRepository: attachmentRepository,
+		TodoService:          todoService,
+		UserService:          service.NewUserService(userRepository),
+		TagService:           tagService,
+		ProjectService:       service.NewProjectService(projectRepository),
+		WorkflowService:      service.NewWorkflowService(workflowRepository, todoRepository),
+		CommentService:       service.NewCommentService(NewFakeCommentRepository(append([]domain.Comment{}, fixture.Comments...)), todoRepository, shareRepository),
+		AttachmentService:    service.NewAttachmentService(attachmentRepository, todoRepository, shareRepository, fixture.BlobStore, fixture.MaxAttachmentSize, fixture.AttachmentContentTypes),
+		ShareService:         service.NewShareService(shareRepository, todoRepository, projectRepository, userRepository),
+		TimeEntryService:     service.NewTimeEntryService(NewFakeTimeEntryRepository(append([]domain.TimeEntry{}, fixture.TimeEntries...)), todoRepository, shareRepository),
+		TemplateService:      service.NewTemplateService(NewFakeTemplateRepository(append([]domain.Template{}, fixture.Templates...)), todoRepository, projectRepository, workflowRepository, tagRepository, shareRepository),
+		QuickAddService:      service.NewQuickAddService(todoService, tagService, now),
+		SavedFilterService:   service.NewSavedFilterService(NewFakeSavedFilterRepository(append([]domain.SavedFilter{}, fixture.SavedFilters...)), todoRepository, shareRepository, now),
+		StatsService:         service.NewStatsService(todoRepository, now),
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func timePointer(value time.Time) *time.Time {
+	return &value
+}
+
+func Test_ShouldTrackTimeWithTimer(t *testing.T) {
+	timeEntryService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Client website", Description: "Landing page redesign", EstimateMinutes: intPointer(180)},
+			{Id: 2, UserId: 1, Title: "Invoice review", Description: "Monthly invoices"},
+			{Id: 3, UserId: 3, Title: "Private todo", Description: "Not shared"},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 2, Permission: domain.PermissionEditor},
+			{Id: 2, TodoId: intPointer(2), UserId: 2, Permission: domain.PermissionViewer},
+		},
+		TimeEntries: []domain.TimeEntry{
+			{Id: 1, TodoId: 1, UserId: 1, StartedAt: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC))},
+			{Id: 2, TodoId: 1, UserId: 2, StartedAt: time.Date(2024, 3, 4, 23, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 5, 1, 0, 0, 0, time.UTC))},
+			{Id: 3, TodoId: 2, UserId: 1, StartedAt: time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 5, 14, 45, 0, 0, time.UTC))},
+		},
+	}).TimeEntryService
+
+	t.Run("ShouldStartAndStopTimer", func(t *testing.T) {
+		timeEntry, err := timeEntryService.StartTimer(1, 2)
+		assert.Nil(t, err)
+		assert.True(t, timeEntry.IsRunning)
+
+		runningTimer, _ := timeEntryService.GetRunningTimer(1)
+		assert.Equal(t, timeEntry.Id, runningTimer.Id)
+
+		timeEntry, err = timeEntryService.StopTimer(1, 2)
+		assert.Nil(t, err)
+		assert.False(t, timeEntry.IsRunning)
+		assert.NotNil(t, timeEntry.EndedAt)
+
+		runningTimer, _ = timeEntryService.GetRunningTimer(1)
+		assert.Nil(t, runningTimer)
+	})
+
+	t.Run("ShouldNotRunMoreThanOneTimer", func(t *testing.T) {
+		timeEntryService.StartTimer(1, 1)
+
+		_, err := timeEntryService.StartTimer(1, 2)
+		assert.Equal(t, "A timer is already running on todo 1, stop it first", err.Error())
+
+		_, err = timeEntryService.StopTimer(1, 2)
+		assert.Equal(t, "No timer is running on this todo", err.Error())
+	})
+
+	t.Run("ShouldNotStartTimerWithoutEditAccess", func(t *testing.T) {
+		_, err := timeEntryService.StartTimer(2, 2)
+		assert.Equal(t, "This todo is only shared with you for viewing", err.Error())
+
+		_, err = timeEntryService.StartTimer(2, 3)
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldAddTimeEntry(t *testing.T) {
+	timeEntryService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Client website", Description: "Landing page redesign", EstimateMinutes: intPointer(180)},
+			{Id: 2, UserId: 1, Title: "Invoice review", Description: "Monthly invoices"},
+			{Id: 3, UserId: 3, Title: "Private todo", Description: "Not shared"},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 2, Permission: domain.PermissionEditor},
+			{Id: 2, TodoId: intPointer(2), UserId: 2, Permission: domain.PermissionViewer},
+		},
+		TimeEntries: []domain.TimeEntry{
+			{Id: 1, TodoId: 1, UserId: 1, StartedAt: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC))},
+			{Id: 2, TodoId: 1, UserId: 2, StartedAt: time.Date(2024, 3, 4, 23, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 5, 1, 0, 0, 0, time.UTC))},
+			{Id: 3, TodoId: 2, UserId: 1, StartedAt: time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 5, 14, 45, 0, 0, time.UTC))},
+		},
+	}).TimeEntryService
+
+	t.Run("ShouldAddTimeEntryManually", func(t *testing.T) {
+		timeEntry, err := timeEntryService.AddTimeEntry(2, 1, request.TimeEntryCreate{
+			StartedAt: time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC),
+			EndedAt:   time.Date(2024, 3, 6, 8, 20, 0, 0, time.UTC),
+			Note:      " Copy changes ",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(20*60), timeEntry.DurationSeconds)
+		assert.Equal(t, "Copy changes", timeEntry.Note)
+	})
+
+	t.Run("ShouldNotAddInvalidTimeEntry", func(t *testing.T) {
+		_, err := timeEntryService.AddTimeEntry(1, 1, request.TimeEntryCreate{
+			StartedAt: time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC),
+			EndedAt:   time.Date(2024, 3, 6, 8, 0, 0, 0, time.UTC),
+		})
+		assert.Equal(t, "Time entry must end after it starts", err.Error())
+
+		_, err = timeEntryService.AddTimeEntry(1, 1, request.TimeEntryCreate{
+			StartedAt: time.Now().Add(-time.Hour),
+			EndedAt:   time.Now().Add(time.Hour),
+		})
+		assert.Equal(t, "Time entry cannot end in the future", err.Error())
+	})
+
+	t.Run("ShouldOnlyDeleteOwnTimeEntry", func(t *testing.T) {
+		err := timeEntryService.DeleteTimeEntry(1, 1, 2)
+		assert.Equal(t, "This time entry is not belongs to you", err.Error())
+
+		err = timeEntryService.DeleteTimeEntry(1, 1, 1)
+		assert.Nil(t, err)
+	})
+}
+
+func Test_ShouldReportTrackedTime(t *testing.T) {
+	timeEntryService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Client website", Description: "Landing page redesign", EstimateMinutes: intPointer(180)},
+			{Id: 2, UserId: 1, Title: "Invoice review", Description: "Monthly invoices"},
+			{Id: 3, UserId: 3, Title: "Private todo", Description: "Not shared"},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 2, Permission: domain.PermissionEditor},
+			{Id: 2, TodoId: intPointer(2), UserId: 2, Permission: domain.PermissionViewer},
+		},
+		TimeEntries: []domain.TimeEntry{
+			{Id: 1, TodoId: 1, UserId: 1, StartedAt: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC))},
+			{Id: 2, TodoId: 1, UserId: 2, StartedAt: time.Date(2024, 3, 4, 23, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 5, 1, 0, 0, 0, time.UTC))},
+			{Id: 3, TodoId: 2, UserId: 1, StartedAt: time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC), EndedAt: timePointer(time.Date(2024, 3, 5, 14, 45, 0, 0, time.UTC))},
+		},
+	}).TimeEntryService
+
+	t.Run("ShouldReportTrackedTimeAgainstEstimate", func(t *testing.T) {
+		timeReport, err := timeEntryService.GetTodoTimeReport(2, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 210, timeReport.TrackedMinutes)
+		assert.Equal(t, 180, *timeReport.EstimateMinutes)
+		assert.Equal(t, -30, *timeReport.RemainingMinutes)
+		assert.Equal(t, 2, len(timeReport.Users))
+		assert.Equal(t, 90, timeReport.Users[0].TrackedMinutes)
+		assert.Equal(t, 120, timeReport.Users[1].TrackedMinutes)
+	})
+
+	t.Run("ShouldReportTrackedTimePerDay", func(t *testing.T) {
+		dailyReports, err := timeEntryService.GetDailyTimeReport(1, request.TimeReportFilter{
+			From: timePointer(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)),
+			To:   timePointer(time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(dailyReports))
+		assert.Equal(t, "2024-03-04", dailyReports[0].Date)
+		assert.Equal(t, 90, dailyReports[0].TrackedMinutes)
+		assert.Equal(t, "Client website", dailyReports[0].Todos[0].Title)
+		assert.Equal(t, 180, *dailyReports[0].Todos[0].EstimateMinutes)
+		assert.Equal(t, 45, dailyReports[1].TrackedMinutes)
+		assert.Empty(t, dailyReports[2].Todos)
+	})
+
+	t.Run("ShouldSplitTimeEntryAcrossMidnight", func(t *testing.T) {
+		dailyReports, _ := timeEntryService.GetDailyTimeReport(2, request.TimeReportFilter{
+			From: timePointer(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)),
+			To:   timePointer(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)),
+		})
+		assert.Equal(t, 60, dailyReports[0].TrackedMinutes)
+		assert.Equal(t, 60, dailyReports[1].TrackedMinutes)
+	})
+
+	t.Run("ShouldNotReportInvalidPeriod", func(t *testing.T) {
+		_, err := timeEntryService.GetDailyTimeReport(1, request.TimeReportFilter{
+			From: timePointer(time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)),
+			To:   timePointer(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)),
+		})
+		assert.Equal(t, "From must not be after to", err.Error())
+
+		_, err = timeEntryService.GetDailyTimeReport(1, request.TimeReportFilter{Timezone: "Mars/Olympus"})
+		assert.Equal(t, "Unknown timezone Mars/Olympus", err.Error())
+	})
+}
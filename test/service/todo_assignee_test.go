@@ -0,0 +1,82 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldAssignTodo(t *testing.T) {
+	assigneeTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare demo", Description: "Demo for the client", Position: 1024},
+			{Id: 2, UserId: 1, Title: "Update roadmap", Description: "Next quarter roadmap", Position: 2048},
+			{Id: 3, UserId: 2, Title: "Fix invoices", Description: "Rounding errors", Position: 1024, AssigneeId: intPointer(2)},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 2, Permission: domain.PermissionEditor},
+			{Id: 2, TodoId: intPointer(1), UserId: 3, Permission: domain.PermissionViewer},
+		},
+	}).TodoService
+
+	t.Run("ShouldAssignTodoToCollaborator", func(t *testing.T) {
+		todo, err := assigneeTodoService.AssignTodo(1, 1, request.TodoAssign{AssigneeId: intPointer(2)})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, *todo.AssigneeId)
+		assert.Equal(t, 1, *todo.AssignedBy)
+		assert.NotNil(t, todo.AssignedAt)
+	})
+
+	t.Run("ShouldNotAssignTodoToUserWithoutEditAccess", func(t *testing.T) {
+		_, err := assigneeTodoService.AssignTodo(1, 1, request.TodoAssign{AssigneeId: intPointer(3)})
+		assert.Equal(t, "User 3 cannot edit this todo and cannot be assigned to it", err.Error())
+
+		_, err = assigneeTodoService.AssignTodo(1, 2, request.TodoAssign{AssigneeId: intPointer(2)})
+		assert.Equal(t, "User 2 cannot edit this todo and cannot be assigned to it", err.Error())
+	})
+
+	t.Run("ShouldNotAssignTodoWithoutEditAccess", func(t *testing.T) {
+		_, err := assigneeTodoService.AssignTodo(3, 1, request.TodoAssign{AssigneeId: intPointer(1)})
+		assert.Equal(t, "This todo is only shared with you for viewing", err.Error())
+	})
+
+	t.Run("ShouldUnassignTodo", func(t *testing.T) {
+		todo, err := assigneeTodoService.AssignTodo(2, 1, request.TodoAssign{})
+		assert.Nil(t, err)
+		assert.Nil(t, todo.AssigneeId)
+		assert.Equal(t, 2, *todo.AssignedBy)
+	})
+}
+
+func Test_ShouldGetAssignedTodos(t *testing.T) {
+	assigneeTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Prepare demo", Description: "Demo for the client", Position: 1024},
+			{Id: 2, UserId: 1, Title: "Update roadmap", Description: "Next quarter roadmap", Position: 2048},
+			{Id: 3, UserId: 2, Title: "Fix invoices", Description: "Rounding errors", Position: 1024, AssigneeId: intPointer(2)},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 2, Permission: domain.PermissionEditor},
+			{Id: 2, TodoId: intPointer(1), UserId: 3, Permission: domain.PermissionViewer},
+		},
+	}).TodoService
+	assigneeTodoService.AssignTodo(1, 1, request.TodoAssign{AssigneeId: intPointer(2)})
+
+	t.Run("ShouldGetTodosAssignedToMeAcrossOwners", func(t *testing.T) {
+		todoPage, err := assigneeTodoService.GetAssignedTodos(2, request.TodoFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 3}, todoResponseIds(todoPage.Todos))
+	})
+
+	t.Run("ShouldFilterTodosByAssignee", func(t *testing.T) {
+		todoPage, _ := assigneeTodoService.GetTodosByFilter(1, request.TodoFilter{Assignee: "2"})
+		assert.Equal(t, []int{1}, todoResponseIds(todoPage.Todos))
+
+		todoPage, _ = assigneeTodoService.GetTodosByFilter(1, request.TodoFilter{Assignee: domain.TodoAssigneeNone})
+		assert.Equal(t, []int{2}, todoResponseIds(todoPage.Todos))
+
+		_, err := assigneeTodoService.GetTodosByFilter(1, request.TodoFilter{Assignee: "someone"})
+		assert.Equal(t, "Assignee must be a user id, me or none", err.Error())
+	})
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldBulkCompleteTodosWithPerItemResults(t *testing.T) {
+	bulkTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Pay rent"},
+			{Id: 2, UserId: 1, Title: "Pay electricity bill"},
+			{Id: 3, UserId: 2, Title: "Someone else's todo"},
+		},
+		Tags: initialTags,
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Finance"},
+		},
+	}).TodoService
+
+	t.Run("ShouldBulkCompleteTodosWithPerItemResults", func(t *testing.T) {
+		bulkResponse, err := bulkTodoService.BulkUpdateTodos(1, request.TodoBulk{Ids: []int{1, 2, 3}, Action: domain.BulkActionComplete})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, bulkResponse.Succeeded)
+		assert.Equal(t, 1, bulkResponse.Failed)
+		assert.Equal(t, "This todo is not belongs to you", bulkResponse.Results[2].Error)
+
+		todo, _ := bulkTodoService.GetTodoById(1, 2)
+		assert.True(t, todo.IsCompleted)
+	})
+}
+
+func Test_ShouldBulkUncompleteOnlyCompletedTodos(t *testing.T) {
+	bulkTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Pay rent", IsCompleted: true, Status: domain.StatusDone},
+			{Id: 2, UserId: 1, Title: "Pay electricity bill", Status: domain.StatusTodo},
+		},
+	}).TodoService
+
+	t.Run("ShouldBulkUncompleteOnlyCompletedTodos", func(t *testing.T) {
+		bulkResponse, err := bulkTodoService.BulkUpdateTodos(1, request.TodoBulk{Ids: []int{1, 2}, Action: domain.BulkActionUncomplete})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, bulkResponse.Succeeded)
+
+		todo, _ := bulkTodoService.GetTodoById(1, 1)
+		assert.False(t, todo.IsCompleted)
+		assert.Equal(t, domain.StatusTodo, todo.Status)
+		todo, _ = bulkTodoService.GetTodoById(1, 2)
+		assert.False(t, todo.IsCompleted)
+
+		historyPage, _ := bulkTodoService.GetTodoHistory(1, 2, request.TodoHistoryFilter{})
+		assert.Equal(t, 0, historyPage.Total)
+	})
+}
+
+func Test_ShouldRollBackAllOrNothingBulkOperation(t *testing.T) {
+	bulkTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Pay rent"},
+			{Id: 2, UserId: 1, Title: "Pay electricity bill"},
+			{Id: 3, UserId: 2, Title: "Someone else's todo"},
+		},
+		Tags: initialTags,
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Finance"},
+		},
+	}).TodoService
+
+	t.Run("ShouldRollBackAllOrNothingBulkOperation", func(t *testing.T) {
+		bulkResponse, err := bulkTodoService.BulkUpdateTodos(1, request.TodoBulk{Ids: []int{1, 2, 4}, Action: domain.BulkActionDelete, AllOrNothing: true})
+		assert.Nil(t, err)
+		assert.True(t, bulkResponse.RolledBack)
+		assert.Equal(t, 3, bulkResponse.Failed)
+
+		todos, _ := bulkTodoService.GetAllTodos(1)
+		assert.Equal(t, 2, len(todos))
+	})
+}
+
+func Test_ShouldBulkMoveAndTagTodos(t *testing.T) {
+	bulkTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Pay rent"},
+			{Id: 2, UserId: 1, Title: "Pay electricity bill"},
+			{Id: 3, UserId: 2, Title: "Someone else's todo"},
+		},
+		Tags: initialTags,
+		Projects: []domain.Project{
+			{Id: 1, UserId: 1, Name: "Finance"},
+		},
+	}).TodoService
+
+	t.Run("ShouldBulkMoveTodos", func(t *testing.T) {
+		bulkTodoService.BulkUpdateTodos(1, request.TodoBulk{Ids: []int{1, 2}, Action: domain.BulkActionMove, ProjectId: intPointer(1)})
+		todo, _ := bulkTodoService.GetTodoById(1, 1)
+		assert.Equal(t, 1, *todo.ProjectId)
+	})
+
+	t.Run("ShouldBulkAddTag", func(t *testing.T) {
+		bulkTodoService.BulkUpdateTodos(1, request.TodoBulk{Ids: []int{1, 2}, Action: domain.BulkActionAddTag, TagId: intPointer(2)})
+		todo, _ := bulkTodoService.GetTodoById(1, 2)
+		assert.Equal(t, "urgent", todo.Tags[0].Name)
+	})
+
+	t.Run("ShouldRejectUnsupportedAction", func(t *testing.T) {
+		_, err := bulkTodoService.BulkUpdateTodos(1, request.TodoBulk{Ids: []int{1}, Action: "archive"})
+		assert.Equal(t, "Unsupported bulk action archive", err.Error())
+	})
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+var completionTestCompletedAt = time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)
+
+func Test_ShouldRecordCompletion(t *testing.T) {
+	t.Run("ShouldRecordCompletionOnToggle", func(t *testing.T) {
+		todoService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Write report", Status: "todo"},
+				{Id: 2, UserId: 1, Title: "Ship release", IsCompleted: true, Status: "done", CompletedAt: timePointer(completionTestCompletedAt), CompletedBy: intPointer(1)},
+				{Id: 3, UserId: 1, Title: "Plan offsite", IsCompleted: true, Status: "done", CompletedAt: timePointer(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)), CompletedBy: intPointer(1)},
+				{Id: 4, UserId: 1, ParentId: intPointer(1), Title: "Collect numbers", Status: "todo"},
+				{Id: 5, UserId: 2, Title: "Review budget", Status: "todo"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, TodoId: intPointer(5), UserId: 1, Permission: domain.PermissionEditor},
+			},
+		}).TodoService
+		before := time.Now()
+
+		todo, err := todoService.ToggleTodo(1, 1, request.TodoToggle{CompleteSubtasks: true})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, *todo.CompletedBy)
+		assert.False(t, todo.CompletedAt.Before(before))
+		assert.False(t, todo.UpdatedAt.Before(before))
+
+		subtask, _ := todoService.GetTodoById(1, 4)
+		assert.True(t, subtask.IsCompleted)
+		assert.Equal(t, 1, *subtask.CompletedBy)
+		assert.NotNil(t, subtask.CompletedAt)
+
+		todo, _ = todoService.ToggleTodo(1, 1, request.TodoToggle{})
+		assert.False(t, todo.IsCompleted)
+		assert.Nil(t, todo.CompletedAt)
+		assert.Nil(t, todo.CompletedBy)
+	})
+
+	t.Run("ShouldRecordWhoCompletedSharedTodo", func(t *testing.T) {
+		todoService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Write report", Status: "todo"},
+				{Id: 2, UserId: 1, Title: "Ship release", IsCompleted: true, Status: "done", CompletedAt: timePointer(completionTestCompletedAt), CompletedBy: intPointer(1)},
+				{Id: 3, UserId: 1, Title: "Plan offsite", IsCompleted: true, Status: "done", CompletedAt: timePointer(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)), CompletedBy: intPointer(1)},
+				{Id: 4, UserId: 1, ParentId: intPointer(1), Title: "Collect numbers", Status: "todo"},
+				{Id: 5, UserId: 2, Title: "Review budget", Status: "todo"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, TodoId: intPointer(5), UserId: 1, Permission: domain.PermissionEditor},
+			},
+		}).TodoService
+
+		todo, err := todoService.ChangeTodoStatus(1, 5, request.TodoStatusChange{Status: "done"})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, todo.UserId)
+		assert.Equal(t, 1, *todo.CompletedBy)
+	})
+
+	t.Run("ShouldRecordCompletionOnUpdate", func(t *testing.T) {
+		todoService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Write report", Status: "todo"},
+				{Id: 2, UserId: 1, Title: "Ship release", IsCompleted: true, Status: "done", CompletedAt: timePointer(completionTestCompletedAt), CompletedBy: intPointer(1)},
+				{Id: 3, UserId: 1, Title: "Plan offsite", IsCompleted: true, Status: "done", CompletedAt: timePointer(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)), CompletedBy: intPointer(1)},
+				{Id: 4, UserId: 1, ParentId: intPointer(1), Title: "Collect numbers", Status: "todo"},
+				{Id: 5, UserId: 2, Title: "Review budget", Status: "todo"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, TodoId: intPointer(5), UserId: 1, Permission: domain.PermissionEditor},
+			},
+		}).TodoService
+
+		todo, err := todoService.UpdateTodo(2, request.TodoUpdate{UserId: 1, Title: "Ship release 2", Description: "Tagged", IsCompleted: true})
+		assert.Nil(t, err)
+		assert.Equal(t, completionTestCompletedAt, *todo.CompletedAt)
+
+		todo, _ = todoService.UpdateTodo(2, request.TodoUpdate{UserId: 1, Title: "Ship release 2", Description: "Tagged", IsCompleted: false})
+		assert.Nil(t, todo.CompletedAt)
+
+		todo, _ = todoService.UpdateTodo(2, request.TodoUpdate{UserId: 1, Title: "Ship release 2", Description: "Tagged", IsCompleted: true})
+		assert.True(t, todo.CompletedAt.After(completionTestCompletedAt))
+		assert.Equal(t, 1, *todo.CompletedBy)
+	})
+
+	t.Run("ShouldRecordCompletionOnPatch", func(t *testing.T) {
+		todoService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Write report", Status: "todo"},
+				{Id: 2, UserId: 1, Title: "Ship release", IsCompleted: true, Status: "done", CompletedAt: timePointer(completionTestCompletedAt), CompletedBy: intPointer(1)},
+				{Id: 3, UserId: 1, Title: "Plan offsite", IsCompleted: true, Status: "done", CompletedAt: timePointer(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)), CompletedBy: intPointer(1)},
+				{Id: 4, UserId: 1, ParentId: intPointer(1), Title: "Collect numbers", Status: "todo"},
+				{Id: 5, UserId: 2, Title: "Review budget", Status: "todo"},
+			},
+			Shares: []domain.Share{
+				{Id: 1, TodoId: intPointer(5), UserId: 1, Permission: domain.PermissionEditor},
+			},
+		}).TodoService
+
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"isCompleted": true}`))
+		todo, err := todoService.PatchTodo(1, 1, todoPatch)
+		assert.Nil(t, err)
+		assert.NotNil(t, todo.CompletedAt)
+
+		stored, _ := todoService.GetTodoById(1, 1)
+		assert.Equal(t, todo.CompletedAt, stored.CompletedAt)
+		assert.Equal(t, 1, *stored.CompletedBy)
+	})
+}
+
+func Test_ShouldFilterAndSortByCompletion(t *testing.T) {
+	todoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report", Status: "todo"},
+			{Id: 2, UserId: 1, Title: "Ship release", IsCompleted: true, Status: "done", CompletedAt: timePointer(completionTestCompletedAt), CompletedBy: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Plan offsite", IsCompleted: true, Status: "done", CompletedAt: timePointer(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)), CompletedBy: intPointer(1)},
+			{Id: 4, UserId: 1, ParentId: intPointer(1), Title: "Collect numbers", Status: "todo"},
+			{Id: 5, UserId: 2, Title: "Review budget", Status: "todo"},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(5), UserId: 1, Permission: domain.PermissionEditor},
+		},
+	}).TodoService
+
+	todoPage, err := todoService.GetTodosByFilter(1, request.TodoFilter{Owner: domain.TodoOwnerMe, Sort: domain.TodoSortByCompletedAt})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 2, 1, 4}, todoResponseIds(todoPage.Todos))
+
+	todoPage, err = todoService.GetTodosByFilter(1, request.TodoFilter{CompletedFrom: timePointer(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC))})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2}, todoResponseIds(todoPage.Todos))
+
+	_, err = todoService.GetTodosByFilter(1, request.TodoFilter{CompletedFrom: timePointer(completionTestCompletedAt), CompletedTo: timePointer(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))})
+	assert.Equal(t, "completedFrom must be before completedTo", err.Error())
+}
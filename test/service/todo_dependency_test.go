@@ -0,0 +1,148 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldAddTodoDependency(t *testing.T) {
+	dependencyTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Design schema", Description: "Tables and indexes", Status: domain.StatusTodo},
+			{Id: 2, UserId: 1, Title: "Write migration", Description: "Migration script", Status: domain.StatusTodo},
+			{Id: 3, UserId: 1, Title: "Deploy release", Description: "Production deploy", Status: domain.StatusTodo},
+			{Id: 4, UserId: 2, Title: "Other user todo", Description: "Not yours", Status: domain.StatusTodo},
+		},
+		Dependencies: map[int][]int{2: {1}},
+	}).TodoService
+
+	t.Run("ShouldAddTodoDependencyAndMarkTodoBlocked", func(t *testing.T) {
+		todo, err := dependencyTodoService.AddTodoDependency(1, 3, request.TodoDependency{DependsOnId: 2})
+		assert.Nil(t, err)
+		assert.True(t, todo.IsBlocked)
+		assert.Equal(t, []int{2}, todo.BlockedBy)
+
+		dependencies, _ := dependencyTodoService.GetTodoDependencies(1, 3)
+		assert.Equal(t, 1, len(dependencies))
+		assert.Equal(t, 2, dependencies[0].Id)
+	})
+
+	t.Run("ShouldNotAddTodoDependencyCreatingCycle", func(t *testing.T) {
+		_, err := dependencyTodoService.AddTodoDependency(1, 1, request.TodoDependency{DependsOnId: 3})
+		assert.Equal(t, "Todo 3 already depends on todo 1, the dependency would create a cycle", err.Error())
+
+		_, err = dependencyTodoService.AddTodoDependency(1, 1, request.TodoDependency{DependsOnId: 1})
+		assert.Equal(t, "A todo cannot depend on itself", err.Error())
+	})
+
+	t.Run("ShouldNotAddTodoDependencyOnOtherUsersTodo", func(t *testing.T) {
+		_, err := dependencyTodoService.AddTodoDependency(1, 3, request.TodoDependency{DependsOnId: 4})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldNotCompleteBlockedTodo(t *testing.T) {
+	dependencyTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Design schema", Description: "Tables and indexes", Status: domain.StatusTodo},
+			{Id: 2, UserId: 1, Title: "Write migration", Description: "Migration script", Status: domain.StatusTodo},
+			{Id: 3, UserId: 1, Title: "Deploy release", Description: "Production deploy", Status: domain.StatusTodo},
+			{Id: 4, UserId: 2, Title: "Other user todo", Description: "Not yours", Status: domain.StatusTodo},
+		},
+		Dependencies: map[int][]int{2: {1}},
+	}).TodoService
+
+	t.Run("ShouldNotToggleBlockedTodo", func(t *testing.T) {
+		_, err := dependencyTodoService.ToggleTodo(1, 2, request.TodoToggle{})
+		assert.Equal(t, "Todo is blocked by open todos 1", err.Error())
+
+		_, err = dependencyTodoService.ChangeTodoStatus(1, 2, request.TodoStatusChange{Status: domain.StatusDone})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ShouldToggleTodoOncePrerequisitesAreDone", func(t *testing.T) {
+		dependencyTodoService.ToggleTodo(1, 1, request.TodoToggle{})
+
+		todo, err := dependencyTodoService.ToggleTodo(1, 2, request.TodoToggle{})
+		assert.Nil(t, err)
+		assert.True(t, todo.IsCompleted)
+		assert.False(t, todo.IsBlocked)
+	})
+}
+
+func Test_ShouldRemoveTodoDependency(t *testing.T) {
+	dependencyTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Design schema", Description: "Tables and indexes", Status: domain.StatusTodo},
+			{Id: 2, UserId: 1, Title: "Write migration", Description: "Migration script", Status: domain.StatusTodo},
+			{Id: 3, UserId: 1, Title: "Deploy release", Description: "Production deploy", Status: domain.StatusTodo},
+			{Id: 4, UserId: 2, Title: "Other user todo", Description: "Not yours", Status: domain.StatusTodo},
+		},
+		Dependencies: map[int][]int{2: {1}},
+	}).TodoService
+
+	t.Run("ShouldRemoveTodoDependency", func(t *testing.T) {
+		err := dependencyTodoService.RemoveTodoDependency(1, 2, 1)
+		assert.Nil(t, err)
+
+		todo, _ := dependencyTodoService.GetTodoById(1, 2)
+		assert.False(t, todo.IsBlocked)
+
+		err = dependencyTodoService.RemoveTodoDependency(1, 2, 1)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_ShouldOnlyCompleteSubtasksThatCanBeCompleted(t *testing.T) {
+	t.Run("ShouldLeaveBlockedSubtasksOpen", func(t *testing.T) {
+		dependencyTodoService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 1, Title: "Design schema", Description: "Tables and indexes", Status: domain.StatusTodo},
+				{Id: 2, UserId: 1, Title: "Launch beta", Description: "Open the beta", Status: domain.StatusTodo},
+				{Id: 3, UserId: 1, Title: "Invite testers", Description: "Send invites", ParentId: intPointer(2), Status: domain.StatusTodo},
+				{Id: 4, UserId: 1, Title: "Migrate data", Description: "Copy the data", ParentId: intPointer(2), Status: domain.StatusTodo},
+				{Id: 5, UserId: 1, Title: "Write FAQ", Description: "Common questions", ParentId: intPointer(2), Status: domain.StatusDone, IsCompleted: true},
+			},
+			Dependencies: map[int][]int{4: {1}},
+		}).TodoService
+
+		todo, err := dependencyTodoService.ToggleTodo(1, 2, request.TodoToggle{CompleteSubtasks: true})
+		assert.Nil(t, err)
+		assert.True(t, todo.IsCompleted)
+
+		invite, _ := dependencyTodoService.GetTodoById(1, 3)
+		assert.True(t, invite.IsCompleted)
+		migrate, _ := dependencyTodoService.GetTodoById(1, 4)
+		assert.False(t, migrate.IsCompleted)
+
+		inviteHistory, _ := dependencyTodoService.GetTodoHistory(1, 3, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionToggled}, revisionActions(inviteHistory.Revisions))
+		migrateHistory, _ := dependencyTodoService.GetTodoHistory(1, 4, request.TodoHistoryFilter{})
+		assert.Equal(t, 0, migrateHistory.Total)
+		faqHistory, _ := dependencyTodoService.GetTodoHistory(1, 5, request.TodoHistoryFilter{})
+		assert.Equal(t, 0, faqHistory.Total)
+	})
+
+	t.Run("ShouldLeaveSubtasksOpenThatCannotTransitionToDone", func(t *testing.T) {
+		dependencyTodoService := NewTestServices(TestFixture{
+			Todos: []domain.Todo{
+				{Id: 1, UserId: 2, Title: "Redesign landing page", Description: "New layout", Status: "review"},
+				{Id: 2, UserId: 2, Title: "Update copy", Description: "Hero text", ParentId: intPointer(1), Status: "review"},
+				{Id: 3, UserId: 2, Title: "New illustrations", Description: "Hero image", ParentId: intPointer(1), Status: "backlog"},
+			},
+			Workflows: []domain.Workflow{newReviewWorkflow(2)},
+		}).TodoService
+
+		todo, err := dependencyTodoService.ToggleTodo(2, 1, request.TodoToggle{CompleteSubtasks: true})
+		assert.Nil(t, err)
+		assert.Equal(t, "shipped", todo.Status)
+
+		copyUpdate, _ := dependencyTodoService.GetTodoById(2, 2)
+		assert.Equal(t, "shipped", copyUpdate.Status)
+		illustrations, _ := dependencyTodoService.GetTodoById(2, 3)
+		assert.Equal(t, "backlog", illustrations.Status)
+		assert.False(t, illustrations.IsCompleted)
+	})
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldGetOverdueTodos(t *testing.T) {
+	now := time.Now().UTC()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	pastTime := "00:00"
+	utc := "UTC"
+	dueViewTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Pay rent", DueDate: &yesterday},
+			{Id: 2, UserId: 1, Title: "Call the bank", DueDate: &today},
+			{Id: 3, UserId: 1, Title: "Morning standup", DueDate: &today, DueTime: &pastTime, DueTimezone: &utc},
+			{Id: 5, UserId: 1, Title: "Finished task", DueDate: &yesterday, IsCompleted: true},
+			{Id: 6, UserId: 1, Title: "Someday task"},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetOverdueTodos", func(t *testing.T) {
+		todoPage, err := dueViewTodoService.GetTodosByDueView(1, domain.DueViewOverdue, request.DueViewFilter{TodoFilter: request.TodoFilter{Timezone: "UTC"}})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, todoPage.Total)
+		assert.Equal(t, 1, todoPage.Todos[0].Id)
+		assert.Equal(t, 3, todoPage.Todos[1].Id)
+	})
+}
+
+func Test_ShouldGetTodayTodos(t *testing.T) {
+	now := time.Now().UTC()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(now.Year(), now.Month(), now.Day()+3, 0, 0, 0, 0, time.UTC)
+	pastTime := "00:00"
+	utc := "UTC"
+	dueViewTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Pay rent", DueDate: &yesterday},
+			{Id: 2, UserId: 1, Title: "Call the bank", DueDate: &today},
+			{Id: 3, UserId: 1, Title: "Morning standup", DueDate: &today, DueTime: &pastTime, DueTimezone: &utc},
+			{Id: 4, UserId: 1, Title: "Plan the trip", DueDate: &nextWeek},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetTodayTodos", func(t *testing.T) {
+		todoPage, _ := dueViewTodoService.GetTodosByDueView(1, domain.DueViewToday, request.DueViewFilter{TodoFilter: request.TodoFilter{Timezone: "UTC"}})
+		assert.Equal(t, 2, todoPage.Total)
+	})
+}
+
+func Test_ShouldGetUpcomingTodos(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(now.Year(), now.Month(), now.Day()+3, 0, 0, 0, 0, time.UTC)
+	dueViewTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 2, UserId: 1, Title: "Call the bank", DueDate: &today},
+			{Id: 4, UserId: 1, Title: "Plan the trip", DueDate: &nextWeek},
+			{Id: 6, UserId: 1, Title: "Someday task"},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetUpcomingTodos", func(t *testing.T) {
+		todoPage, _ := dueViewTodoService.GetTodosByDueView(1, domain.DueViewUpcoming, request.DueViewFilter{Days: 7})
+		assert.Equal(t, 1, todoPage.Total)
+		assert.Equal(t, 4, todoPage.Todos[0].Id)
+	})
+}
+
+func Test_ShouldNotAddTodoWithInvalidDueDate(t *testing.T) {
+	dueViewTodoService := NewTestServices(TestFixture{}).TodoService
+	dueDate := "2024/09/01"
+
+	t.Run("ShouldNotAddTodoWithInvalidDueDate", func(t *testing.T) {
+		_, err := dueViewTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "title", Description: "description", DueDate: &dueDate})
+		assert.Equal(t, "Due date must be in YYYY-MM-DD format", err.Error())
+	})
+}
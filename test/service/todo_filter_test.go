@@ -0,0 +1,78 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldGetTodosByFilterWithPagination(t *testing.T) {
+	filterTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Buy groceries", Description: "Purchase fruits", IsCompleted: false, CreatedAt: mustParseTime("2024-09-01T10:00:00"), UpdatedAt: mustParseTime("2024-09-05T10:00:00")},
+			{Id: 2, UserId: 1, Title: "Complete assignment", Description: "Finish the report", IsCompleted: true, CreatedAt: mustParseTime("2024-09-02T09:30:00"), UpdatedAt: mustParseTime("2024-09-02T09:30:00")},
+			{Id: 3, UserId: 1, Title: "Workout session", Description: "Attend the gym", IsCompleted: false, CreatedAt: mustParseTime("2024-09-03T18:00:00"), UpdatedAt: mustParseTime("2024-09-03T18:00:00")},
+			{Id: 4, UserId: 2, Title: "Read a book", Description: "Start reading a new novel", IsCompleted: true, CreatedAt: mustParseTime("2024-09-04T20:00:00"), UpdatedAt: mustParseTime("2024-09-04T20:00:00")},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetTodosByFilterWithPagination", func(t *testing.T) {
+		todoPage, err := filterTodoService.GetTodosByFilter(1, request.TodoFilter{Limit: 2, Offset: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, todoPage.Total)
+		assert.Equal(t, 2, len(todoPage.Todos))
+		assert.Equal(t, 2, todoPage.Todos[0].Id)
+		assert.Equal(t, 3, todoPage.Todos[1].Id)
+	})
+}
+
+func Test_ShouldGetTodosByFilterWithCompletionAndTitle(t *testing.T) {
+	filterTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Buy groceries", Description: "Purchase fruits", IsCompleted: false, CreatedAt: mustParseTime("2024-09-01T10:00:00"), UpdatedAt: mustParseTime("2024-09-05T10:00:00")},
+			{Id: 2, UserId: 1, Title: "Complete assignment", Description: "Finish the report", IsCompleted: true, CreatedAt: mustParseTime("2024-09-02T09:30:00"), UpdatedAt: mustParseTime("2024-09-02T09:30:00")},
+			{Id: 3, UserId: 1, Title: "Workout session", Description: "Attend the gym", IsCompleted: false, CreatedAt: mustParseTime("2024-09-03T18:00:00"), UpdatedAt: mustParseTime("2024-09-03T18:00:00")},
+			{Id: 4, UserId: 2, Title: "Read a book", Description: "Start reading a new novel", IsCompleted: true, CreatedAt: mustParseTime("2024-09-04T20:00:00"), UpdatedAt: mustParseTime("2024-09-04T20:00:00")},
+		},
+	}).TodoService
+	isCompleted := false
+
+	t.Run("ShouldGetTodosByFilterWithCompletionAndTitle", func(t *testing.T) {
+		todoPage, _ := filterTodoService.GetTodosByFilter(1, request.TodoFilter{IsCompleted: &isCompleted, Title: "WORK"})
+		assert.Equal(t, 1, todoPage.Total)
+		assert.Equal(t, "Workout session", todoPage.Todos[0].Title)
+	})
+}
+
+func Test_ShouldGetTodosByFilterSortedByUpdatedAtDesc(t *testing.T) {
+	filterTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Buy groceries", Description: "Purchase fruits", IsCompleted: false, CreatedAt: mustParseTime("2024-09-01T10:00:00"), UpdatedAt: mustParseTime("2024-09-05T10:00:00")},
+			{Id: 2, UserId: 1, Title: "Complete assignment", Description: "Finish the report", IsCompleted: true, CreatedAt: mustParseTime("2024-09-02T09:30:00"), UpdatedAt: mustParseTime("2024-09-02T09:30:00")},
+			{Id: 3, UserId: 1, Title: "Workout session", Description: "Attend the gym", IsCompleted: false, CreatedAt: mustParseTime("2024-09-03T18:00:00"), UpdatedAt: mustParseTime("2024-09-03T18:00:00")},
+			{Id: 4, UserId: 2, Title: "Read a book", Description: "Start reading a new novel", IsCompleted: true, CreatedAt: mustParseTime("2024-09-04T20:00:00"), UpdatedAt: mustParseTime("2024-09-04T20:00:00")},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetTodosByFilterSortedByUpdatedAtDesc", func(t *testing.T) {
+		todoPage, _ := filterTodoService.GetTodosByFilter(1, request.TodoFilter{Sort: "updatedAt", Order: "desc"})
+		assert.Equal(t, []int{1, 3, 2}, []int{todoPage.Todos[0].Id, todoPage.Todos[1].Id, todoPage.Todos[2].Id})
+	})
+}
+
+func Test_ShouldNotGetTodosByFilterUnsupportedSort(t *testing.T) {
+	filterTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Buy groceries", Description: "Purchase fruits", IsCompleted: false, CreatedAt: mustParseTime("2024-09-01T10:00:00"), UpdatedAt: mustParseTime("2024-09-05T10:00:00")},
+			{Id: 2, UserId: 1, Title: "Complete assignment", Description: "Finish the report", IsCompleted: true, CreatedAt: mustParseTime("2024-09-02T09:30:00"), UpdatedAt: mustParseTime("2024-09-02T09:30:00")},
+			{Id: 3, UserId: 1, Title: "Workout session", Description: "Attend the gym", IsCompleted: false, CreatedAt: mustParseTime("2024-09-03T18:00:00"), UpdatedAt: mustParseTime("2024-09-03T18:00:00")},
+			{Id: 4, UserId: 2, Title: "Read a book", Description: "Start reading a new novel", IsCompleted: true, CreatedAt: mustParseTime("2024-09-04T20:00:00"), UpdatedAt: mustParseTime("2024-09-04T20:00:00")},
+		},
+	}).TodoService
+
+	t.Run("ShouldNotGetTodosByFilterUnsupportedSort", func(t *testing.T) {
+		_, err := filterTodoService.GetTodosByFilter(1, request.TodoFilter{Sort: "description"})
+		assert.Equal(t, "Unsupported sort field description", err.Error())
+	})
+}
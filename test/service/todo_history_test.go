@@ -0,0 +1,186 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/domain/response"
+)
+
+func revisionActions(revisions []response.TodoRevisionResponse) []string {
+	var actions []string
+	for _, revision := range revisions {
+		actions = append(actions, revision.Action)
+	}
+	return actions
+}
+
+func Test_ShouldRecordTodoHistory(t *testing.T) {
+	historyTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 2, Title: "Order supplies", Description: "Paper and toner", Status: domain.StatusTodo, Position: 1024},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 3, Permission: domain.PermissionEditor},
+		},
+	}).TodoService
+
+	t.Run("ShouldRecordCreatedTodo", func(t *testing.T) {
+		todo, _ := historyTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Plan offsite", Description: "Venue and agenda"})
+
+		historyPage, err := historyTodoService.GetTodoHistory(1, todo.Id, request.TodoHistoryFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, historyPage.Total)
+		assert.Equal(t, domain.TodoActionCreated, historyPage.Revisions[0].Action)
+		assert.Equal(t, "Plan offsite", historyPage.Revisions[0].Snapshot.Title)
+	})
+
+	t.Run("ShouldRecordFieldChangesWithActor", func(t *testing.T) {
+		historyTodoService.PatchTodo(3, 1, request.TodoPatch{Title: request.Patchable[string]{Set: true, Value: "Order office supplies"}})
+		historyTodoService.ToggleTodo(2, 1, request.TodoToggle{})
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionToggled, domain.TodoActionUpdated}, revisionActions(historyPage.Revisions))
+
+		update := historyPage.Revisions[1]
+		assert.Equal(t, 3, update.ActorId)
+		assert.Equal(t, []domain.TodoFieldChange{{Field: domain.TodoFieldTitle, OldValue: "Order supplies", NewValue: "Order office supplies"}}, update.Changes)
+		assert.Equal(t, 2, historyPage.Revisions[0].ActorId)
+	})
+
+	t.Run("ShouldRecordDeleteAndRestore", func(t *testing.T) {
+		historyTodoService.DeleteTodo(2, 1)
+		historyTodoService.RestoreTodo(2, 1)
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{Limit: 2})
+		assert.Equal(t, 4, historyPage.Total)
+		assert.Equal(t, []string{domain.TodoActionRestored, domain.TodoActionDeleted}, revisionActions(historyPage.Revisions))
+		assert.Empty(t, historyPage.Revisions[0].Changes)
+		assert.Equal(t, 2, historyPage.Revisions[1].ActorId)
+		assert.Equal(t, "Order office supplies", historyPage.Revisions[1].Snapshot.Title)
+	})
+
+	t.Run("ShouldNotGetHistoryOfOtherUsersTodo", func(t *testing.T) {
+		_, err := historyTodoService.GetTodoHistory(4, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldRecordStatusChangeAndAssignment(t *testing.T) {
+	historyTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 2, Title: "Order supplies", Description: "Paper and toner", Status: domain.StatusTodo, Position: 1024},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 3, Permission: domain.PermissionEditor},
+		},
+	}).TodoService
+
+	t.Run("ShouldRecordStatusChange", func(t *testing.T) {
+		_, err := historyTodoService.ChangeTodoStatus(3, 1, request.TodoStatusChange{Status: domain.StatusInProgress})
+		assert.Nil(t, err)
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionUpdated}, revisionActions(historyPage.Revisions))
+		assert.Equal(t, 3, historyPage.Revisions[0].ActorId)
+		assert.Equal(t, []domain.TodoFieldChange{{Field: domain.TodoFieldStatus, OldValue: domain.StatusTodo, NewValue: domain.StatusInProgress}}, historyPage.Revisions[0].Changes)
+	})
+
+	t.Run("ShouldRecordAssignment", func(t *testing.T) {
+		_, err := historyTodoService.AssignTodo(2, 1, request.TodoAssign{AssigneeId: intPointer(3)})
+		assert.Nil(t, err)
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionAssigned, domain.TodoActionUpdated}, revisionActions(historyPage.Revisions))
+		assert.Equal(t, 2, historyPage.Revisions[0].ActorId)
+		assert.Equal(t, []domain.TodoFieldChange{{Field: domain.TodoFieldAssigneeId, OldValue: nil, NewValue: 3}}, historyPage.Revisions[0].Changes)
+		assert.Equal(t, intPointer(3), historyPage.Revisions[0].Snapshot.AssigneeId)
+	})
+
+	t.Run("ShouldNotRecordUnchangedAssignment", func(t *testing.T) {
+		historyTodoService.AssignTodo(2, 1, request.TodoAssign{AssigneeId: intPointer(3)})
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, 2, historyPage.Total)
+	})
+}
+
+func Test_ShouldRevertTodo(t *testing.T) {
+	historyTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 2, Title: "Order supplies", Description: "Paper and toner", Status: domain.StatusTodo, Position: 1024},
+		},
+		Shares: []domain.Share{
+			{Id: 1, TodoId: intPointer(1), UserId: 3, Permission: domain.PermissionEditor},
+		},
+	}).TodoService
+	historyTodoService.PatchTodo(2, 1, request.TodoPatch{
+		Title:    request.Patchable[string]{Set: true, Value: "Order toner"},
+		Priority: request.Patchable[string]{Set: true, Value: "high"},
+	})
+	historyTodoService.PatchTodo(2, 1, request.TodoPatch{Description: request.Patchable[string]{Set: true, Value: "Black toner only"}})
+	historyTodoService.ToggleTodo(2, 1, request.TodoToggle{})
+
+	t.Run("ShouldRevertTodoToEarlierRevision", func(t *testing.T) {
+		todo, err := historyTodoService.RevertTodo(3, 1, request.TodoRevert{RevisionId: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, "Order toner", todo.Title)
+		assert.Equal(t, "Paper and toner", todo.Description)
+		assert.Equal(t, "high", todo.Priority)
+		assert.False(t, todo.IsCompleted)
+		assert.Equal(t, domain.StatusTodo, todo.Status)
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, domain.TodoActionReverted, historyPage.Revisions[0].Action)
+		assert.Equal(t, 3, historyPage.Revisions[0].ActorId)
+	})
+
+	t.Run("ShouldNotRevertTodoToRevisionOfAnotherTodo", func(t *testing.T) {
+		otherTodo, _ := historyTodoService.AddTodo(request.TodoCreate{UserId: 2, Title: "Book room", Description: "Meeting room"})
+		otherHistory, _ := historyTodoService.GetTodoHistory(2, otherTodo.Id, request.TodoHistoryFilter{})
+
+		_, err := historyTodoService.RevertTodo(2, 1, request.TodoRevert{RevisionId: otherHistory.Revisions[0].Id})
+		assert.Equal(t, "Revision with id 5 not found", err.Error())
+	})
+}
+
+func Test_ShouldRecordTagChangesAndMoves(t *testing.T) {
+	historyTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 2, Title: "Order supplies", Description: "Paper and toner", Status: domain.StatusTodo, Position: 1024},
+			{Id: 2, UserId: 2, Title: "Plan offsite", Description: "Venue and agenda", Status: domain.StatusTodo, Position: 2048},
+		},
+		Tags: []domain.Tag{
+			{Id: 1, UserId: 2, Name: "office"},
+			{Id: 2, UserId: 2, Name: "urgent"},
+		},
+	}).TodoService
+
+	t.Run("ShouldRecordTagChangesOnUpdate", func(t *testing.T) {
+		_, err := historyTodoService.UpdateTodo(1, request.TodoUpdate{UserId: 2, Title: "Order supplies", Description: "Paper and toner", AttachTagIds: []int{2, 1}})
+		assert.Nil(t, err)
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionUpdated}, revisionActions(historyPage.Revisions))
+		assert.Equal(t, []domain.TodoFieldChange{{Field: domain.TodoFieldTagIds, OldValue: []int{}, NewValue: []int{1, 2}}}, historyPage.Revisions[0].Changes)
+	})
+
+	t.Run("ShouldRecordReparent", func(t *testing.T) {
+		_, err := historyTodoService.ReparentTodo(2, 1, request.TodoReparent{ParentId: intPointer(2)})
+		assert.Nil(t, err)
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 1, request.TodoHistoryFilter{})
+		assert.Equal(t, domain.TodoActionMoved, historyPage.Revisions[0].Action)
+		assert.Equal(t, []domain.TodoFieldChange{{Field: domain.TodoFieldParentId, OldValue: nil, NewValue: 2}}, historyPage.Revisions[0].Changes)
+	})
+
+	t.Run("ShouldRecordPositionMove", func(t *testing.T) {
+		_, err := historyTodoService.MoveTodo(2, 2, request.TodoPosition{BeforeId: intPointer(1)})
+		assert.Nil(t, err)
+
+		historyPage, _ := historyTodoService.GetTodoHistory(2, 2, request.TodoHistoryFilter{})
+		assert.Equal(t, []string{domain.TodoActionMoved}, revisionActions(historyPage.Revisions))
+		assert.Equal(t, []domain.TodoFieldChange{{Field: domain.TodoFieldPosition, OldValue: 2048.0, NewValue: 0.0}}, historyPage.Revisions[0].Changes)
+	})
+}
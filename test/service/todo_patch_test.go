@@ -0,0 +1,91 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldPatchOnlySuppliedFields(t *testing.T) {
+	patchTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report", Description: "Quarterly numbers", IsCompleted: true, DueDate: datePointer(2024, time.March, 1), DueTime: stringPointer("09:00")},
+			{Id: 2, UserId: 2, Title: "Someone else's todo", Description: "Not yours"},
+		},
+	}).TodoService
+
+	t.Run("ShouldPatchOnlySuppliedFields", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"title": "Write annual report"}`))
+		todo, err := patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Nil(t, err)
+		assert.Equal(t, "Write annual report", todo.Title)
+		assert.Equal(t, "Quarterly numbers", todo.Description)
+		assert.True(t, todo.IsCompleted)
+	})
+
+	t.Run("ShouldClearNullFields", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"dueTime": null}`))
+		todo, _ := patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Nil(t, todo.DueTime)
+		assert.Equal(t, "2024-03-01", *todo.DueDate)
+	})
+
+	t.Run("ShouldApplyJsonPatch", func(t *testing.T) {
+		todoPatch, err := request.ParseTodoJsonPatch([]byte(`[{"op": "replace", "path": "/isCompleted", "value": false}, {"op": "remove", "path": "/dueDate"}]`))
+		assert.Nil(t, err)
+		todo, _ := patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.False(t, todo.IsCompleted)
+		assert.Nil(t, todo.DueDate)
+	})
+
+	t.Run("ShouldPatchAndClearEstimate", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"estimateMinutes": 90}`))
+		todo, err := patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Nil(t, err)
+		assert.Equal(t, 90, *todo.EstimateMinutes)
+
+		todoPatch, _ = request.ParseTodoMergePatch([]byte(`{"estimateMinutes": null}`))
+		todo, _ = patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Nil(t, todo.EstimateMinutes)
+	})
+}
+
+func Test_ShouldRejectInvalidPatch(t *testing.T) {
+	patchTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report", Description: "Quarterly numbers", IsCompleted: true, DueDate: datePointer(2024, time.March, 1), DueTime: stringPointer("09:00")},
+			{Id: 2, UserId: 2, Title: "Someone else's todo", Description: "Not yours"},
+		},
+	}).TodoService
+
+	t.Run("ShouldRejectNullTitle", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"title": null}`))
+		_, err := patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Equal(t, "Todo title, description and isCompleted cannot be null", err.Error())
+	})
+
+	t.Run("ShouldRejectNonPositiveEstimate", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"estimateMinutes": 0}`))
+		_, err := patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Equal(t, "Estimate must be between 1 and 60000 minutes", err.Error())
+	})
+
+	t.Run("ShouldRejectUnknownField", func(t *testing.T) {
+		_, err := request.ParseTodoMergePatch([]byte(`{"userId": 2}`))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ShouldRejectDueTimeWithoutDueDate", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"dueDate": null}`))
+		_, err := patchTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Equal(t, "Due time and timezone require a due date", err.Error())
+	})
+
+	t.Run("ShouldNotPatchOthersTodo", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"isCompleted": true}`))
+		_, err := patchTodoService.PatchTodo(1, 2, todoPatch)
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func todoIds(todos []domain.Todo) []int {
+	var ids []int
+	for _, todo := range todos {
+		ids = append(ids, todo.Id)
+	}
+	return ids
+}
+
+func Test_ShouldAddTodoWithPriority(t *testing.T) {
+	priorityTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report", Description: "Quarterly report", Priority: domain.PriorityHigh, Position: 1024},
+			{Id: 2, UserId: 1, Title: "Book flights", Description: "Flights to Berlin", Priority: domain.PriorityLow, Position: 2048},
+			{Id: 3, UserId: 1, Title: "Call plumber", Description: "Kitchen sink leak", Priority: domain.PriorityUrgent, Position: 3072},
+			{Id: 4, UserId: 2, Title: "Water plants", Description: "Balcony plants", Position: 1024},
+		},
+	}).TodoService
+
+	t.Run("ShouldAddTodoWithPriority", func(t *testing.T) {
+		todo, err := priorityTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Pay rent", Description: "Transfer the rent", Priority: "medium"})
+		assert.Nil(t, err)
+		assert.Equal(t, "medium", todo.Priority)
+		assert.Equal(t, 4096.0, todo.Position)
+	})
+
+	t.Run("ShouldNotAddTodoWithUnsupportedPriority", func(t *testing.T) {
+		_, err := priorityTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Pay rent", Description: "Transfer the rent", Priority: "critical"})
+		assert.Equal(t, "Unsupported priority critical", err.Error())
+	})
+}
+
+func Test_ShouldGetTodosByFilterWithPriority(t *testing.T) {
+	priorityTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report", Description: "Quarterly report", Priority: domain.PriorityHigh, Position: 1024},
+			{Id: 2, UserId: 1, Title: "Book flights", Description: "Flights to Berlin", Priority: domain.PriorityLow, Position: 2048},
+			{Id: 3, UserId: 1, Title: "Call plumber", Description: "Kitchen sink leak", Priority: domain.PriorityUrgent, Position: 3072},
+			{Id: 4, UserId: 2, Title: "Water plants", Description: "Balcony plants", Position: 1024},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetTodosByFilterWithPriority", func(t *testing.T) {
+		todoPage, err := priorityTodoService.GetTodosByFilter(1, request.TodoFilter{Priority: "urgent"})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(todoPage.Todos))
+		assert.Equal(t, 3, todoPage.Todos[0].Id)
+	})
+
+	t.Run("ShouldGetTodosByFilterSortedByPriorityDesc", func(t *testing.T) {
+		todoPage, _ := priorityTodoService.GetTodosByFilter(1, request.TodoFilter{Sort: "priority", Order: "desc"})
+		assert.Equal(t, 3, todoPage.Todos[0].Id)
+		assert.Equal(t, 2, todoPage.Todos[2].Id)
+	})
+}
+
+func Test_ShouldMoveTodo(t *testing.T) {
+	priorityTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write report", Description: "Quarterly report", Priority: domain.PriorityHigh, Position: 1024},
+			{Id: 2, UserId: 1, Title: "Book flights", Description: "Flights to Berlin", Priority: domain.PriorityLow, Position: 2048},
+			{Id: 3, UserId: 1, Title: "Call plumber", Description: "Kitchen sink leak", Priority: domain.PriorityUrgent, Position: 3072},
+			{Id: 4, UserId: 2, Title: "Water plants", Description: "Balcony plants", Position: 1024},
+		},
+	}).TodoService
+
+	t.Run("ShouldMoveTodoBeforeAnotherTodo", func(t *testing.T) {
+		todo, err := priorityTodoService.MoveTodo(1, 3, request.TodoPosition{BeforeId: intPointer(1)})
+		assert.Nil(t, err)
+		assert.Equal(t, 0.0, todo.Position)
+
+		todos, _ := priorityTodoService.GetAllTodos(1)
+		assert.Equal(t, []int{3, 1, 2}, []int{todos[0].Id, todos[1].Id, todos[2].Id})
+	})
+
+	t.Run("ShouldMoveTodoAfterAnotherTodo", func(t *testing.T) {
+		todo, err := priorityTodoService.MoveTodo(1, 3, request.TodoPosition{AfterId: intPointer(1)})
+		assert.Nil(t, err)
+		assert.Equal(t, 1536.0, todo.Position)
+
+		todos, _ := priorityTodoService.GetAllTodos(1)
+		assert.Equal(t, []int{1, 3, 2}, []int{todos[0].Id, todos[1].Id, todos[2].Id})
+	})
+
+	t.Run("ShouldNotMoveTodoWithoutExactlyOneAnchor", func(t *testing.T) {
+		_, err := priorityTodoService.MoveTodo(1, 3, request.TodoPosition{BeforeId: intPointer(1), AfterId: intPointer(2)})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ShouldNotMoveTodoNextToOtherUsersTodo", func(t *testing.T) {
+		_, err := priorityTodoService.MoveTodo(1, 3, request.TodoPosition{AfterId: intPointer(4)})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
+
+func Test_ShouldRebalancePositionsWhenGapIsExhausted(t *testing.T) {
+	priorityTestServices := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "First todo", Description: "First position", Position: 1},
+			{Id: 2, UserId: 1, Title: "Second todo", Description: "Second position", Position: 1},
+			{Id: 3, UserId: 1, Title: "Third todo", Description: "Third position", Position: 2},
+		},
+	})
+	priorityTodoService := priorityTestServices.TodoService
+
+	t.Run("ShouldRebalancePositionsWhenGapIsExhausted", func(t *testing.T) {
+		_, err := priorityTodoService.MoveTodo(1, 3, request.TodoPosition{AfterId: intPointer(1)})
+		assert.Nil(t, err)
+
+		todos, _ := priorityTestServices.TodoRepository.GetAllTodosByUserId(1)
+		assert.Equal(t, []int{1, 3, 2}, todoIds(todos))
+	})
+}
+
+func Test_ShouldKeepVersionOfTodosNotMovedByRebalance(t *testing.T) {
+	priorityTestServices := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "First todo", Description: "First position", Position: 1, Version: 1},
+			{Id: 2, UserId: 1, Title: "Second todo", Description: "Second position", Position: 1, Version: 1},
+			{Id: 3, UserId: 1, Title: "Third todo", Description: "Third position", Position: 2, Version: 1},
+			{Id: 4, UserId: 1, Title: "Fourth todo", Description: "Fourth position", Position: 4096, Version: 1},
+		},
+	})
+	priorityTodoService := priorityTestServices.TodoService
+
+	t.Run("ShouldKeepVersionOfTodosNotMovedByRebalance", func(t *testing.T) {
+		_, err := priorityTodoService.MoveTodo(1, 3, request.TodoPosition{AfterId: intPointer(1)})
+		assert.Nil(t, err)
+
+		todo, _ := priorityTodoService.GetTodoById(1, 4)
+		assert.Equal(t, 1, todo.Version)
+		todo, _ = priorityTodoService.GetTodoById(1, 2)
+		assert.Equal(t, 2, todo.Version)
+	})
+}
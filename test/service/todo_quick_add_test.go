@@ -0,0 +1,121 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/common/util/quickadd"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+// quickAddTestNow is a Wednesday.
+var quickAddTestNow = time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC)
+
+var quickAddTestTags = []domain.Tag{
+	{Id: 1, UserId: 1, Name: "Home"},
+	{Id: 2, UserId: 1, Name: "work"},
+	{Id: 3, UserId: 2, Name: "errands"},
+}
+
+func Test_ShouldParseQuickAddText(t *testing.T) {
+	testCases := []struct {
+		text           string
+		title          string
+		dueDate        *string
+		dueTime        *string
+		recurrenceRule *string
+	}{
+		{"Call mom friday", "Call mom", stringPointer("2024-03-08"), nil, nil},
+		{"Call mom wednesday", "Call mom", stringPointer("2024-03-13"), nil, nil},
+		{"Plan trip next week", "Plan trip", stringPointer("2024-03-11"), nil, nil},
+		{"Submit report in 3 days", "Submit report", stringPointer("2024-03-09"), nil, nil},
+		{"Dentist on jun 5th at 2pm", "Dentist", stringPointer("2024-06-05"), stringPointer("14:00"), nil},
+		{"Leap day party feb 29", "Leap day party", stringPointer("2028-02-29"), nil, nil},
+		{"Release 2024-04-01 today", "Release today", stringPointer("2024-04-01"), nil, nil},
+		{"Send invoice 9am", "Send invoice", stringPointer("2024-03-07"), stringPointer("09:00"), nil},
+		{"Send invoice 9:30pm", "Send invoice", stringPointer("2024-03-06"), stringPointer("21:30"), nil},
+		{"Lunch with team noon", "Lunch with team", stringPointer("2024-03-06"), stringPointer("12:00"), nil},
+		{"Gym every monday", "Gym", stringPointer("2024-03-11"), nil, stringPointer("FREQ=WEEKLY;BYDAY=MO")},
+		{"Standup every weekday at 9:30am", "Standup", stringPointer("2024-03-06"), stringPointer("09:30"), stringPointer("FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR")},
+		{"Water plants every other week", "Water plants", stringPointer("2024-03-06"), nil, stringPointer("FREQ=WEEKLY;INTERVAL=2")},
+		{"Backup every 3 days tomorrow", "Backup", stringPointer("2024-03-07"), nil, stringPointer("FREQ=DAILY;INTERVAL=3")},
+		{"Sat down with mom at the cafe", "Sat down with mom at the cafe", nil, nil, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.text, func(t *testing.T) {
+			result := quickadd.Parse(testCase.text, quickAddTestNow)
+			assert.Equal(t, testCase.title, result.Title)
+			assert.Equal(t, testCase.dueDate, result.DueDate)
+			assert.Equal(t, testCase.dueTime, result.DueTime)
+			assert.Equal(t, testCase.recurrenceRule, result.RecurrenceRule)
+		})
+	}
+
+	t.Run("ShouldRecogniseTagsAndFirstPriority", func(t *testing.T) {
+		result := quickadd.Parse("Pay rent #home !high #Home !low", quickAddTestNow)
+		assert.Equal(t, "Pay rent !low", result.Title)
+		assert.Equal(t, "high", result.Priority)
+		assert.Equal(t, []string{"home"}, result.Tags)
+		assert.Equal(t, []quickadd.Token{
+			{Text: "#home", Kind: quickadd.KindTag, Value: "home"},
+			{Text: "!high", Kind: quickadd.KindPriority, Value: "high"},
+			{Text: "#Home", Kind: quickadd.KindTag, Value: "Home"},
+		}, result.Tokens)
+	})
+}
+
+func Test_ShouldQuickAddTodo(t *testing.T) {
+	t.Run("ShouldAddParsedTodo", func(t *testing.T) {
+		quickAddService := NewTestServices(TestFixture{
+			Tags: quickAddTestTags,
+			Now:  quickAddTestNow,
+		}).QuickAddService
+
+		quickAddResult, err := quickAddService.QuickAddTodo(request.TodoQuickAdd{UserId: 1, Text: "Pay rent tomorrow 9am #home #errands !high every month"})
+		assert.Nil(t, err)
+
+		todo := quickAddResult.Todo
+		assert.Equal(t, "Pay rent", todo.Title)
+		assert.Equal(t, "Pay rent tomorrow 9am #home #errands !high every month", todo.Description)
+		assert.Equal(t, "2024-03-07", *todo.DueDate)
+		assert.Equal(t, "09:00", *todo.DueTime)
+		assert.Equal(t, "high", todo.Priority)
+		assert.Equal(t, "FREQ=MONTHLY", *todo.RecurrenceRule)
+		assert.Equal(t, "Home", todo.Tags[0].Name)
+
+		assert.Equal(t, []string{"Home"}, quickAddResult.Parsed.Tags)
+		assert.Equal(t, []string{"errands"}, quickAddResult.Parsed.UnknownTags)
+		assert.Equal(t, 6, len(quickAddResult.Parsed.Tokens))
+	})
+
+	t.Run("ShouldResolveDatesInTimezone", func(t *testing.T) {
+		quickAddService := NewTestServices(TestFixture{
+			Tags: quickAddTestTags,
+			Now:  time.Date(2024, 3, 6, 23, 30, 0, 0, time.UTC),
+		}).QuickAddService
+
+		quickAddResult, err := quickAddService.QuickAddTodo(request.TodoQuickAdd{UserId: 1, Text: "Book flights tomorrow 8am", Description: "Trip to Osaka", Timezone: stringPointer("Asia/Tokyo")})
+		assert.Nil(t, err)
+		assert.Equal(t, "2024-03-08", *quickAddResult.Todo.DueDate)
+		assert.Equal(t, "Asia/Tokyo", *quickAddResult.Todo.DueTimezone)
+		assert.Equal(t, "Trip to Osaka", quickAddResult.Todo.Description)
+	})
+
+	t.Run("ShouldValidateParsedTodo", func(t *testing.T) {
+		quickAddService := NewTestServices(TestFixture{
+			Tags: quickAddTestTags,
+			Now:  quickAddTestNow,
+		}).QuickAddService
+
+		_, err := quickAddService.QuickAddTodo(request.TodoQuickAdd{UserId: 1, Text: "Go tomorrow"})
+		assert.Equal(t, "Todo title must be at least 3 characters long", err.Error())
+
+		_, err = quickAddService.QuickAddTodo(request.TodoQuickAdd{UserId: 1, Text: "  "})
+		assert.Equal(t, "Quick add text cannot be empty", err.Error())
+
+		_, err = quickAddService.QuickAddTodo(request.TodoQuickAdd{UserId: 1, Text: "Book flights tomorrow", Timezone: stringPointer("Mars/Olympus")})
+		assert.Equal(t, "Unknown timezone Mars/Olympus", err.Error())
+	})
+}
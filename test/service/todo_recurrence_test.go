@@ -0,0 +1,104 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/common/util/recurrence"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func datePointer(year int, month time.Month, day int) *time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+func Test_ShouldExpandRecurrenceRule(t *testing.T) {
+	t.Run("ShouldSkipMonthsWithoutTheDay", func(t *testing.T) {
+		rule, _ := recurrence.Parse("FREQ=MONTHLY")
+		occurrences := rule.Occurrences(*datePointer(2024, time.January, 31), 3)
+		assert.Equal(t, *datePointer(2024, time.March, 31), occurrences[1])
+		assert.Equal(t, *datePointer(2024, time.May, 31), occurrences[2])
+	})
+
+	t.Run("ShouldStopAtUntil", func(t *testing.T) {
+		rule, _ := recurrence.Parse("FREQ=DAILY;INTERVAL=2;UNTIL=20240105")
+		occurrences := rule.Occurrences(*datePointer(2024, time.January, 1), 10)
+		assert.Equal(t, 3, len(occurrences))
+	})
+
+	t.Run("ShouldRejectUntilWithCount", func(t *testing.T) {
+		_, err := recurrence.Parse("FREQ=DAILY;COUNT=2;UNTIL=20240105")
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_ShouldGenerateNextOccurrenceWhenCompleted(t *testing.T) {
+	recurrenceTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Weekly review", DueDate: datePointer(2024, time.January, 1), RecurrenceRule: stringPointer("FREQ=WEEKLY;BYDAY=MO,FR"), OccurrenceIndex: 1},
+			{Id: 2, UserId: 1, Title: "Monthly report", DueDate: datePointer(2024, time.January, 31), RecurrenceRule: stringPointer("FREQ=MONTHLY;COUNT=2"), OccurrenceIndex: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldGenerateNextOccurrenceWhenCompleted", func(t *testing.T) {
+		recurrenceTodoService.ToggleTodo(1, 1, request.TodoToggle{})
+		nextTodo, err := recurrenceTodoService.GetTodoById(1, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, "2024-01-05", *nextTodo.DueDate)
+		assert.Equal(t, 1, *nextTodo.SeriesId)
+		assert.Equal(t, 2, nextTodo.OccurrenceIndex)
+	})
+
+	t.Run("ShouldNotDuplicateOccurrenceWhenCompletedAgain", func(t *testing.T) {
+		recurrenceTodoService.ToggleTodo(1, 1, request.TodoToggle{})
+		recurrenceTodoService.ToggleTodo(1, 1, request.TodoToggle{})
+		_, err := recurrenceTodoService.GetTodoById(1, 4)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_ShouldStopGeneratingWhenCountReached(t *testing.T) {
+	recurrenceTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Weekly review", DueDate: datePointer(2024, time.January, 1), RecurrenceRule: stringPointer("FREQ=WEEKLY;BYDAY=MO,FR"), OccurrenceIndex: 1},
+			{Id: 2, UserId: 1, Title: "Monthly report", DueDate: datePointer(2024, time.January, 31), RecurrenceRule: stringPointer("FREQ=MONTHLY;COUNT=2"), OccurrenceIndex: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldStopGeneratingWhenCountReached", func(t *testing.T) {
+		recurrenceTodoService.ToggleTodo(1, 2, request.TodoToggle{})
+		recurrenceTodoService.ToggleTodo(1, 3, request.TodoToggle{})
+		nextTodo, _ := recurrenceTodoService.GetTodoById(1, 3)
+		assert.Equal(t, "2024-03-31", *nextTodo.DueDate)
+		_, err := recurrenceTodoService.GetTodoById(1, 4)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_ShouldGetNextOccurrences(t *testing.T) {
+	recurrenceTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Weekly review", DueDate: datePointer(2024, time.January, 1), RecurrenceRule: stringPointer("FREQ=WEEKLY;BYDAY=MO,FR"), OccurrenceIndex: 1},
+			{Id: 2, UserId: 1, Title: "Monthly report", DueDate: datePointer(2024, time.January, 31), RecurrenceRule: stringPointer("FREQ=MONTHLY;COUNT=2"), OccurrenceIndex: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetNextOccurrences", func(t *testing.T) {
+		occurrences, _ := recurrenceTodoService.GetOccurrences(1, 1, 3)
+		assert.Equal(t, 3, len(occurrences))
+		assert.Equal(t, "2024-01-05", occurrences[0].DueDate)
+		assert.Equal(t, "2024-01-08", occurrences[1].DueDate)
+		assert.Equal(t, 4, occurrences[2].OccurrenceIndex)
+	})
+
+	t.Run("ShouldRejectRecurrenceWithoutDueDate", func(t *testing.T) {
+		_, err := recurrenceTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Water plants", Description: "Every few days", RecurrenceRule: stringPointer("FREQ=DAILY;INTERVAL=3")})
+		assert.Equal(t, "Recurring todos require a due date", err.Error())
+	})
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func Test_ShouldSearchTodosByRelevance(t *testing.T) {
+	searchTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Renew car insurance", Description: "Compare offers before March", DueDate: datePointer(2024, time.March, 1)},
+			{Id: 2, UserId: 1, Title: "Call the bank", Description: "Ask about home insurance discount", IsCompleted: true},
+			{Id: 3, UserId: 1, Title: "Buy groceries", Description: "Milk, eggs and bread"},
+			{Id: 4, UserId: 2, Title: "Insurance claim", Description: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldSearchTodosByRelevance", func(t *testing.T) {
+		searchPage, err := searchTodoService.SearchTodos(1, request.TodoSearchFilter{Query: "insur"})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, searchPage.Total)
+		assert.Equal(t, 1, searchPage.Results[0].Id)
+		assert.Equal(t, "Renew car <mark>insurance</mark>", searchPage.Results[0].Highlights.Title)
+	})
+
+	t.Run("ShouldRequireEveryWord", func(t *testing.T) {
+		searchPage, _ := searchTodoService.SearchTodos(1, request.TodoSearchFilter{Query: "insurance home"})
+		assert.Equal(t, 1, searchPage.Total)
+		assert.Equal(t, 2, searchPage.Results[0].Id)
+	})
+
+	t.Run("ShouldRejectEmptyQuery", func(t *testing.T) {
+		_, err := searchTodoService.SearchTodos(1, request.TodoSearchFilter{Query: "  "})
+		assert.Equal(t, "Search query cannot be empty", err.Error())
+	})
+}
+
+func Test_ShouldCombineSearchWithFilters(t *testing.T) {
+	searchTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Renew car insurance", Description: "Compare offers before March", DueDate: datePointer(2024, time.March, 1)},
+			{Id: 2, UserId: 1, Title: "Call the bank", Description: "Ask about home insurance discount", IsCompleted: true},
+			{Id: 3, UserId: 1, Title: "Buy groceries", Description: "Milk, eggs and bread"},
+			{Id: 4, UserId: 2, Title: "Insurance claim", Description: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldCombineSearchWithCompletionFilter", func(t *testing.T) {
+		isCompleted := false
+		searchPage, _ := searchTodoService.SearchTodos(1, request.TodoSearchFilter{Query: "insurance", TodoFilter: request.TodoFilter{IsCompleted: &isCompleted}})
+		assert.Equal(t, 1, searchPage.Total)
+		assert.Equal(t, 1, searchPage.Results[0].Id)
+	})
+
+	t.Run("ShouldCombineSearchWithDueDateFilter", func(t *testing.T) {
+		searchPage, _ := searchTodoService.SearchTodos(1, request.TodoSearchFilter{Query: "insurance", TodoFilter: request.TodoFilter{DueFrom: datePointer(2024, time.April, 1)}})
+		assert.Equal(t, 0, searchPage.Total)
+		assert.NotNil(t, searchPage.Results)
+	})
+}
@@ -11,7 +11,7 @@ import (
 func Test_ShouldGetAllTodo(t *testing.T) {
 	t.Run("ShouldGetAllTodo", func(t *testing.T) {
 		actualTodos, _ := todoService.GetAllTodos(1)
-		assert.Equal(t, 4, len(actualTodos))
+		assert.Equal(t, 3, len(actualTodos))
 	})
 }
 
@@ -58,9 +58,9 @@ func Test_ShouldNotGetTodoByIdInvalidId(t *testing.T) {
 
 func Test_ShouldAddTodo(t *testing.T) {
 	t.Run("ShouldAddTodo", func(t *testing.T) {
-		todoService.AddTodo(request.TodoCreate{Title: "title", Description: "description"})
+		todoService.AddTodo(request.TodoCreate{UserId: 1, Title: "title", Description: "description"})
 		actualTodos, _ := todoService.GetAllTodos(1)
-		assert.Equal(t, 5, len(actualTodos))
+		assert.Equal(t, 4, len(actualTodos))
 	})
 }
 
@@ -94,7 +94,7 @@ func Test_ShouldUpdateTodo(t *testing.T) {
 	}
 
 	t.Run("ShouldUpdateTodo", func(t *testing.T) {
-		todoService.UpdateTodo(1, 1, request.TodoUpdate{
+		todoService.UpdateTodo(1, request.TodoUpdate{
 			UserId:      1,
 			Title:       "Buy groceries updated",
 			Description: "Purchase fruits, vegetables, and bread",
@@ -108,7 +108,7 @@ func Test_ShouldUpdateTodo(t *testing.T) {
 
 func Test_ShouldNotUpdateTodoInvalidId(t *testing.T) {
 	t.Run("ShouldNotUpdateTodoInvalidId", func(t *testing.T) {
-		_, err := todoService.UpdateTodo(1, 6, request.TodoUpdate{
+		_, err := todoService.UpdateTodo(6, request.TodoUpdate{
 			UserId:      1,
 			Title:       "Buy groceries updated",
 			Description: "Purchase fruits, vegetables, and bread",
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func Test_ShouldGetTodoWithNestedSubtasks(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+			{Id: 4, UserId: 1, Title: "Push the tag", ParentId: intPointer(3)},
+			{Id: 5, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldGetTodoWithNestedSubtasks", func(t *testing.T) {
+		todo, _ := subtaskTodoService.GetTodoById(1, 1)
+		assert.Equal(t, 2, len(todo.Subtasks))
+		assert.Equal(t, 4, todo.Subtasks[1].Subtasks[0].Id)
+	})
+}
+
+func Test_ShouldCompleteSubtasksWhenRequested(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+			{Id: 4, UserId: 1, Title: "Push the tag", ParentId: intPointer(3)},
+			{Id: 5, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldCompleteSubtasksWhenRequested", func(t *testing.T) {
+		todo, _ := subtaskTodoService.ToggleTodo(1, 1, request.TodoToggle{CompleteSubtasks: true})
+		assert.True(t, todo.IsCompleted)
+		assert.True(t, todo.Subtasks[0].IsCompleted)
+		assert.True(t, todo.Subtasks[1].Subtasks[0].IsCompleted)
+	})
+}
+
+func Test_ShouldCompleteParentWhenLastSubtaskCompleted(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+			{Id: 4, UserId: 1, Title: "Push the tag", ParentId: intPointer(3)},
+			{Id: 5, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldCompleteParentWhenLastSubtaskCompleted", func(t *testing.T) {
+		subtaskTodoService.ToggleTodo(1, 2, request.TodoToggle{})
+		parent, _ := subtaskTodoService.GetTodoById(1, 1)
+		assert.False(t, parent.IsCompleted)
+
+		subtaskTodoService.ToggleTodo(1, 4, request.TodoToggle{})
+		parent, _ = subtaskTodoService.GetTodoById(1, 1)
+		assert.True(t, parent.Subtasks[1].IsCompleted)
+		assert.True(t, parent.IsCompleted)
+	})
+}
+
+func Test_ShouldCompleteParentWhenLastSubtaskUpdatedToCompleted(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1), IsCompleted: true},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+		},
+	}).TodoService
+
+	t.Run("ShouldCompleteParentWhenLastSubtaskUpdatedToCompleted", func(t *testing.T) {
+		_, err := subtaskTodoService.UpdateTodo(3, request.TodoUpdate{UserId: 1, Title: "Tag the release", Description: "Tag and sign the release commit", IsCompleted: true})
+		assert.Nil(t, err)
+
+		parent, _ := subtaskTodoService.GetTodoById(1, 1)
+		assert.True(t, parent.IsCompleted)
+	})
+}
+
+func Test_ShouldCompleteParentWhenLastSubtaskPatchedToCompleted(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1), IsCompleted: true},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+		},
+	}).TodoService
+
+	t.Run("ShouldCompleteParentWhenLastSubtaskPatchedToCompleted", func(t *testing.T) {
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"isCompleted": true}`))
+		_, err := subtaskTodoService.PatchTodo(1, 3, todoPatch)
+		assert.Nil(t, err)
+
+		parent, _ := subtaskTodoService.GetTodoById(1, 1)
+		assert.True(t, parent.IsCompleted)
+	})
+}
+
+func Test_ShouldNotReparentTodoUnderItsSubtask(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+			{Id: 4, UserId: 1, Title: "Push the tag", ParentId: intPointer(3)},
+			{Id: 5, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldNotReparentTodoUnderItsSubtask", func(t *testing.T) {
+		_, err := subtaskTodoService.ReparentTodo(1, 1, request.TodoReparent{ParentId: intPointer(4)})
+		assert.Equal(t, "A todo cannot be moved under itself or one of its subtasks", err.Error())
+	})
+}
+
+func Test_ShouldNotAddSubtaskDeeperThanMaxDepth(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+			{Id: 4, UserId: 1, Title: "Push the tag", ParentId: intPointer(3)},
+			{Id: 5, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldNotAddSubtaskDeeperThanMaxDepth", func(t *testing.T) {
+		_, err := subtaskTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Level three", Description: "Third level subtask", ParentId: intPointer(4)})
+		assert.Nil(t, err)
+		_, err = subtaskTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Level four", Description: "Fourth level subtask", ParentId: intPointer(6)})
+		assert.Equal(t, "Subtasks cannot be nested deeper than 3 levels", err.Error())
+	})
+}
+
+func Test_ShouldNotAddSubtaskToTodoOfAnotherUser(t *testing.T) {
+	subtaskTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Release version 2"},
+			{Id: 2, UserId: 1, Title: "Write changelog", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Tag the release", ParentId: intPointer(1)},
+			{Id: 4, UserId: 1, Title: "Push the tag", ParentId: intPointer(3)},
+			{Id: 5, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldNotAddSubtaskToTodoOfAnotherUser", func(t *testing.T) {
+		_, err := subtaskTodoService.AddTodo(request.TodoCreate{UserId: 1, Title: "Sneaky subtask", Description: "Not allowed here", ParentId: intPointer(5)})
+		assert.Equal(t, "This todo is not belongs to you", err.Error())
+	})
+}
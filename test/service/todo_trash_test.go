@@ -0,0 +1,97 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todo-app--go-gin/domain"
+)
+
+func Test_ShouldMoveDeletedTodoToTrash(t *testing.T) {
+	trashTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Plan trip"},
+			{Id: 2, UserId: 1, Title: "Book flights", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Renew passport"},
+			{Id: 4, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldMoveDeletedTodoToTrash", func(t *testing.T) {
+		trashTodoService.DeleteTodo(1, 1)
+		todos, _ := trashTodoService.GetAllTodos(1)
+		assert.Equal(t, 1, len(todos))
+
+		trash, _ := trashTodoService.GetTrash(1)
+		assert.Equal(t, 2, len(trash))
+		assert.NotNil(t, trash[0].DeletedAt)
+	})
+
+	t.Run("ShouldRestoreTodoWithSubtasks", func(t *testing.T) {
+		todo, err := trashTodoService.RestoreTodo(1, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(todo.Subtasks))
+
+		trash, _ := trashTodoService.GetTrash(1)
+		assert.Equal(t, 0, len(trash))
+	})
+}
+
+func Test_ShouldNotRestoreSubtaskOfTrashedParent(t *testing.T) {
+	trashTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Plan trip"},
+			{Id: 2, UserId: 1, Title: "Book flights", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Renew passport"},
+			{Id: 4, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldNotRestoreSubtaskOfTrashedParent", func(t *testing.T) {
+		trashTodoService.DeleteTodo(1, 1)
+		_, err := trashTodoService.RestoreTodo(1, 2)
+		assert.Equal(t, "The parent of this todo is in the trash, restore it first", err.Error())
+	})
+}
+
+func Test_ShouldPermanentlyDeleteOnlyTrashedTodos(t *testing.T) {
+	trashTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Plan trip"},
+			{Id: 2, UserId: 1, Title: "Book flights", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Renew passport"},
+			{Id: 4, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldPermanentlyDeleteOnlyTrashedTodos", func(t *testing.T) {
+		err := trashTodoService.PermanentlyDeleteTodo(1, 3)
+		assert.Equal(t, "Todo with id 3 not found in trash", err.Error())
+
+		trashTodoService.DeleteTodo(1, 3)
+		err = trashTodoService.PermanentlyDeleteTodo(1, 3)
+		assert.Nil(t, err)
+		_, err = trashTodoService.RestoreTodo(1, 3)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_ShouldPurgeTrashAfterRetentionPeriod(t *testing.T) {
+	trashTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Plan trip"},
+			{Id: 2, UserId: 1, Title: "Book flights", ParentId: intPointer(1)},
+			{Id: 3, UserId: 1, Title: "Renew passport"},
+			{Id: 4, UserId: 2, Title: "Someone else's todo"},
+		},
+	}).TodoService
+
+	t.Run("ShouldPurgeTrashAfterRetentionPeriod", func(t *testing.T) {
+		trashTodoService.DeleteTodo(1, 3)
+		purgedCount, _ := trashTodoService.PurgeTrash(time.Hour)
+		assert.Equal(t, int64(0), purgedCount)
+
+		purgedCount, _ = trashTodoService.PurgeTrash(-time.Hour)
+		assert.Equal(t, int64(1), purgedCount)
+	})
+}
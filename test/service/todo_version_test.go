@@ -0,0 +1,53 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+	"todo-app--go-gin/service"
+)
+
+func Test_ShouldIncrementVersionOnWrite(t *testing.T) {
+	versionTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Draft proposal", Description: "First version", Version: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldIncrementVersionOnWrite", func(t *testing.T) {
+		todo, _ := versionTodoService.ToggleTodo(1, 1, request.TodoToggle{VersionPrecondition: request.VersionPrecondition{ExpectedVersion: intPointer(1)}})
+		assert.Equal(t, 2, todo.Version)
+
+		todoPatch, _ := request.ParseTodoMergePatch([]byte(`{"title": "Final proposal"}`))
+		todoPatch.ExpectedVersion = intPointer(2)
+		todo, err := versionTodoService.PatchTodo(1, 1, todoPatch)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, todo.Version)
+	})
+}
+
+func Test_ShouldRejectWriteWithStaleVersion(t *testing.T) {
+	versionTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Draft proposal", Description: "First version", Version: 1},
+		},
+	}).TodoService
+
+	t.Run("ShouldRejectUpdateWithStaleVersion", func(t *testing.T) {
+		versionTodoService.UpdateTodo(1, request.TodoUpdate{UserId: 1, Title: "Draft proposal v2", Description: "Second version"})
+		_, err := versionTodoService.UpdateTodo(1, request.TodoUpdate{UserId: 1, Title: "Draft proposal v3", Description: "Third version", VersionPrecondition: request.VersionPrecondition{ExpectedVersion: intPointer(1)}})
+		assert.Equal(t, service.ErrTodoVersionMismatch, err)
+
+		todo, _ := versionTodoService.GetTodoById(1, 1)
+		assert.Equal(t, "Draft proposal v2", todo.Title)
+	})
+
+	t.Run("ShouldRejectDeleteWithStaleVersion", func(t *testing.T) {
+		err := versionTodoService.DeleteTodoWithVersion(1, 1, intPointer(1))
+		assert.Equal(t, service.ErrTodoVersionMismatch, err)
+
+		err = versionTodoService.DeleteTodoWithVersion(1, 1, intPointer(2))
+		assert.Nil(t, err)
+	})
+}
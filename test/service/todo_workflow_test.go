@@ -0,0 +1,160 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todo-app--go-gin/domain"
+	"todo-app--go-gin/domain/request"
+)
+
+func newReviewWorkflow(userId int) domain.Workflow {
+	return domain.Workflow{
+		UserId: userId,
+		Statuses: []domain.WorkflowStatus{
+			{Key: "backlog", Name: "Backlog"},
+			{Key: "review", Name: "Review"},
+			{Key: "shipped", Name: "Shipped", IsDone: true},
+		},
+		Transitions: []domain.WorkflowTransition{
+			{From: "backlog", To: "review"},
+			{From: "review", To: "backlog"},
+			{From: "review", To: "shipped"},
+		},
+	}
+}
+
+func Test_ShouldGetBoardGroupedByStatus(t *testing.T) {
+	workflowTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write tests", Description: "Board tests", Status: domain.StatusTodo, Position: 1024},
+			{Id: 2, UserId: 1, Title: "Fix login", Description: "Session expiry", Status: domain.StatusInProgress, Position: 2048},
+			{Id: 3, UserId: 1, Title: "Release notes", Description: "Version two", Status: domain.StatusDone, IsCompleted: true, Position: 3072},
+			{Id: 4, UserId: 2, Title: "Design review", Description: "New landing page", Status: "backlog", Position: 1024},
+		},
+		Workflows: []domain.Workflow{newReviewWorkflow(2)},
+	}).TodoService
+
+	t.Run("ShouldGetBoardGroupedByStatus", func(t *testing.T) {
+		board, err := workflowTodoService.GetBoard(1, request.TodoFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, 4, len(board.Columns))
+		assert.Equal(t, domain.StatusTodo, board.Columns[0].Key)
+		assert.Equal(t, 1, board.Columns[0].Todos[0].Id)
+		assert.Equal(t, 2, board.Columns[1].Todos[0].Id)
+		assert.Equal(t, 0, board.Columns[2].Total)
+		assert.Equal(t, 3, board.Columns[3].Todos[0].Id)
+	})
+
+	t.Run("ShouldGetBoardWithConfiguredWorkflow", func(t *testing.T) {
+		board, _ := workflowTodoService.GetBoard(2, request.TodoFilter{})
+		assert.Equal(t, []string{"backlog", "review", "shipped"}, []string{board.Columns[0].Key, board.Columns[1].Key, board.Columns[2].Key})
+		assert.Equal(t, 1, board.Columns[0].Total)
+	})
+}
+
+func Test_ShouldChangeTodoStatus(t *testing.T) {
+	workflowTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write tests", Description: "Board tests", Status: domain.StatusTodo, Position: 1024},
+			{Id: 2, UserId: 1, Title: "Fix login", Description: "Session expiry", Status: domain.StatusInProgress, Position: 2048},
+			{Id: 3, UserId: 1, Title: "Release notes", Description: "Version two", Status: domain.StatusDone, IsCompleted: true, Position: 3072},
+			{Id: 4, UserId: 2, Title: "Design review", Description: "New landing page", Status: "backlog", Position: 1024},
+		},
+		Workflows: []domain.Workflow{newReviewWorkflow(2)},
+	}).TodoService
+
+	t.Run("ShouldChangeTodoStatusAndDeriveCompletion", func(t *testing.T) {
+		todo, err := workflowTodoService.ChangeTodoStatus(1, 2, request.TodoStatusChange{Status: domain.StatusDone})
+		assert.Nil(t, err)
+		assert.Equal(t, domain.StatusDone, todo.Status)
+		assert.True(t, todo.IsCompleted)
+
+		todo, _ = workflowTodoService.ChangeTodoStatus(1, 2, request.TodoStatusChange{Status: domain.StatusBlocked})
+		assert.False(t, todo.IsCompleted)
+	})
+
+	t.Run("ShouldNotChangeTodoToUnknownStatus", func(t *testing.T) {
+		_, err := workflowTodoService.ChangeTodoStatus(1, 1, request.TodoStatusChange{Status: "archived"})
+		assert.Equal(t, "Unknown status archived", err.Error())
+	})
+
+	t.Run("ShouldNotChangeTodoStatusWithDisallowedTransition", func(t *testing.T) {
+		_, err := workflowTodoService.ChangeTodoStatus(2, 4, request.TodoStatusChange{Status: "shipped"})
+		assert.Equal(t, "Todo cannot move from status backlog to shipped", err.Error())
+
+		todo, err := workflowTodoService.ChangeTodoStatus(2, 4, request.TodoStatusChange{Status: "review"})
+		assert.Nil(t, err)
+		assert.Equal(t, "review", todo.Status)
+	})
+}
+
+func Test_ShouldKeepStatusInSyncWithCompletion(t *testing.T) {
+	workflowTodoService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write tests", Description: "Board tests", Status: domain.StatusTodo, Position: 1024},
+			{Id: 2, UserId: 1, Title: "Fix login", Description: "Session expiry", Status: domain.StatusInProgress, Position: 2048},
+			{Id: 3, UserId: 1, Title: "Release notes", Description: "Version two", Status: domain.StatusDone, IsCompleted: true, Position: 3072},
+			{Id: 4, UserId: 2, Title: "Design review", Description: "New landing page", Status: "backlog", Position: 1024},
+		},
+		Workflows: []domain.Workflow{newReviewWorkflow(2)},
+	}).TodoService
+
+	t.Run("ShouldMoveToggledTodoToDoneStatus", func(t *testing.T) {
+		todo, _ := workflowTodoService.ToggleTodo(1, 2, request.TodoToggle{})
+		assert.Equal(t, domain.StatusDone, todo.Status)
+
+		todo, _ = workflowTodoService.ToggleTodo(1, 2, request.TodoToggle{})
+		assert.Equal(t, domain.StatusTodo, todo.Status)
+	})
+
+	t.Run("ShouldAddTodoInFirstOpenStatus", func(t *testing.T) {
+		todo, err := workflowTodoService.AddTodo(request.TodoCreate{UserId: 2, Title: "Copy edits", Description: "Landing page copy"})
+		assert.Nil(t, err)
+		assert.Equal(t, "backlog", todo.Status)
+	})
+}
+
+func Test_ShouldUpdateWorkflow(t *testing.T) {
+	workflowService := NewTestServices(TestFixture{
+		Todos: []domain.Todo{
+			{Id: 1, UserId: 1, Title: "Write tests", Description: "Board tests", Status: domain.StatusTodo, Position: 1024},
+			{Id: 2, UserId: 1, Title: "Fix login", Description: "Session expiry", Status: domain.StatusInProgress, Position: 2048},
+			{Id: 3, UserId: 1, Title: "Release notes", Description: "Version two", Status: domain.StatusDone, IsCompleted: true, Position: 3072},
+			{Id: 4, UserId: 2, Title: "Design review", Description: "New landing page", Status: "backlog", Position: 1024},
+		},
+	}).WorkflowService
+
+	t.Run("ShouldGetDefaultWorkflow", func(t *testing.T) {
+		workflow, err := workflowService.GetWorkflow(1)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, len(workflow.Statuses))
+		assert.Empty(t, workflow.Transitions)
+	})
+
+	t.Run("ShouldUpdateWorkflow", func(t *testing.T) {
+		_, err := workflowService.UpdateWorkflow(request.WorkflowUpdate{UserId: 2, Statuses: []request.WorkflowStatus{
+			{Key: "backlog", Name: "Backlog"},
+			{Key: "done", Name: "Done", IsDone: true},
+		}, Transitions: []request.WorkflowTransition{{From: "backlog", To: "done"}}})
+		assert.Nil(t, err)
+
+		workflow, _ := workflowService.GetWorkflow(2)
+		assert.Equal(t, "backlog", workflow.Statuses[0].Key)
+		assert.Equal(t, 1, len(workflow.Transitions))
+	})
+
+	t.Run("ShouldNotRemoveStatusInUse", func(t *testing.T) {
+		_, err := workflowService.UpdateWorkflow(request.WorkflowUpdate{UserId: 1, Statuses: []request.WorkflowStatus{
+			{Key: "todo", Name: "To do"},
+			{Key: "done", Name: "Done", IsDone: true},
+		}})
+		assert.Equal(t, "Status in_progress is still used by todos", err.Error())
+	})
+
+	t.Run("ShouldNotUpdateWorkflowWithoutDoneStatus", func(t *testing.T) {
+		_, err := workflowService.UpdateWorkflow(request.WorkflowUpdate{UserId: 3, Statuses: []request.WorkflowStatus{
+			{Key: "todo", Name: "To do"},
+		}})
+		assert.Equal(t, "Workflow must have at least one open and one done status", err.Error())
+	})
+}
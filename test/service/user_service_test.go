@@ -82,8 +82,8 @@ func Test_ShouldNotGetUserByEmailForValidation(t *testing.T) {
 func Test_ShouldAddUser(t *testing.T) {
 	t.Run("ShouldAddUser", func(t *testing.T) {
 		userService.AddUser(request.UserCreate{
-			Username: "user1",
-			Email:    "user1@mail.com",
+			Username: "user5",
+			Email:    "user5@mail.com",
 			Password: "12345",
 		})
 		actualUsers, _ := userService.GetAllUsers()
@@ -99,7 +99,7 @@ func Test_ShouldNotAddUserValidationErrorUsername(t *testing.T) {
 			Password: "12345",
 		})
 		actualUsers, _ := userService.GetAllUsers()
-		assert.Equal(t, 4, len(actualUsers))
+		assert.Equal(t, 5, len(actualUsers))
 		assert.Equal(t, "Username cannot be empty", err.Error())
 	})
 }
@@ -112,7 +112,7 @@ func Test_ShouldNotAddUserValidationErrorEmail(t *testing.T) {
 			Password: "12345",
 		})
 		actualUsers, _ := userService.GetAllUsers()
-		assert.Equal(t, 4, len(actualUsers))
+		assert.Equal(t, 5, len(actualUsers))
 		assert.Equal(t, "Invalid email format", err.Error())
 	})
 }
@@ -125,7 +125,7 @@ func Test_ShouldNotAddUserValidationErrorPassword(t *testing.T) {
 			Password: "1234",
 		})
 		actualUsers, _ := userService.GetAllUsers()
-		assert.Equal(t, 4, len(actualUsers))
+		assert.Equal(t, 5, len(actualUsers))
 		assert.Equal(t, "Password must be at least 5 characters long", err.Error())
 	})
 }
@@ -171,15 +171,15 @@ func Test_ShouldDeleteUser(t *testing.T) {
 	t.Run("ShouldDeleteUser", func(t *testing.T) {
 		userService.DeleteUser(1)
 		actualUsers, _ := userService.GetAllUsers()
-		assert.Equal(t, 3, len(actualUsers))
+		assert.Equal(t, 4, len(actualUsers))
 	})
 }
 
 func Test_ShouldNotDeleteUser(t *testing.T) {
 	t.Run("ShouldNotDeleteUser", func(t *testing.T) {
-		err := userService.DeleteUser(5)
+		err := userService.DeleteUser(6)
 		actualUsers, _ := userService.GetAllUsers()
 		assert.Equal(t, 4, len(actualUsers))
-		assert.Equal(t, "Todo with id 5 not found", err.Error())
+		assert.Equal(t, "Todo with id 6 not found", err.Error())
 	})
 }